@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtransform // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/internal/logtransform"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// LogRecords transforms a slice of OpenTelemetry log records into a slice
+// of OTLP ResourceLogs.
+func LogRecords(lrs []sdklog.ReadOnlyLogRecord) []*logspb.ResourceLogs {
+	if len(lrs) == 0 {
+		return nil
+	}
+
+	rlm := make(map[attribute.Distinct]*logspb.ResourceLogs)
+
+	type illKey struct {
+		r  attribute.Distinct
+		il instrumentation.Library
+	}
+	illm := make(map[illKey]*logspb.InstrumentationLibraryLogs)
+
+	var resources int
+	for _, lr := range lrs {
+		if lr == nil {
+			continue
+		}
+
+		rKey := lr.Resource().Equivalent()
+		iKey := illKey{
+			r:  rKey,
+			il: lr.InstrumentationLibrary(),
+		}
+		ill, iOk := illm[iKey]
+		if !iOk {
+			// Either the resource or instrumentation library were unknown.
+			ill = &logspb.InstrumentationLibraryLogs{
+				InstrumentationLibrary: InstrumentationLibrary(lr.InstrumentationLibrary()),
+				Logs:                   []*logspb.LogRecord{},
+				SchemaUrl:              lr.InstrumentationLibrary().SchemaURL,
+			}
+		}
+		ill.Logs = append(ill.Logs, logRecord(lr))
+		illm[iKey] = ill
+
+		rl, rOk := rlm[rKey]
+		if !rOk {
+			resources++
+			// The resource was unknown.
+			rl = &logspb.ResourceLogs{
+				Resource:                   Resource(lr.Resource()),
+				InstrumentationLibraryLogs: []*logspb.InstrumentationLibraryLogs{ill},
+				SchemaUrl:                  lr.Resource().SchemaURL(),
+			}
+			rlm[rKey] = rl
+			continue
+		}
+
+		// The resource has been seen before. Check if the instrumentation
+		// library lookup was unknown because if so we need to add it to the
+		// ResourceLogs. Otherwise, the instrumentation library has already
+		// been seen and the append we did above will be included it in the
+		// InstrumentationLibraryLogs reference.
+		if !iOk {
+			rl.InstrumentationLibraryLogs = append(rl.InstrumentationLibraryLogs, ill)
+		}
+	}
+
+	// Transform the categorized map into a slice
+	rls := make([]*logspb.ResourceLogs, 0, resources)
+	for _, rl := range rlm {
+		rls = append(rls, rl)
+	}
+	return rls
+}
+
+// logRecord transforms a log record into an OTLP log record.
+func logRecord(lr sdklog.ReadOnlyLogRecord) *logspb.LogRecord {
+	if lr == nil {
+		return nil
+	}
+
+	l := &logspb.LogRecord{
+		TimeUnixNano:   uint64(lr.Timestamp().UnixNano()),
+		SeverityNumber: severityNumber(lr.Severity()),
+		SeverityText:   lr.SeverityText(),
+		Attributes:     KeyValues(lr.Attributes()),
+	}
+	if body := lr.Body(); body != "" {
+		l.Body = &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{
+				StringValue: body,
+			},
+		}
+	}
+
+	if sc := lr.SpanContext(); sc.IsValid() {
+		tid := sc.TraceID()
+		sid := sc.SpanID()
+		l.TraceId = tid[:]
+		l.SpanId = sid[:]
+		l.Flags = uint32(sc.TraceFlags())
+	}
+
+	return l
+}
+
+// severityNumber transforms a Severity into an OTLP severity number.
+func severityNumber(s log.Severity) logspb.SeverityNumber {
+	if s < log.SeverityTrace1 || s > log.SeverityFatal4 {
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+	// The OpenTelemetry severity values are defined to match the OTLP
+	// severity numbers.
+	return logspb.SeverityNumber(s)
+}
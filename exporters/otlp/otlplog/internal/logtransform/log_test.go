@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtransform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+func TestSeverityNumber(t *testing.T) {
+	for _, test := range []struct {
+		severity log.Severity
+		expected logspb.SeverityNumber
+	}{
+		{log.SeverityUndefined, logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED},
+		{log.SeverityTrace, logspb.SeverityNumber_SEVERITY_NUMBER_TRACE},
+		{log.SeverityDebug2, logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG2},
+		{log.SeverityInfo, logspb.SeverityNumber_SEVERITY_NUMBER_INFO},
+		{log.SeverityWarn3, logspb.SeverityNumber_SEVERITY_NUMBER_WARN3},
+		{log.SeverityError, logspb.SeverityNumber_SEVERITY_NUMBER_ERROR},
+		{log.SeverityFatal4, logspb.SeverityNumber_SEVERITY_NUMBER_FATAL4},
+		{log.Severity(25), logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED},
+		{log.Severity(-1), logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED},
+	} {
+		assert.Equal(t, test.expected, severityNumber(test.severity))
+	}
+}
+
+func TestNilLogRecords(t *testing.T) {
+	assert.Nil(t, LogRecords(nil))
+}
+
+func TestLogRecordData(t *testing.T) {
+	// March 31, 2020 5:01:26 1234nanos (UTC)
+	timestamp := time.Unix(1585674086, 1234)
+	record := logtest.LogRecordStub{
+		Timestamp:         timestamp,
+		ObservedTimestamp: timestamp.Add(time.Second),
+		Severity:          log.SeverityWarn,
+		SeverityText:      "warning",
+		Body:              "disk almost full",
+		Attributes: []attribute.KeyValue{
+			attribute.Int64("free_bytes", 512),
+		},
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F},
+			SpanID:     trace.SpanID{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Resource: resource.NewWithAttributes(
+			"http://example.com/custom-resource-schema",
+			attribute.String("rk1", "rv1"),
+			attribute.Int64("rk2", 5),
+		),
+		InstrumentationLibrary: instrumentation.Library{
+			Name:      "go.opentelemetry.io/test/otel",
+			Version:   "v0.0.1",
+			SchemaURL: semconv.SchemaURL,
+		},
+	}
+
+	expected := &logspb.LogRecord{
+		TimeUnixNano:   uint64(timestamp.UnixNano()),
+		SeverityNumber: logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+		SeverityText:   "warning",
+		Body: &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{StringValue: "disk almost full"},
+		},
+		Attributes: KeyValues(record.Attributes),
+		Flags:      1,
+		TraceId:    []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F},
+		SpanId:     []byte{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8},
+	}
+
+	got := LogRecords(logtest.LogRecordStubs{record}.Snapshots())
+	require.Len(t, got, 1)
+
+	assert.Equal(t, got[0].GetResource(), Resource(record.Resource))
+	assert.Equal(t, got[0].SchemaUrl, record.Resource.SchemaURL())
+	ilLogs := got[0].GetInstrumentationLibraryLogs()
+	require.Len(t, ilLogs, 1)
+	assert.Equal(t, ilLogs[0].SchemaUrl, record.InstrumentationLibrary.SchemaURL)
+	assert.Equal(t, ilLogs[0].GetInstrumentationLibrary(), InstrumentationLibrary(record.InstrumentationLibrary))
+	require.Len(t, ilLogs[0].Logs, 1)
+
+	if diff := cmp.Diff(expected, ilLogs[0].Logs[0], cmp.Comparer(proto.Equal)); diff != "" {
+		t.Fatalf("transformed log record differs %v\n", diff)
+	}
+}
+
+// A log record emitted outside of a span should not carry trace context.
+func TestLogRecordDataWithoutSpanContext(t *testing.T) {
+	got := LogRecords(logtest.LogRecordStubs{{Body: "message"}}.Snapshots())
+	require.Len(t, got, 1)
+	lr := got[0].GetInstrumentationLibraryLogs()[0].GetLogs()[0]
+
+	assert.Nil(t, lr.GetTraceId())
+	assert.Nil(t, lr.GetSpanId())
+	assert.Equal(t, uint32(0), lr.GetFlags())
+}
+
+func TestLogRecordDataEmptyBody(t *testing.T) {
+	got := LogRecords(logtest.LogRecordStubs{{}}.Snapshots())
+	require.Len(t, got, 1)
+	assert.Nil(t, got[0].GetInstrumentationLibraryLogs()[0].GetLogs()[0].GetBody())
+}
+
+func TestLogRecordsGroupedByResourceAndLibrary(t *testing.T) {
+	r1 := resource.NewSchemaless(attribute.String("r", "1"))
+	r2 := resource.NewSchemaless(attribute.String("r", "2"))
+	lib1 := instrumentation.Library{Name: "lib1"}
+	lib2 := instrumentation.Library{Name: "lib2"}
+
+	got := LogRecords(logtest.LogRecordStubs{
+		{Resource: r1, InstrumentationLibrary: lib1},
+		{Resource: r1, InstrumentationLibrary: lib1},
+		{Resource: r1, InstrumentationLibrary: lib2},
+		{Resource: r2, InstrumentationLibrary: lib1},
+	}.Snapshots())
+	require.Len(t, got, 2)
+
+	counts := make(map[string]int)
+	for _, rl := range got {
+		for _, ill := range rl.GetInstrumentationLibraryLogs() {
+			key := rl.GetResource().GetAttributes()[0].GetValue().GetStringValue() + "/" + ill.GetInstrumentationLibrary().GetName()
+			counts[key] += len(ill.GetLogs())
+		}
+	}
+	assert.Equal(t, map[string]int{"1/lib1": 2, "1/lib2": 1, "2/lib1": 1}, counts)
+}
+
+func TestLogRecordDataNilResource(t *testing.T) {
+	assert.NotPanics(t, func() { LogRecords(logtest.LogRecordStubs{{}}.Snapshots()) })
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogtest // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/internal/otlplogtest"
+
+import (
+	"sort"
+
+	collectorlogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// LogsCollector mocks a collector for the end-to-end testing.
+type LogsCollector interface {
+	Stop() error
+	GetResourceLogs() []*logspb.ResourceLogs
+}
+
+// LogsStorage stores the log records. Mock collectors can use it to
+// store log records they have received.
+type LogsStorage struct {
+	rlm      map[string]*logspb.ResourceLogs
+	logCount int
+}
+
+// NewLogsStorage creates a new logs storage.
+func NewLogsStorage() LogsStorage {
+	return LogsStorage{
+		rlm: make(map[string]*logspb.ResourceLogs),
+	}
+}
+
+// AddLogs adds log records to the logs storage.
+func (s *LogsStorage) AddLogs(request *collectorlogspb.ExportLogsServiceRequest) {
+	for _, rl := range request.GetResourceLogs() {
+		rstr := resourceString(rl.Resource)
+		if existingRl, ok := s.rlm[rstr]; !ok {
+			s.rlm[rstr] = rl
+			if len(rl.InstrumentationLibraryLogs) == 0 {
+				rl.InstrumentationLibraryLogs = []*logspb.InstrumentationLibraryLogs{
+					{
+						Logs: []*logspb.LogRecord{},
+					},
+				}
+			}
+			s.logCount += len(rl.InstrumentationLibraryLogs[0].Logs)
+		} else {
+			if len(rl.InstrumentationLibraryLogs) > 0 {
+				newLogs := rl.InstrumentationLibraryLogs[0].GetLogs()
+				existingRl.InstrumentationLibraryLogs[0].Logs =
+					append(existingRl.InstrumentationLibraryLogs[0].Logs,
+						newLogs...)
+				s.logCount += len(newLogs)
+			}
+		}
+	}
+}
+
+// GetLogs returns the stored log records.
+func (s *LogsStorage) GetLogs() []*logspb.LogRecord {
+	logs := make([]*logspb.LogRecord, 0, s.logCount)
+	for _, rl := range s.rlm {
+		logs = append(logs, rl.InstrumentationLibraryLogs[0].Logs...)
+	}
+	return logs
+}
+
+// GetResourceLogs returns the stored resource logs.
+func (s *LogsStorage) GetResourceLogs() []*logspb.ResourceLogs {
+	rls := make([]*logspb.ResourceLogs, 0, len(s.rlm))
+	for _, rl := range s.rlm {
+		rls = append(rls, rl)
+	}
+	return rls
+}
+
+func resourceString(res *resourcepb.Resource) string {
+	sAttrs := sortedAttributes(res.GetAttributes())
+	rstr := ""
+	for _, attr := range sAttrs {
+		rstr = rstr + attr.String()
+	}
+	return rstr
+}
+
+func sortedAttributes(attrs []*commonpb.KeyValue) []*commonpb.KeyValue {
+	sort.Slice(attrs[:], func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+	return attrs
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogtest // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/internal/otlplogtest"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SingleReadOnlyLogRecord returns a one-element slice with a read-only log
+// record. It may be useful for testing driver's log export.
+func SingleReadOnlyLogRecord() []sdklog.ReadOnlyLogRecord {
+	return logtest.LogRecordStubs{
+		{
+			Timestamp:         time.Date(2020, time.December, 8, 20, 23, 0, 0, time.UTC),
+			ObservedTimestamp: time.Date(2020, time.December, 8, 20, 23, 1, 0, time.UTC),
+			Severity:          log.SeverityInfo,
+			SeverityText:      "INFO",
+			Body:              "foo",
+			Attributes:        []attribute.KeyValue{},
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    trace.TraceID{2, 3, 4, 5, 6, 7, 8, 9, 2, 3, 4, 5, 6, 7, 8, 9},
+				SpanID:     trace.SpanID{3, 4, 5, 6, 7, 8, 9, 0},
+				TraceFlags: trace.FlagsSampled,
+			}),
+			Resource: resource.NewSchemaless(attribute.String("a", "b")),
+			InstrumentationLibrary: instrumentation.Library{
+				Name:    "bar",
+				Version: "0.0.0",
+			},
+		},
+	}.Snapshots()
+}
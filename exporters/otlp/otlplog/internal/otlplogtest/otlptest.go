@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogtest // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/internal/otlplogtest"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+)
+
+// RunEndToEndTest can be used by otlplog.Client tests to validate
+// themselves.
+func RunEndToEndTest(ctx context.Context, t *testing.T, exp *otlplog.Exporter, logsCollector LogsCollector) {
+	pOpts := []sdklog.LoggerProviderOption{
+		sdklog.WithBatcher(
+			exp,
+			// add following two options to ensure flush
+			sdklog.WithBatchTimeout(5*time.Second),
+			sdklog.WithMaxExportBatchSize(10),
+		),
+	}
+	lp1 := sdklog.NewLoggerProvider(append(pOpts,
+		sdklog.WithResource(resource.NewSchemaless(
+			attribute.String("rk1", "rv11)"),
+			attribute.Int64("rk2", 5),
+		)))...)
+
+	lp2 := sdklog.NewLoggerProvider(append(pOpts,
+		sdklog.WithResource(resource.NewSchemaless(
+			attribute.String("rk1", "rv12)"),
+			attribute.Float64("rk3", 6.5),
+		)))...)
+
+	l1 := lp1.Logger("test-logger1")
+	l2 := lp2.Logger("test-logger2")
+	// Now emit few log records
+	m := 4
+	for i := 0; i < m; i++ {
+		l1.Emit(ctx, log.Record{
+			Severity:   log.SeverityInfo,
+			Body:       "message",
+			Attributes: []attribute.KeyValue{attribute.Int64("i", int64(i))},
+		})
+		l2.Emit(ctx, log.Record{
+			Severity:   log.SeverityInfo,
+			Body:       "message",
+			Attributes: []attribute.KeyValue{attribute.Int64("i", int64(i))},
+		})
+	}
+
+	func() {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := lp1.Shutdown(ctx); err != nil {
+			t.Fatalf("failed to shut down a logger provider 1: %v", err)
+		}
+		if err := lp2.Shutdown(ctx); err != nil {
+			t.Fatalf("failed to shut down a logger provider 2: %v", err)
+		}
+	}()
+
+	// Wait >2 cycles.
+	<-time.After(40 * time.Millisecond)
+
+	// Now shutdown the exporter
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := exp.Shutdown(ctx); err != nil {
+		t.Fatalf("failed to stop the exporter: %v", err)
+	}
+
+	// Shutdown the collector too so that we can begin
+	// verification checks of expected data back.
+	_ = logsCollector.Stop()
+
+	// Now verify that we only got two resources
+	rls := logsCollector.GetResourceLogs()
+	if got, want := len(rls), 2; got != want {
+		t.Fatalf("resource logs count: got %d, want %d\n", got, want)
+	}
+
+	// Now verify log records and attributes for each resource logs.
+	for _, rl := range rls {
+		if len(rl.InstrumentationLibraryLogs) == 0 {
+			t.Fatalf("zero Instrumentation Library Logs")
+		}
+		if got, want := len(rl.InstrumentationLibraryLogs[0].Logs), m; got != want {
+			t.Fatalf("log record counts: got %d, want %d", got, want)
+		}
+		attrMap := map[int64]bool{}
+		for _, l := range rl.InstrumentationLibraryLogs[0].Logs {
+			if gotBody, want := l.Body.GetStringValue(), "message"; gotBody != want {
+				t.Fatalf("log record body: got %s, want %s", gotBody, want)
+			}
+			attrMap[l.Attributes[0].Value.Value.(*commonpb.AnyValue_IntValue).IntValue] = true
+		}
+		if got, want := len(attrMap), m; got != want {
+			t.Fatalf("log record attribute unique values: got %d  want %d", got, want)
+		}
+		for i := 0; i < m; i++ {
+			_, ok := attrMap[int64(i)]
+			if !ok {
+				t.Fatalf("log record with attribute %d missing", i)
+			}
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlploghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/internal/otlplogtest"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+)
+
+const (
+	relOtherLogsPath = "post/logs/here"
+	otherLogsPath    = "/post/logs/here"
+)
+
+var (
+	testHeaders = map[string]string{
+		"Otel-Go-Key-1": "somevalue",
+		"Otel-Go-Key-2": "someothervalue",
+	}
+)
+
+func TestEndToEnd(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []otlploghttp.Option
+		mcCfg mockCollectorConfig
+		tls   bool
+	}{
+		{
+			name: "no extra options",
+			opts: nil,
+		},
+		{
+			name: "with gzip compression",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithCompression(otlploghttp.GzipCompression),
+			},
+		},
+		{
+			name: "retry",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithRetry(otlploghttp.RetryConfig{
+					Enabled:         true,
+					InitialInterval: time.Nanosecond,
+					MaxInterval:     time.Nanosecond,
+					// Do not stop trying.
+					MaxElapsedTime: 0,
+				}),
+			},
+			mcCfg: mockCollectorConfig{
+				InjectHTTPStatus: []int{503, 429},
+			},
+		},
+		{
+			name: "retry with gzip compression",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithCompression(otlploghttp.GzipCompression),
+				otlploghttp.WithRetry(otlploghttp.RetryConfig{
+					Enabled:         true,
+					InitialInterval: time.Nanosecond,
+					MaxInterval:     time.Nanosecond,
+					// Do not stop trying.
+					MaxElapsedTime: 0,
+				}),
+			},
+			mcCfg: mockCollectorConfig{
+				InjectHTTPStatus: []int{503, 503},
+			},
+		},
+		{
+			name: "retry with throttle",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithRetry(otlploghttp.RetryConfig{
+					Enabled:         true,
+					InitialInterval: time.Nanosecond,
+					MaxInterval:     time.Nanosecond,
+					// Do not stop trying.
+					MaxElapsedTime: 0,
+				}),
+			},
+			mcCfg: mockCollectorConfig{
+				InjectHTTPStatus: []int{503},
+				InjectResponseHeader: []map[string]string{
+					{"Retry-After": "10"},
+				},
+			},
+		},
+		{
+			name: "with empty paths (forced to defaults)",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithURLPath(""),
+			},
+		},
+		{
+			name: "with relative paths",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithURLPath(relOtherLogsPath),
+			},
+			mcCfg: mockCollectorConfig{
+				LogsURLPath: otherLogsPath,
+			},
+		},
+		{
+			name: "with TLS",
+			opts: nil,
+			mcCfg: mockCollectorConfig{
+				WithTLS: true,
+			},
+			tls: true,
+		},
+		{
+			name: "with extra headers",
+			opts: []otlploghttp.Option{
+				otlploghttp.WithHeaders(testHeaders),
+			},
+			mcCfg: mockCollectorConfig{
+				ExpectedHeaders: testHeaders,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := runMockCollector(t, tc.mcCfg)
+			defer mc.MustStop(t)
+			allOpts := []otlploghttp.Option{
+				otlploghttp.WithEndpoint(mc.Endpoint()),
+			}
+			if tc.tls {
+				tlsConfig := mc.ClientTLSConfig()
+				require.NotNil(t, tlsConfig)
+				allOpts = append(allOpts, otlploghttp.WithTLSClientConfig(tlsConfig))
+			} else {
+				allOpts = append(allOpts, otlploghttp.WithInsecure())
+			}
+			allOpts = append(allOpts, tc.opts...)
+			client := otlploghttp.NewClient(allOpts...)
+			ctx := context.Background()
+			exporter, err := otlplog.New(ctx, client)
+			if assert.NoError(t, err) {
+				defer func() {
+					assert.NoError(t, exporter.Shutdown(ctx))
+				}()
+				otlplogtest.RunEndToEndTest(ctx, t, exporter, mc)
+			}
+		})
+	}
+}
+
+func TestExporterShutdown(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer func() {
+		_ = mc.Stop()
+	}()
+
+	<-time.After(5 * time.Millisecond)
+
+	otlplogtest.RunExporterShutdownTest(t, func() otlplog.Client {
+		return otlploghttp.NewClient(
+			otlploghttp.WithInsecure(),
+			otlploghttp.WithEndpoint(mc.endpoint),
+		)
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	mcCfg := mockCollectorConfig{
+		InjectDelay: 100 * time.Millisecond,
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	client := otlploghttp.NewClient(
+		otlploghttp.WithEndpoint(mc.Endpoint()),
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithTimeout(50*time.Millisecond),
+	)
+	ctx := context.Background()
+	exporter, err := otlplog.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportLogRecords(ctx, otlplogtest.SingleReadOnlyLogRecord())
+	assert.Equal(t, true, os.IsTimeout(err))
+}
+
+func TestNoRetry(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusBadRequest},
+	})
+	defer mc.MustStop(t)
+	driver := otlploghttp.NewClient(
+		otlploghttp.WithEndpoint(mc.Endpoint()),
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 1 * time.Nanosecond,
+			MaxInterval:     1 * time.Nanosecond,
+			// Never stop retry of retry-able status.
+			MaxElapsedTime: 0,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlplog.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportLogRecords(ctx, otlplogtest.SingleReadOnlyLogRecord())
+	assert.Error(t, err)
+	assert.Equal(t, fmt.Sprintf("failed to send logs to http://%s/v1/logs: 400 Bad Request", mc.endpoint), err.Error())
+	assert.Empty(t, mc.GetLogs())
+}
+
+func TestEmptyData(t *testing.T) {
+	mcCfg := mockCollectorConfig{}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlploghttp.NewClient(
+		otlploghttp.WithEndpoint(mc.Endpoint()),
+		otlploghttp.WithInsecure(),
+	)
+	ctx := context.Background()
+	exporter, err := otlplog.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	assert.NoError(t, err)
+	err = exporter.ExportLogRecords(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, mc.GetLogs())
+}
+
+func TestCancelledContext(t *testing.T) {
+	mcCfg := mockCollectorConfig{}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlploghttp.NewClient(
+		otlploghttp.WithEndpoint(mc.Endpoint()),
+		otlploghttp.WithInsecure(),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	exporter, err := otlplog.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(context.Background()))
+	}()
+	cancel()
+	err = exporter.ExportLogRecords(ctx, otlplogtest.SingleReadOnlyLogRecord())
+	assert.Error(t, err)
+	assert.Empty(t, mc.GetLogs())
+}
+
+func TestDeadlineContext(t *testing.T) {
+	statuses := make([]int, 0, 5)
+	for i := 0; i < cap(statuses); i++ {
+		statuses = append(statuses, http.StatusTooManyRequests)
+	}
+	mcCfg := mockCollectorConfig{
+		InjectHTTPStatus: statuses,
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlploghttp.NewClient(
+		otlploghttp.WithEndpoint(mc.Endpoint()),
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 1 * time.Hour,
+			MaxInterval:     1 * time.Hour,
+			// Never stop retry of retry-able status.
+			MaxElapsedTime: 0,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlplog.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(context.Background()))
+	}()
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	err = exporter.ExportLogRecords(ctx, otlplogtest.SingleReadOnlyLogRecord())
+	assert.Error(t, err)
+	assert.Empty(t, mc.GetLogs())
+}
+
+func TestStopWhileExporting(t *testing.T) {
+	statuses := make([]int, 0, 5)
+	for i := 0; i < cap(statuses); i++ {
+		statuses = append(statuses, http.StatusTooManyRequests)
+	}
+	mcCfg := mockCollectorConfig{
+		InjectHTTPStatus: statuses,
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlploghttp.NewClient(
+		otlploghttp.WithEndpoint(mc.Endpoint()),
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 1 * time.Hour,
+			MaxInterval:     1 * time.Hour,
+			// Never stop retry of retry-able status.
+			MaxElapsedTime: 0,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlplog.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	doneCh := make(chan struct{})
+	go func() {
+		err := exporter.ExportLogRecords(ctx, otlplogtest.SingleReadOnlyLogRecord())
+		assert.Error(t, err)
+		assert.Empty(t, mc.GetLogs())
+		close(doneCh)
+	}()
+	<-time.After(time.Second)
+	err = exporter.Shutdown(ctx)
+	assert.NoError(t, err)
+	<-doneCh
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlploghttp_test
+
+import (
+	"context"
+	"log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const (
+	instrumentationName    = "github.com/instrumentron"
+	instrumentationVersion = "v0.1.0"
+)
+
+func newResource() *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String("otlplog-example"),
+		semconv.ServiceVersionKey.String("0.0.1"),
+	)
+}
+
+func installExportPipeline(ctx context.Context) (*sdklog.LoggerProvider, func()) {
+	client := otlploghttp.NewClient()
+	exporter, err := otlplog.New(ctx, client)
+	if err != nil {
+		log.Fatalf("creating OTLP log exporter: %v", err)
+	}
+
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithBatcher(exporter),
+		sdklog.WithResource(newResource()),
+	)
+
+	return loggerProvider, func() {
+		if err := loggerProvider.Shutdown(ctx); err != nil {
+			log.Fatalf("stopping logger provider: %v", err)
+		}
+	}
+}
+
+func Example() {
+	ctx := context.Background()
+	loggerProvider, cleanup := installExportPipeline(ctx)
+	defer cleanup()
+
+	logger := loggerProvider.Logger(
+		instrumentationName,
+		otellog.WithInstrumentationVersion(instrumentationVersion),
+		otellog.WithSchemaURL(semconv.SchemaURL),
+	)
+	logger.Emit(ctx, otellog.Record{
+		Severity:   otellog.SeverityInfo,
+		Body:       "the answer is 42",
+		Attributes: []attribute.KeyValue{attribute.Int("answer", 42)},
+	})
+}
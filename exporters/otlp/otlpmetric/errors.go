@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetric // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrorKind describes why an export request failed.
+type ErrorKind int
+
+const (
+	// TransportFailure means the request did not reach the collector, or no
+	// valid response was received from it. For example, the connection could
+	// not be established, the request timed out, or the collector was
+	// unavailable.
+	TransportFailure ErrorKind = iota
+	// Throttled means the collector asked to retry the request later, and
+	// the request was not accepted before the retries stopped.
+	Throttled
+	// Rejected means the collector permanently rejected the request.
+	// Sending the same request again will fail the same way.
+	Rejected
+)
+
+// String returns the name of k.
+func (k ErrorKind) String() string {
+	switch k {
+	case TransportFailure:
+		return "transport failure"
+	case Throttled:
+		return "throttled"
+	case Rejected:
+		return "rejected"
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// ExportError is the error returned by the OTLP clients when an export
+// request is not accepted by the collector. Use errors.As to retrieve it from
+// the errors returned by an Exporter.
+type ExportError struct {
+	// Kind is the kind of the failure.
+	Kind ErrorKind
+	// RetryAfter is the delay the collector asked to wait before retrying
+	// the request, or zero if it did not ask for one.
+	RetryAfter time.Duration
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *ExportError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExportError) Unwrap() error {
+	return e.Err
+}
+
+// PartialSuccess is the error passed to the global error handler when the
+// collector accepted an export request but rejected some of its data points,
+// or returned a warning.
+type PartialSuccess struct {
+	// RejectedDataPoints is the number of data points rejected by the collector.
+	RejectedDataPoints int64
+	// ErrorMessage is the explanation given by the collector.
+	ErrorMessage string
+}
+
+// Error returns a description of the partial success.
+func (p PartialSuccess) Error() string {
+	if p.ErrorMessage == "" {
+		return fmt.Sprintf("OTLP partial success: %d data points rejected", p.RejectedDataPoints)
+	}
+	return fmt.Sprintf("OTLP partial success: %s (%d data points rejected)", p.ErrorMessage, p.RejectedDataPoints)
+}
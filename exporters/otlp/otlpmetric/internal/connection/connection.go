@@ -24,7 +24,6 @@ import (
 	"unsafe"
 
 	"github.com/cenkalti/backoff/v4"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/encoding/gzip"
@@ -32,6 +31,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpresponse"
 )
 
 type Connection struct {
@@ -288,7 +288,25 @@ func (c *Connection) ContextWithStop(ctx context.Context) (context.Context, cont
 	return ctx, cancel
 }
 
+// DoRequest calls fn, retrying it as configured. The error returned is an
+// *otlpmetric.ExportError classified according to the status returned by the
+// last call.
 func (c *Connection) DoRequest(ctx context.Context, fn func(context.Context) error) error {
+	var last error
+	err := c.doRequest(ctx, func(ctx context.Context) error {
+		err := fn(ctx)
+		if err != nil {
+			last = err
+		}
+		return err
+	})
+	if err != nil {
+		return otlpresponse.GRPCError(err, last)
+	}
+	return nil
+}
+
+func (c *Connection) doRequest(ctx context.Context, fn func(context.Context) error) error {
 	expBackoff := newExponentialBackoff(c.cfg.RetrySettings)
 
 	for {
@@ -401,16 +419,11 @@ func shouldRetry(code codes.Code) bool {
 
 func getThrottleDuration(status *status.Status) time.Duration {
 	// See if throttling information is available.
-	for _, detail := range status.Details() {
-		if t, ok := detail.(*errdetails.RetryInfo); ok {
-			if t.RetryDelay.Seconds > 0 || t.RetryDelay.Nanos > 0 {
-				// We are throttled. Wait before retrying as requested by the server.
-				return time.Duration(t.RetryDelay.Seconds)*time.Second + time.Duration(t.RetryDelay.Nanos)*time.Nanosecond
-			}
-			return 0
-		}
+	delay, _ := otlpresponse.RetryInfoDelay(status.Details())
+	if delay < 0 {
+		return 0
 	}
-	return 0
+	return delay
 }
 
 func newExponentialBackoff(rs otlpconfig.RetrySettings) *backoff.ExponentialBackOff {
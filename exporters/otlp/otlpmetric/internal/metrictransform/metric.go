@@ -210,6 +210,8 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.Metric, error) {
 			m.GetSum().DataPoints = append(m.GetSum().DataPoints, res.Metric.GetSum().DataPoints...)
 		case *metricpb.Metric_Histogram:
 			m.GetHistogram().DataPoints = append(m.GetHistogram().DataPoints, res.Metric.GetHistogram().DataPoints...)
+		case *metricpb.Metric_ExponentialHistogram:
+			m.GetExponentialHistogram().DataPoints = append(m.GetExponentialHistogram().DataPoints, res.Metric.GetExponentialHistogram().DataPoints...)
 		case *metricpb.Metric_Summary:
 			m.GetSummary().DataPoints = append(m.GetSummary().DataPoints, res.Metric.GetSummary().DataPoints...)
 		default:
@@ -235,8 +237,18 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.Metric, error) {
 }
 
 // Record transforms a Record into an OTLP Metric. An ErrIncompatibleAgg
-// error is returned if the Record Aggregator is not supported.
+// error is returned if the Record Aggregator is not supported. The data
+// points of a stale Record are flagged as having no recorded value.
 func Record(temporalitySelector aggregation.TemporalitySelector, r export.Record) (*metricpb.Metric, error) {
+	m, err := record(temporalitySelector, r)
+	if err != nil || !r.Stale() {
+		return m, err
+	}
+	noRecordedValue(m)
+	return m, nil
+}
+
+func record(temporalitySelector aggregation.TemporalitySelector, r export.Record) (*metricpb.Metric, error) {
 	agg := r.Aggregation()
 	switch agg.Kind() {
 	case aggregation.MinMaxSumCountKind:
@@ -253,6 +265,13 @@ func Record(temporalitySelector aggregation.TemporalitySelector, r export.Record
 		}
 		return histogramPoint(r, temporalitySelector.TemporalityFor(r.Descriptor(), aggregation.HistogramKind), h)
 
+	case aggregation.ExponentialHistogramKind:
+		h, ok := agg.(aggregation.ExponentialHistogram)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		return exponentialHistogramPoint(r, temporalitySelector.TemporalityFor(r.Descriptor(), aggregation.ExponentialHistogramKind), h)
+
 	case aggregation.SumKind:
 		s, ok := agg.(aggregation.Sum)
 		if !ok {
@@ -280,6 +299,33 @@ func Record(temporalitySelector aggregation.TemporalitySelector, r export.Record
 	}
 }
 
+// noRecordedValue flags the data points of m as staleness markers.
+func noRecordedValue(m *metricpb.Metric) {
+	flags := uint32(metricpb.DataPointFlags_FLAG_NO_RECORDED_VALUE)
+	switch data := m.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		for _, dp := range data.Gauge.DataPoints {
+			dp.Flags = flags
+		}
+	case *metricpb.Metric_Sum:
+		for _, dp := range data.Sum.DataPoints {
+			dp.Flags = flags
+		}
+	case *metricpb.Metric_Histogram:
+		for _, dp := range data.Histogram.DataPoints {
+			dp.Flags = flags
+		}
+	case *metricpb.Metric_ExponentialHistogram:
+		for _, dp := range data.ExponentialHistogram.DataPoints {
+			dp.Flags = flags
+		}
+	case *metricpb.Metric_Summary:
+		for _, dp := range data.Summary.DataPoints {
+			dp.Flags = flags
+		}
+	}
+}
+
 func gaugePoint(record export.Record, num number.Number, start, end time.Time) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
 	labels := record.Labels()
@@ -341,6 +387,10 @@ func sdkTemporalityToTemporality(temporality aggregation.Temporality) metricpb.A
 func sumPoint(record export.Record, num number.Number, start, end time.Time, temporality aggregation.Temporality, monotonic bool) (*metricpb.Metric, error) {
 	desc := record.Descriptor()
 	labels := record.Labels()
+	ex, err := exemplars(record)
+	if err != nil {
+		return nil, err
+	}
 
 	m := &metricpb.Metric{
 		Name:        desc.Name(),
@@ -362,6 +412,7 @@ func sumPoint(record export.Record, num number.Number, start, end time.Time, tem
 						Attributes:        Iterator(labels.Iter()),
 						StartTimeUnixNano: toNanos(start),
 						TimeUnixNano:      toNanos(end),
+						Exemplars:         ex,
 					},
 				},
 			},
@@ -379,6 +430,7 @@ func sumPoint(record export.Record, num number.Number, start, end time.Time, tem
 						Attributes:        Iterator(labels.Iter()),
 						StartTimeUnixNano: toNanos(start),
 						TimeUnixNano:      toNanos(end),
+						Exemplars:         ex,
 					},
 				},
 			},
@@ -470,6 +522,11 @@ func histogramPoint(record export.Record, temporality aggregation.Temporality, a
 		return nil, err
 	}
 
+	ex, err := exemplars(record)
+	if err != nil {
+		return nil, err
+	}
+
 	count, err := a.Count()
 	if err != nil {
 		return nil, err
@@ -496,6 +553,7 @@ func histogramPoint(record export.Record, temporality aggregation.Temporality, a
 						Count:             uint64(count),
 						BucketCounts:      counts,
 						ExplicitBounds:    boundaries,
+						Exemplars:         ex,
 					},
 				},
 			},
@@ -503,3 +561,116 @@ func histogramPoint(record export.Record, temporality aggregation.Temporality, a
 	}
 	return m, nil
 }
+
+// exponentialHistogramPoint transforms an ExponentialHistogram Aggregator
+// into an OTLP Metric.
+func exponentialHistogramPoint(record export.Record, temporality aggregation.Temporality, a aggregation.ExponentialHistogram) (*metricpb.Metric, error) {
+	desc := record.Descriptor()
+	labels := record.Labels()
+
+	count, err := a.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+
+	scale, err := a.Scale()
+	if err != nil {
+		return nil, err
+	}
+
+	zeroCount, err := a.ZeroCount()
+	if err != nil {
+		return nil, err
+	}
+
+	positive, err := a.Positive()
+	if err != nil {
+		return nil, err
+	}
+
+	negative, err := a.Negative()
+	if err != nil {
+		return nil, err
+	}
+
+	ex, err := exemplars(record)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &metricpb.Metric{
+		Name:        desc.Name(),
+		Description: desc.Description(),
+		Unit:        string(desc.Unit()),
+		Data: &metricpb.Metric_ExponentialHistogram{
+			ExponentialHistogram: &metricpb.ExponentialHistogram{
+				AggregationTemporality: sdkTemporalityToTemporality(temporality),
+				DataPoints: []*metricpb.ExponentialHistogramDataPoint{
+					{
+						Sum:               sum.CoerceToFloat64(desc.NumberKind()),
+						Attributes:        Iterator(labels.Iter()),
+						StartTimeUnixNano: toNanos(record.StartTime()),
+						TimeUnixNano:      toNanos(record.EndTime()),
+						Count:             count,
+						Scale:             scale,
+						ZeroCount:         zeroCount,
+						Positive:          exponentialBuckets(positive),
+						Negative:          exponentialBuckets(negative),
+						Exemplars:         ex,
+					},
+				},
+			},
+		},
+	}
+	return m, nil
+}
+
+// exponentialBuckets transforms ExponentialBuckets into OTLP buckets.
+func exponentialBuckets(b aggregation.ExponentialBuckets) *metricpb.ExponentialHistogramDataPoint_Buckets {
+	return &metricpb.ExponentialHistogramDataPoint_Buckets{
+		Offset:       b.Offset,
+		BucketCounts: b.Counts,
+	}
+}
+
+// exemplars transforms the exemplars sampled by the record Aggregator, if
+// any, into OTLP exemplars.
+func exemplars(record export.Record) ([]*metricpb.Exemplar, error) {
+	agg, ok := record.Aggregation().(aggregation.Exemplars)
+	if !ok {
+		return nil, nil
+	}
+	exemplars, err := agg.Exemplars()
+	if err != nil || len(exemplars) == 0 {
+		return nil, err
+	}
+
+	kind := record.Descriptor().NumberKind()
+	out := make([]*metricpb.Exemplar, 0, len(exemplars))
+	for _, e := range exemplars {
+		pb := &metricpb.Exemplar{
+			FilteredAttributes: KeyValues(e.FilteredAttributes),
+			TimeUnixNano:       toNanos(e.Time),
+		}
+		switch kind {
+		case number.Int64Kind:
+			pb.Value = &metricpb.Exemplar_AsInt{AsInt: e.Value.CoerceToInt64(kind)}
+		case number.Float64Kind:
+			pb.Value = &metricpb.Exemplar_AsDouble{AsDouble: e.Value.CoerceToFloat64(kind)}
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrUnknownValueType, kind)
+		}
+		if e.TraceID.IsValid() {
+			traceID, spanID := e.TraceID, e.SpanID
+			pb.TraceId = traceID[:]
+			pb.SpanId = spanID[:]
+		}
+		out = append(out, pb)
+	}
+	return out, nil
+}
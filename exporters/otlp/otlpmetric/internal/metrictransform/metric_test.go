@@ -30,9 +30,12 @@ import (
 	"go.opentelemetry.io/otel/metric/sdkapi"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/minmaxsumcount"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/trace"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
@@ -252,6 +255,25 @@ func TestSumFloatDataPoints(t *testing.T) {
 	}
 }
 
+func TestStaleRecordDataPoints(t *testing.T) {
+	desc := metrictest.NewDescriptor("", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	labels := attribute.NewSet(attribute.String("one", "1"))
+	sums := sum.New(2)
+	s, ckpt := &sums[0], &sums[1]
+
+	assert.NoError(t, s.Update(context.Background(), number.Number(1), &desc))
+	require.NoError(t, s.SynchronizedMove(ckpt, &desc))
+	sel := aggregation.CumulativeTemporalitySelector()
+
+	m, err := Record(sel, export.NewRecord(&desc, &labels, ckpt.Aggregation(), intervalStart, intervalEnd))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), m.GetSum().DataPoints[0].Flags)
+
+	m, err = Record(sel, export.NewStaleRecord(&desc, &labels, ckpt.Aggregation(), intervalStart, intervalEnd))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(metricpb.DataPointFlags_FLAG_NO_RECORDED_VALUE), m.GetSum().DataPoints[0].Flags)
+}
+
 func TestLastValueIntDataPoints(t *testing.T) {
 	desc := metrictest.NewDescriptor("", sdkapi.HistogramInstrumentKind, number.Int64Kind)
 	labels := attribute.NewSet(attribute.String("one", "1"))
@@ -287,6 +309,89 @@ func TestLastValueIntDataPoints(t *testing.T) {
 	}
 }
 
+func TestExponentialHistogramDataPoints(t *testing.T) {
+	desc := metrictest.NewDescriptor("", sdkapi.HistogramInstrumentKind, number.Float64Kind)
+	labels := attribute.NewSet(attribute.String("one", "1"))
+	aggs := exponential.New(2, &desc, exponential.WithMaxSize(4))
+	agg, ckpt := &aggs[0], &aggs[1]
+
+	for _, v := range []float64{0, 1, 2, 4, 8, -1} {
+		assert.NoError(t, agg.Update(context.Background(), number.NewFloat64Number(v), &desc))
+	}
+	require.NoError(t, agg.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &labels, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	m, err := Record(aggregation.CumulativeTemporalitySelector(), record)
+	require.NoError(t, err)
+	assert.Nil(t, m.GetHistogram())
+	assert.Equal(t, &metricpb.ExponentialHistogram{
+		AggregationTemporality: otelCumulative,
+		DataPoints: []*metricpb.ExponentialHistogramDataPoint{{
+			StartTimeUnixNano: uint64(intervalStart.UnixNano()),
+			TimeUnixNano:      uint64(intervalEnd.UnixNano()),
+			Attributes: []*commonpb.KeyValue{
+				{
+					Key:   "one",
+					Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "1"}},
+				},
+			},
+			Count:     6,
+			Sum:       14,
+			Scale:     0,
+			ZeroCount: 1,
+			Positive: &metricpb.ExponentialHistogramDataPoint_Buckets{
+				Offset:       0,
+				BucketCounts: []uint64{1, 1, 1, 1},
+			},
+			Negative: &metricpb.ExponentialHistogramDataPoint_Buckets{
+				Offset:       0,
+				BucketCounts: []uint64{1},
+			},
+		}},
+	}, m.GetExponentialHistogram())
+}
+
+func TestSumExemplars(t *testing.T) {
+	desc := metrictest.NewDescriptor("", sdkapi.CounterInstrumentKind, number.Int64Kind)
+	labels := attribute.NewSet()
+	sums := sum.New(2)
+	s, ckpt := &sums[0], &sums[1]
+
+	traceID := trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	spanID := trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	ctx = exemplar.NewCandidateContext(ctx, []attribute.KeyValue{attribute.String("user", "alice")})
+
+	assert.NoError(t, s.Update(ctx, number.NewInt64Number(3), &desc))
+	require.NoError(t, s.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &labels, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	m, err := Record(aggregation.CumulativeTemporalitySelector(), record)
+	require.NoError(t, err)
+	dataPoints := m.GetSum().DataPoints
+	require.Len(t, dataPoints, 1)
+	require.Len(t, dataPoints[0].Exemplars, 1)
+
+	e := dataPoints[0].Exemplars[0]
+	assert.NotZero(t, e.TimeUnixNano)
+	e.TimeUnixNano = 0
+	assert.Equal(t, &metricpb.Exemplar{
+		FilteredAttributes: []*commonpb.KeyValue{
+			{
+				Key:   "user",
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "alice"}},
+			},
+		},
+		Value:   &metricpb.Exemplar_AsInt{AsInt: 3},
+		TraceId: traceID[:],
+		SpanId:  spanID[:],
+	}, e)
+}
+
 func TestSumErrUnknownValueType(t *testing.T) {
 	desc := metrictest.NewDescriptor("", sdkapi.HistogramInstrumentKind, number.Kind(-1))
 	labels := attribute.NewSet()
@@ -399,6 +504,12 @@ func TestRecordAggregatorIncompatibleErrors(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, mpb)
 	require.True(t, errors.Is(err, ErrIncompatibleAgg))
+
+	mpb, err = makeMpb(aggregation.ExponentialHistogramKind, &sum.New(1)[0])
+
+	require.Error(t, err)
+	require.Nil(t, mpb)
+	require.True(t, errors.Is(err, ErrIncompatibleAgg))
 }
 
 func TestRecordAggregatorUnexpectedErrors(t *testing.T) {
@@ -255,6 +255,60 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE":         "overrode_by_signal_specific",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":                 "overrode_by_signal_specific",
+				"OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE": "cert_path",
+				"OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY":         "key_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+				"key_path":  []byte(WeakPrivateKey),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				if grpcOption {
+					assert.NotNil(t, c.Metrics.GRPCCredentials)
+				}
+				if assert.NotNil(t, c.Metrics.TLSCfg) && assert.NotNil(t, c.Metrics.TLSCfg.GetClientCertificate) {
+					cert, err := c.Metrics.TLSCfg.GetClientCertificate(nil)
+					assert.NoError(t, err)
+					assert.Len(t, cert.Certificate, 1)
+				}
+			},
+		},
+		{
+			name: "Test Environment Certificate and Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         "key_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+				"key_path":  []byte(WeakPrivateKey),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				if assert.NotNil(t, c.Metrics.TLSCfg) {
+					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Metrics.TLSCfg.RootCAs.Subjects())
+					assert.NotNil(t, c.Metrics.TLSCfg.GetClientCertificate)
+				}
+			},
+		},
+		{
+			name: "Test Environment Client Certificate Without Key",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Nil(t, c.Metrics.TLSCfg)
+			},
+		},
+
 		// Headers tests
 		{
 			name: "Test With Headers",
@@ -334,6 +388,45 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// Marshaler Tests
+		{
+			name: "Test With Marshal",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMarshal(otlpconfig.MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/json",
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment gRPC Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
@@ -52,3 +52,13 @@ type RetrySettings struct {
 	// Once this value is reached, the data is discarded.
 	MaxElapsedTime time.Duration
 }
+
+// Marshaler describes the kind of message format sent to the collector
+type Marshaler int
+
+const (
+	// MarshalProto tells the driver to send using the protobuf binary format.
+	MarshalProto Marshaler = iota
+	// MarshalJSON tells the driver to send using json format.
+	MarshalJSON
+)
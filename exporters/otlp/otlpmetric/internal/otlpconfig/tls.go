@@ -15,10 +15,16 @@
 package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/otel"
 )
 
 // ReadTLSConfigFromFile reads a PEM certificate file and creates
@@ -35,12 +41,219 @@ func ReadTLSConfigFromFile(path string) (*tls.Config, error) {
 // CreateTLSConfig creates a tls.Config from a raw certificate bytes
 // to verify a server certificate.
 func CreateTLSConfig(certBytes []byte) (*tls.Config, error) {
-	cp := x509.NewCertPool()
-	if ok := cp.AppendCertsFromPEM(certBytes); !ok {
-		return nil, errors.New("failed to append certificate to the cert pool")
+	cp, err := createCertPool(certBytes)
+	if err != nil {
+		return nil, err
 	}
 
 	return &tls.Config{
 		RootCAs: cp,
 	}, nil
 }
+
+func createCertPool(certBytes []byte) (*x509.CertPool, error) {
+	cp := x509.NewCertPool()
+	if ok := cp.AppendCertsFromPEM(certBytes); !ok {
+		return nil, errors.New("failed to append certificate to the cert pool")
+	}
+	return cp, nil
+}
+
+// fileReloader parses a set of files, and parses them again when their
+// content changes. The files are read each time the parsed value is
+// requested, that is at every TLS handshake, so the certificates renewed on
+// disk are used by the next connections without restarting the exporter.
+type fileReloader struct {
+	readFile func(filename string) ([]byte, error)
+	paths    []string
+	parse    func(contents [][]byte) (interface{}, error)
+
+	mu       sync.Mutex
+	contents [][]byte
+	value    interface{}
+	lastErr  string
+}
+
+func newFileReloader(readFile func(string) ([]byte, error), parse func([][]byte) (interface{}, error), paths ...string) *fileReloader {
+	return &fileReloader{readFile: readFile, paths: paths, parse: parse}
+}
+
+// get returns the value parsed from the files. If the files cannot be read
+// or parsed, the previously parsed value is kept and the error is passed to
+// the global error handler. An error is only returned if no value could be
+// parsed yet.
+func (r *fileReloader) get() (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contents := make([][]byte, len(r.paths))
+	for i, path := range r.paths {
+		b, err := r.readFile(path)
+		if err != nil {
+			return r.keep(fmt.Errorf("failed to read '%s': %w", path, err))
+		}
+		contents[i] = b
+	}
+	if r.unchanged(contents) {
+		return r.value, nil
+	}
+	// The new content is recorded even if invalid so it is not parsed
+	// again until it changes, the files may be partially written.
+	r.contents = contents
+	v, err := r.parse(contents)
+	if err != nil {
+		return r.keep(err)
+	}
+	r.value, r.lastErr = v, ""
+	return v, nil
+}
+
+func (r *fileReloader) unchanged(contents [][]byte) bool {
+	if r.contents == nil {
+		return false
+	}
+	for i := range contents {
+		if !bytes.Equal(contents[i], r.contents[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *fileReloader) keep(err error) (interface{}, error) {
+	if r.value == nil {
+		return nil, err
+	}
+	// Only report an error once, it is encountered again at every
+	// handshake until the files are fixed.
+	if msg := err.Error(); msg != r.lastErr {
+		r.lastErr = msg
+		otel.Handle(fmt.Errorf("failed to reload otlp exporter certificate, using the previous one: %w", err))
+	}
+	return r.value, nil
+}
+
+func parseCertPool(contents [][]byte) (interface{}, error) {
+	return createCertPool(contents[0])
+}
+
+func parseKeyPair(contents [][]byte) (interface{}, error) {
+	cert, err := tls.X509KeyPair(contents[0], contents[1])
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+// reloadRootCAs sets tlsCfg to verify the server certificates with the
+// certificates of the PEM file path, reloaded by r. The certificates are
+// verified for the host of the endpoint of cfg, unless tlsCfg has a
+// ServerName.
+func reloadRootCAs(cfg *Config, tlsCfg *tls.Config, path string, r *fileReloader) {
+	if v, err := r.get(); err == nil {
+		tlsCfg.RootCAs = v.(*x509.CertPool)
+	} else {
+		tlsCfg.RootCAs = nil
+		otel.Handle(fmt.Errorf("failed to configure otlp exporter certificate '%s': %w", path, err))
+	}
+
+	next := tlsCfg.VerifyConnection
+	if prev := cfg.Metrics.serverVerifier; prev != nil {
+		// tlsCfg already verifies with the certificates of another
+		// file, they are replaced.
+		next = prev.next
+	}
+	v := &serverVerifier{
+		path:       path,
+		reloader:   r,
+		serverName: tlsCfg.ServerName,
+		endpoint:   func() string { return cfg.Metrics.Endpoint },
+		next:       next,
+	}
+	cfg.Metrics.serverVerifier = v
+
+	// The RootCAs of a tls.Config cannot be replaced once it is used, the
+	// verification is made by VerifyConnection with the reloaded
+	// certificates instead.
+	tlsCfg.InsecureSkipVerify = true
+	tlsCfg.VerifyConnection = v.verifyConnection
+}
+
+// serverVerifier verifies the server certificates with reloaded root
+// certificates.
+type serverVerifier struct {
+	path     string
+	reloader *fileReloader
+	// serverName is the ServerName of the TLS configuration.
+	serverName string
+	// endpoint returns the endpoint the exporter connects to.
+	endpoint func() string
+	// next is the VerifyConnection function of the TLS configuration, it
+	// is called once the server certificates are verified.
+	next func(tls.ConnectionState) error
+}
+
+func (v *serverVerifier) verifyConnection(cs tls.ConnectionState) error {
+	roots, err := v.reloader.get()
+	if err != nil {
+		return fmt.Errorf("failed to load otlp exporter certificate '%s': %w", v.path, err)
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no server certificate received")
+	}
+	name := v.name(cs)
+	if name == "" {
+		return errors.New("no server name to verify the otlp exporter certificate for")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots.(*x509.CertPool),
+		DNSName:       name,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+		return err
+	}
+	if v.next != nil {
+		return v.next(cs)
+	}
+	return nil
+}
+
+// name returns the name the server certificate must be valid for. The
+// server name sent by the client is empty when the dialed host is an IP
+// address, the ServerName of the TLS configuration or the host of the
+// endpoint is used then.
+func (v *serverVerifier) name(cs tls.ConnectionState) string {
+	if cs.ServerName != "" {
+		return cs.ServerName
+	}
+	if v.serverName != "" {
+		return v.serverName
+	}
+	endpoint := v.endpoint()
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		// The endpoint has no port.
+		return endpoint
+	}
+	return host
+}
+
+// reloadClientCertificate sets tlsCfg to send the client certificate of
+// the PEM files certFile and keyFile, reloaded by r.
+func reloadClientCertificate(tlsCfg *tls.Config, certFile, keyFile string, r *fileReloader) {
+	if _, err := r.get(); err != nil {
+		otel.Handle(fmt.Errorf("failed to configure otlp exporter client certificate '%s' and key '%s': %w", certFile, keyFile, err))
+	}
+	tlsCfg.Certificates = nil
+	tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		v, err := r.get()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load otlp exporter client certificate '%s' and key '%s': %w", certFile, keyFile, err)
+		}
+		return v.(*tls.Certificate), nil
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpjson implements the OTLP/JSON encoding of the OTLP Protobuf
+// messages.
+//
+// The OTLP/JSON encoding is the Protobuf JSON mapping of the messages with
+// the exception of trace and span IDs, which are encoded as hex strings
+// instead of base64.
+package otlpjson // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpjson"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentType is the media type of OTLP/JSON encoded messages.
+const ContentType = "application/json"
+
+// idKeys are the JSON names of the trace and span ID fields.
+var idKeys = map[string]bool{
+	"traceId":      true,
+	"spanId":       true,
+	"parentSpanId": true,
+}
+
+// Marshal returns the OTLP/JSON encoding of m: the Protobuf JSON mapping,
+// with enum names and 64-bit integers as strings, but with hex encoded trace
+// and span IDs.
+func Marshal(m proto.Message) ([]byte, error) {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	v, err := decode(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := convertIDs(v, hexID); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Unmarshal parses the OTLP/JSON encoded b into m.
+func Unmarshal(b []byte, m proto.Message) error {
+	v, err := decode(b)
+	if err != nil {
+		return err
+	}
+	if err := convertIDs(v, base64ID); err != nil {
+		return err
+	}
+	if b, err = json.Marshal(v); err != nil {
+		return err
+	}
+	return protojson.Unmarshal(b, m)
+}
+
+// decode decodes the JSON encoded b, keeping its numbers as they were
+// encoded.
+func decode(b []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// hexID re-encodes a base64 encoded ID as hex.
+func hexID(s string) (string, error) {
+	id, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// base64ID re-encodes a hex encoded ID as base64.
+func base64ID(s string) (string, error) {
+	id, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(id), nil
+}
+
+// convertIDs replaces the IDs found in v with their conversion by fn.
+func convertIDs(v interface{}, fn func(string) (string, error)) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if s, ok := e.(string); ok && idKeys[k] {
+				id, err := fn(s)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %w", k, s, err)
+				}
+				v[k] = id
+				continue
+			}
+			if err := convertIDs(e, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := convertIDs(e, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpresponse interprets the responses of OTLP collectors. It
+// classifies failed requests into ExportErrors, extracts the throttling
+// delays requested by the collectors, and reports partial successes.
+package otlpresponse // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpresponse"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpjson"
+)
+
+const (
+	// ContentTypeProto is the media type of Protobuf encoded messages.
+	ContentTypeProto = "application/x-protobuf"
+
+	// maxBodySize is the maximum size of the response bodies read.
+	maxBodySize = 64 << 10
+
+	// rejectedKey is the OTLP/JSON name of the number of rejected items of
+	// a partial success.
+	rejectedKey = "rejectedDataPoints"
+)
+
+// ReadBody reads and closes the body of a response. At most 64 KiB are
+// returned, the rest of the body is discarded so the connection can be
+// reused.
+func ReadBody(body io.ReadCloser) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(body, maxBodySize))
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, body)
+	}
+	if cErr := body.Close(); err == nil {
+		err = cErr
+	}
+	return b, err
+}
+
+// RetryAfter returns the delay requested by the value of a Retry-After HTTP
+// header, either a number of seconds or an HTTP date relative to now. It
+// returns false if v is not a valid value.
+func RetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// RetryInfoDelay returns the delay of the first RetryInfo found in the
+// details of a google.rpc.Status. It returns false if there is none.
+func RetryInfoDelay(details []interface{}) (time.Duration, bool) {
+	for _, detail := range details {
+		if t, ok := detail.(*errdetails.RetryInfo); ok {
+			return t.RetryDelay.AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// GRPCError returns err as an ExportError classified according to the
+// status of last, the error of the last gRPC request made. A nil last means
+// no request completed. The status of err is still returned by status.Convert
+// for the returned error.
+func GRPCError(err, last error) error {
+	e := &otlpmetric.ExportError{Kind: otlpmetric.TransportFailure, Err: err}
+	if s, ok := status.FromError(last); ok && last != nil {
+		delay, throttled := RetryInfoDelay(s.Details())
+		switch s.Code() {
+		case codes.ResourceExhausted:
+			throttled = true
+		case codes.Canceled,
+			codes.DeadlineExceeded,
+			codes.Aborted,
+			codes.OutOfRange,
+			codes.Unavailable,
+			codes.DataLoss:
+		default:
+			if !throttled {
+				e.Kind = otlpmetric.Rejected
+			}
+		}
+		if throttled {
+			e.Kind = otlpmetric.Throttled
+			e.RetryAfter = delay
+		}
+	}
+	return grpcError{ExportError: e, status: status.Convert(err)}
+}
+
+// grpcError is an ExportError that keeps the gRPC status of its error.
+type grpcError struct {
+	*otlpmetric.ExportError
+	status *status.Status
+}
+
+// GRPCStatus returns the gRPC status of the error.
+func (e grpcError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// Unwrap returns the ExportError.
+func (e grpcError) Unwrap() error {
+	return e.ExportError
+}
+
+// HTTPError returns err as an ExportError classified according to resp, a
+// response with an unsuccessful status, and its body. The delay requested
+// by the collector is read from the Retry-After header of resp, or from the
+// RetryInfo of the google.rpc.Status in the body.
+func HTTPError(err error, resp *http.Response, body []byte) *otlpmetric.ExportError {
+	e := &otlpmetric.ExportError{Kind: otlpmetric.TransportFailure, Err: err}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusServiceUnavailable:
+		e.Kind = otlpmetric.Throttled
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		e.Kind = otlpmetric.Rejected
+	}
+
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if d, ok := RetryAfter(v, time.Now()); ok {
+			e.RetryAfter = d
+			return e
+		}
+	}
+	if s, err := decodeStatus(resp.Header.Get("Content-Type"), body); err == nil {
+		if d, ok := RetryInfoDelay(status.FromProto(s).Details()); ok {
+			e.RetryAfter = d
+		}
+	}
+	return e
+}
+
+// decodeStatus decodes the google.rpc.Status encoded as contentType in b.
+func decodeStatus(contentType string, b []byte) (*spb.Status, error) {
+	s := new(spb.Status)
+	switch mediaType(contentType) {
+	case ContentTypeProto:
+		return s, proto.Unmarshal(b, s)
+	case otlpjson.ContentType:
+		return s, protojson.Unmarshal(b, s)
+	}
+	return nil, fmt.Errorf("unsupported content type: %q", contentType)
+}
+
+// Retryable returns if err is an ExportError of a throttled request, and
+// the delay requested by the collector.
+func Retryable(err error) (bool, time.Duration) {
+	var e *otlpmetric.ExportError
+	if !errors.As(err, &e) || e.Kind != otlpmetric.Throttled {
+		return false, 0
+	}
+	return true, e.RetryAfter
+}
+
+// ReportPartialSuccess passes the partial success contained in body, a
+// successful export response encoded as contentType, to the global error
+// handler. Nothing is reported if the response has no partial success or
+// contentType is not an OTLP encoding.
+func ReportPartialSuccess(contentType string, body []byte) {
+	var (
+		p   otlpmetric.PartialSuccess
+		err error
+	)
+	switch mediaType(contentType) {
+	case ContentTypeProto:
+		p, err = partialSuccessFromProto(body)
+	case otlpjson.ContentType:
+		p, err = partialSuccessFromJSON(body)
+	default:
+		return
+	}
+	if err != nil {
+		otel.Handle(fmt.Errorf("invalid OTLP export response: %w", err))
+		return
+	}
+	if p != (otlpmetric.PartialSuccess{}) {
+		otel.Handle(p)
+	}
+}
+
+// mediaType returns the media type of contentType, without its parameters.
+func mediaType(contentType string) string {
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// partialSuccessFromProto returns the partial success of the Protobuf
+// encoded export response b. The partial success is the message of its
+// first field, made of the number of rejected items and an error message.
+// It is decoded from the wire format so it is read from the fields of the
+// responses unknown to the generated types.
+func partialSuccessFromProto(b []byte) (otlpmetric.PartialSuccess, error) {
+	var p otlpmetric.PartialSuccess
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		return n, consumeFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+			switch {
+			case num == 1 && typ == protowire.VarintType:
+				v, n := protowire.ConsumeVarint(b)
+				p.RejectedDataPoints = int64(v)
+				return n, nil
+			case num == 2 && typ == protowire.BytesType:
+				v, n := protowire.ConsumeBytes(b)
+				p.ErrorMessage = string(v)
+				return n, nil
+			}
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		})
+	})
+	return p, err
+}
+
+// consumeFields calls fn with the number, type, and remaining bytes of each
+// field of the message b. fn returns the length of the field value.
+func consumeFields(b []byte, fn func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := fn(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// partialSuccessFromJSON returns the partial success of the OTLP/JSON
+// encoded export response b.
+func partialSuccessFromJSON(b []byte) (otlpmetric.PartialSuccess, error) {
+	var p otlpmetric.PartialSuccess
+	if len(b) == 0 {
+		return p, nil
+	}
+	var resp struct {
+		PartialSuccess map[string]json.RawMessage `json:"partialSuccess"`
+	}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return p, err
+	}
+	if raw, ok := resp.PartialSuccess[rejectedKey]; ok {
+		// 64-bit integers are encoded as strings, which are accepted as
+		// numbers as well.
+		var n json.Number
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return p, err
+		}
+		v, err := n.Int64()
+		if err != nil {
+			return p, err
+		}
+		p.RejectedDataPoints = v
+	}
+	if raw, ok := resp.PartialSuccess["errorMessage"]; ok {
+		if err := json.Unmarshal(raw, &p.ErrorMessage); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpresponse
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, time.November, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"3", 3 * time.Second, true},
+		{" 0 ", 0, true},
+		{"-1", 0, false},
+		{"Thu, 04 Nov 2021 12:00:05 GMT", 5 * time.Second, true},
+		{"Thu, 04 Nov 2021 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := RetryAfter(test.value, now)
+		assert.Equal(t, test.ok, ok, test.value)
+		assert.Equal(t, test.want, got, test.value)
+	}
+}
+
+func throttledStatus(t *testing.T, c codes.Code, delay time.Duration) *status.Status {
+	s, err := status.New(c, "slow down").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(delay),
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestGRPCError(t *testing.T) {
+	tests := []struct {
+		name       string
+		last       error
+		kind       otlpmetric.ErrorKind
+		retryAfter time.Duration
+	}{
+		{
+			name: "no request",
+			kind: otlpmetric.TransportFailure,
+		},
+		{
+			name: "not a status",
+			last: errors.New("dial failed"),
+			kind: otlpmetric.TransportFailure,
+		},
+		{
+			name: "unavailable",
+			last: status.Error(codes.Unavailable, "unavailable"),
+			kind: otlpmetric.TransportFailure,
+		},
+		{
+			name: "resource exhausted",
+			last: status.Error(codes.ResourceExhausted, "exhausted"),
+			kind: otlpmetric.Throttled,
+		},
+		{
+			name:       "retry info",
+			last:       throttledStatus(t, codes.Unavailable, time.Second).Err(),
+			kind:       otlpmetric.Throttled,
+			retryAfter: time.Second,
+		},
+		{
+			name: "invalid argument",
+			last: status.Error(codes.InvalidArgument, "invalid"),
+			kind: otlpmetric.Rejected,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := GRPCError(status.Error(codes.DeadlineExceeded, "deadline"), test.last)
+			var e *otlpmetric.ExportError
+			require.True(t, errors.As(err, &e))
+			assert.Equal(t, test.kind, e.Kind)
+			assert.Equal(t, test.retryAfter, e.RetryAfter)
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		})
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	body, err := proto.Marshal(throttledStatus(t, codes.Unavailable, 2*time.Second).Proto())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		code       int
+		header     http.Header
+		body       []byte
+		kind       otlpmetric.ErrorKind
+		retryAfter time.Duration
+	}{
+		{
+			name: "bad request",
+			code: http.StatusBadRequest,
+			kind: otlpmetric.Rejected,
+		},
+		{
+			name: "bad gateway",
+			code: http.StatusBadGateway,
+			kind: otlpmetric.TransportFailure,
+		},
+		{
+			name:       "retry after",
+			code:       http.StatusTooManyRequests,
+			header:     http.Header{"Retry-After": []string{"3"}},
+			kind:       otlpmetric.Throttled,
+			retryAfter: 3 * time.Second,
+		},
+		{
+			name:       "retry info",
+			code:       http.StatusServiceUnavailable,
+			header:     http.Header{"Content-Type": []string{ContentTypeProto}},
+			body:       body,
+			kind:       otlpmetric.Throttled,
+			retryAfter: 2 * time.Second,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: test.code, Header: test.header}
+			e := HTTPError(errors.New("failed"), resp, test.body)
+			assert.Equal(t, test.kind, e.Kind)
+			assert.Equal(t, test.retryAfter, e.RetryAfter)
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	retry, delay := Retryable(&otlpmetric.ExportError{Kind: otlpmetric.Throttled, RetryAfter: time.Second})
+	assert.True(t, retry)
+	assert.Equal(t, time.Second, delay)
+
+	retry, _ = Retryable(&otlpmetric.ExportError{Kind: otlpmetric.Rejected})
+	assert.False(t, retry)
+
+	retry, _ = Retryable(errors.New("failed"))
+	assert.False(t, retry)
+}
+
+func TestPartialSuccessFromProto(t *testing.T) {
+	var ps []byte
+	ps = protowire.AppendTag(ps, 1, protowire.VarintType)
+	ps = protowire.AppendVarint(ps, 2)
+	ps = protowire.AppendTag(ps, 2, protowire.BytesType)
+	ps = protowire.AppendString(ps, "invalid data points")
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, ps)
+
+	p, err := partialSuccessFromProto(b)
+	require.NoError(t, err)
+	assert.Equal(t, otlpmetric.PartialSuccess{RejectedDataPoints: 2, ErrorMessage: "invalid data points"}, p)
+
+	p, err = partialSuccessFromProto(nil)
+	require.NoError(t, err)
+	assert.Equal(t, otlpmetric.PartialSuccess{}, p)
+
+	_, err = partialSuccessFromProto(b[:len(b)-1])
+	assert.Error(t, err)
+}
+
+func TestPartialSuccessFromJSON(t *testing.T) {
+	p, err := partialSuccessFromJSON([]byte(`{"partialSuccess":{"rejectedDataPoints":"2","errorMessage":"invalid data points"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, otlpmetric.PartialSuccess{RejectedDataPoints: 2, ErrorMessage: "invalid data points"}, p)
+
+	p, err = partialSuccessFromJSON([]byte(`{"partialSuccess":{"rejectedDataPoints":3}}`))
+	require.NoError(t, err)
+	assert.Equal(t, otlpmetric.PartialSuccess{RejectedDataPoints: 3}, p)
+
+	p, err = partialSuccessFromJSON([]byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, otlpmetric.PartialSuccess{}, p)
+
+	_, err = partialSuccessFromJSON([]byte(`{"partialSuccess":{"rejectedDataPoints":"two"}}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpmetrictest"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+	"go.opentelemetry.io/otel/sdk/resource"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// export exports the test record n times to the file at path.
+func export(t *testing.T, path string, n int, opts ...otlpmetricfile.Option) {
+	ctx := context.Background()
+	exp, err := otlpmetricfile.New(ctx, path, opts...)
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		require.NoError(t, exp.Export(ctx, resource.Empty(), otlpmetrictest.OneRecordReader()))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+}
+
+type recordingClient struct {
+	mu       sync.Mutex
+	requests [][]*metricpb.ResourceMetrics
+}
+
+var _ otlpmetric.Client = (*recordingClient)(nil)
+
+func (c *recordingClient) Start(context.Context) error { return nil }
+func (c *recordingClient) Stop(context.Context) error  { return nil }
+
+func (c *recordingClient) UploadMetrics(_ context.Context, rms []*metricpb.ResourceMetrics) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, rms)
+	return nil
+}
+
+// replay returns the requests replayed from the file at path.
+func replay(t *testing.T, path string) [][]*metricpb.ResourceMetrics {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	client := &recordingClient{}
+	require.NoError(t, otlpmetricfile.Replay(context.Background(), f, client))
+	return client.requests
+}
+
+func TestExporterWritesOTLPJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	export(t, path, 2)
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var got struct {
+		ResourceMetrics []struct {
+			InstrumentationLibraryMetrics []struct {
+				Metrics []struct {
+					Name string
+					Sum  map[string]interface{}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	m := got.ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0]
+	assert.Equal(t, "foo", m.Name)
+	assert.Equal(t, "AGGREGATION_TEMPORALITY_CUMULATIVE", m.Sum["aggregationTemporality"])
+	dp := m.Sum["dataPoints"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "42", dp["asInt"])
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	export(t, path, 2)
+
+	requests := replay(t, path)
+	require.Len(t, requests, 2)
+	m := requests[1][0].InstrumentationLibraryMetrics[0].Metrics[0]
+	assert.Equal(t, "foo", m.Name)
+	assert.Equal(t, int64(42), m.GetSum().DataPoints[0].GetAsInt())
+}
+
+func TestExporterGzipCompression(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl.gz")
+	opt := otlpmetricfile.WithCompression(otlpmetricfile.GzipCompression)
+	export(t, path, 1, opt)
+	// Appending to the file adds a gzip stream read as its continuation.
+	export(t, path, 1, opt)
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b, []byte{0x1f, 0x8b}), "not gzip compressed")
+	assert.Len(t, replay(t, path), 2)
+}
+
+func TestExporterRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "metrics.jsonl")
+	export(t, path, 3, otlpmetricfile.WithMaxSize(1))
+
+	rotated, err := filepath.Glob(filepath.Join(dir, "metrics-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+	for _, p := range append(rotated, path) {
+		assert.Len(t, replay(t, p), 1, p)
+	}
+}
+
+func TestClientUploadAfterStop(t *testing.T) {
+	ctx := context.Background()
+	client := otlpmetricfile.NewClient(filepath.Join(t.TempDir(), "metrics.jsonl"))
+	require.NoError(t, client.Start(ctx))
+	require.NoError(t, client.Stop(ctx))
+	assert.Error(t, client.UploadMetrics(ctx, nil))
+}
+
+func TestReaderInvalidLine(t *testing.T) {
+	r, err := otlpmetricfile.NewReader(strings.NewReader("{}\n{invalid\n"))
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
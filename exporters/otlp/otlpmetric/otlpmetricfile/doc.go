@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package otlpmetricfile provides a client that writes metrics to a file in the
+OTLP/JSON format, so they can be sent to a collector later.
+
+Each line of the file is the OTLP/JSON encoding of an
+ExportMetricsServiceRequest, the body of an OTLP/HTTP request sent with the
+application/json content type. The file can be rotated once it reaches a size
+or an age, and compressed with gzip.
+
+The files can be read back with a Reader, and replayed with Replay to any
+otlpmetric.Client.
+*/
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+)
+
+// New constructs a new Exporter writing to the file at path and starts it.
+func New(ctx context.Context, path string, opts ...Option) (*otlpmetric.Exporter, error) {
+	return otlpmetric.New(ctx, NewClient(path, opts...))
+}
+
+// NewUnstarted constructs a new Exporter writing to the file at path and
+// does not start it.
+func NewUnstarted(path string, opts ...Option) *otlpmetric.Exporter {
+	return otlpmetric.NewUnstarted(NewClient(path, opts...))
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// rotatedTimeFormat is the format of the time inserted in the name of the
+// rotated files.
+const rotatedTimeFormat = "20060102T150405.000000000Z"
+
+// file is a file of newline-delimited requests that is rotated once it
+// reaches a size or an age.
+type file struct {
+	path        string
+	maxSize     int64
+	maxAge      time.Duration
+	compression Compression
+	now         func() time.Time
+
+	f    *os.File
+	w    io.Writer
+	gz   *gzip.Writer
+	size int64
+	// started is the time the file was opened, or the time the first
+	// request was written to it if it was empty.
+	started time.Time
+}
+
+func newFile(path string, cfg config) *file {
+	return &file{
+		path:        path,
+		maxSize:     cfg.maxSize,
+		maxAge:      cfg.maxAge,
+		compression: cfg.compression,
+		now:         time.Now,
+	}
+}
+
+// open opens the file for appending, creating it and its directory if
+// needed.
+func (f *file) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	osf, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := osf.Stat()
+	if err != nil {
+		_ = osf.Close()
+		return err
+	}
+
+	f.f = osf
+	f.size = info.Size()
+	f.started = f.now()
+	f.w = &countingWriter{w: osf, n: &f.size}
+	f.gz = nil
+	if f.compression == GzipCompression {
+		// A gzip stream appended to an existing one is read as a
+		// continuation of it.
+		f.gz = gzip.NewWriter(f.w)
+		f.w = f.gz
+	}
+	return nil
+}
+
+// write writes line to the file, rotating it first if needed.
+func (f *file) write(line []byte) error {
+	if f.rotationDue() {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	if f.size == 0 {
+		f.started = f.now()
+	}
+	if _, err := f.w.Write(line); err != nil {
+		return err
+	}
+	if f.gz != nil {
+		// Flush so the file can be read up to this line if the process
+		// stops before the file is closed.
+		return f.gz.Flush()
+	}
+	return nil
+}
+
+// rotationDue returns whether the file needs to be rotated before writing
+// to it.
+func (f *file) rotationDue() bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.maxSize > 0 && f.size >= f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && f.now().Sub(f.started) >= f.maxAge
+}
+
+// rotate closes the file, renames it with the time of the rotation
+// inserted before its extension, and opens a new file.
+func (f *file) rotate() error {
+	if err := f.close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, rotatedPath(f.path, f.now())); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+// close closes the file.
+func (f *file) close() error {
+	if f.gz != nil {
+		if err := f.gz.Close(); err != nil {
+			_ = f.f.Close()
+			return err
+		}
+	}
+	return f.f.Close()
+}
+
+// rotatedPath returns the path a file at path is renamed to when it is
+// rotated at t. For example, metrics.jsonl.gz is renamed to
+// metrics-20211117T101530.000000000Z.jsonl.gz.
+func rotatedPath(path string, t time.Time) string {
+	dir, base := filepath.Split(path)
+	name, ext := base, ""
+	// Ignore the leading dot of hidden files.
+	if len(base) > 1 {
+		if i := strings.Index(base[1:], "."); i >= 0 {
+			name, ext = base[:i+1], base[i+1:]
+		}
+	}
+	return filepath.Join(dir, name+"-"+t.UTC().Format(rotatedTimeFormat)+ext)
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
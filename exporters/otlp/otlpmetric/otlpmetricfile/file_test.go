@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatedPath(t *testing.T) {
+	ts := time.Date(2021, time.November, 17, 10, 15, 30, 5, time.UTC)
+	for _, test := range []struct {
+		path, want string
+	}{
+		{"metrics.jsonl", "metrics-20211117T101530.000000005Z.jsonl"},
+		{"metrics.jsonl.gz", "metrics-20211117T101530.000000005Z.jsonl.gz"},
+		{"metrics", "metrics-20211117T101530.000000005Z"},
+		{".metrics", ".metrics-20211117T101530.000000005Z"},
+		{filepath.Join("dir", "metrics.jsonl"), filepath.Join("dir", "metrics-20211117T101530.000000005Z.jsonl")},
+	} {
+		assert.Equal(t, test.want, rotatedPath(test.path, ts), test.path)
+	}
+}
+
+func TestFileRotatesByAge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Unix(1600000000, 0)
+	f := newFile(filepath.Join(dir, "metrics.jsonl"), config{maxAge: time.Minute})
+	f.now = func() time.Time { return now }
+	require.NoError(t, f.open())
+
+	// An empty file is not rotated.
+	now = now.Add(time.Hour)
+	require.NoError(t, f.write([]byte("a\n")))
+	now = now.Add(time.Minute - 1)
+	require.NoError(t, f.write([]byte("b\n")))
+	now = now.Add(1)
+	require.NoError(t, f.write([]byte("c\n")))
+	require.NoError(t, f.close())
+
+	rotated, err := filepath.Glob(filepath.Join(dir, "metrics-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+	b, err := ioutil.ReadFile(rotated[0])
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\n", string(b))
+	b, err = ioutil.ReadFile(f.path)
+	require.NoError(t, err)
+	assert.Equal(t, "c\n", string(b))
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
+)
+
+// Compression describes the compression used for the written files.
+type Compression otlpconfig.Compression
+
+const (
+	// NoCompression tells the driver to write the files without
+	// compression.
+	NoCompression = Compression(otlpconfig.NoCompression)
+	// GzipCompression tells the driver to compress the written files with
+	// gzip.
+	GzipCompression = Compression(otlpconfig.GzipCompression)
+)
+
+// config contains the options of the file client.
+type config struct {
+	maxSize     int64
+	maxAge      time.Duration
+	compression Compression
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
+}
+
+// Option applies an option to the file client.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(cfg *config) {
+	fn(cfg)
+}
+
+// WithMaxSize sets the size in bytes at which the file is rotated. The file
+// is rotated before a request is written to it once it has reached this
+// size, so it exceeds it by less than the size of a request. If unset or
+// non-positive, the file is not rotated based on its size.
+func WithMaxSize(bytes int64) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.maxSize = bytes
+	})
+}
+
+// WithMaxAge sets the duration after which the file is rotated. The file is
+// rotated before a request is written to it once this duration has elapsed
+// since the first request was written to it, or since it was opened if it
+// already existed. If unset or non-positive, the file is not rotated based on
+// its age.
+func WithMaxAge(d time.Duration) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.maxAge = d
+	})
+}
+
+// WithCompression tells the driver to compress the written files. If unset,
+// the files are not compressed.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.compression = compression
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpjson"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// gzipMagic are the first bytes of a gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Reader reads the requests of a file written by the client.
+type Reader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewReader returns a Reader reading the requests from r. Gzip compressed
+// content is detected and decompressed.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		br = bufio.NewReader(gz)
+	}
+	return &Reader{r: br}, nil
+}
+
+// Read returns the ResourceMetrics of the next request. It returns io.EOF
+// once all the requests have been read. A file truncated when the process
+// writing it stopped is read up to its last complete request.
+func (r *Reader) Read() ([]*metricpb.ResourceMetrics, error) {
+	for {
+		b, err := r.r.ReadBytes('\n')
+		b = bytes.TrimSpace(b)
+		if err != nil {
+			if len(b) == 0 && (err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF)) {
+				return nil, io.EOF
+			}
+			if err != io.EOF {
+				return nil, err
+			}
+		}
+		r.line++
+		if len(b) == 0 {
+			continue
+		}
+
+		req := new(colmetricpb.ExportMetricsServiceRequest)
+		if err := otlpjson.Unmarshal(b, req); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return req.ResourceMetrics, nil
+	}
+}
+
+// Replay uploads the requests read from r with client, which needs to be
+// started. It stops at the first error.
+func Replay(ctx context.Context, r io.Reader, client otlpmetric.Client) error {
+	reader, err := NewReader(r)
+	if err != nil {
+		return err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rms, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := client.UploadMetrics(ctx, rms); err != nil {
+			return err
+		}
+	}
+}
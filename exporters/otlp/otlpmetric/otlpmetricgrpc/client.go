@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/connection"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpresponse"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
@@ -94,9 +95,14 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics []*metricpb.Res
 		}
 
 		return c.connection.DoRequest(ctx, func(ctx context.Context) error {
-			_, err := c.metricsClient.Export(ctx, &colmetricpb.ExportMetricsServiceRequest{
+			resp, err := c.metricsClient.Export(ctx, &colmetricpb.ExportMetricsServiceRequest{
 				ResourceMetrics: protoMetrics,
 			})
+			if err == nil && resp != nil {
+				// The partial success is not known to the generated
+				// types and is kept with the unknown fields.
+				otlpresponse.ReportPartialSuccess(otlpresponse.ContentTypeProto, resp.ProtoReflect().GetUnknown())
+			}
 			return err
 		})
 	}()
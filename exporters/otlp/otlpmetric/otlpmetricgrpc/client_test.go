@@ -16,6 +16,7 @@ package otlpmetricgrpc_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -741,3 +742,50 @@ func TestFailedMetricTransform(t *testing.T) {
 
 	assert.Error(t, exp.Export(ctx, testResource, otlpmetrictest.FailReader{}))
 }
+
+func TestExportErrorKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind otlpmetric.ErrorKind
+	}{
+		{
+			name: "rejected",
+			err:  status.Error(codes.InvalidArgument, "invalid data points"),
+			kind: otlpmetric.Rejected,
+		},
+		{
+			name: "throttled",
+			err:  status.Error(codes.ResourceExhausted, "slow down"),
+			kind: otlpmetric.Throttled,
+		},
+		{
+			name: "unavailable",
+			err:  status.Error(codes.Unavailable, "unavailable"),
+			kind: otlpmetric.TransportFailure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mc := runMockCollectorWithConfig(t, &mockConfig{
+				errors:   []error{test.err},
+				endpoint: "localhost:0",
+			})
+			defer func() {
+				_ = mc.stop()
+			}()
+
+			ctx := context.Background()
+			exp := newGRPCExporter(t, ctx, mc.endpoint, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetrySettings{Enabled: false}))
+			defer func() {
+				_ = exp.Shutdown(ctx)
+			}()
+
+			err := exp.Export(ctx, testResource, oneRecord)
+			var e *otlpmetric.ExportError
+			require.True(t, errors.As(err, &e))
+			assert.Equal(t, test.kind, e.Kind)
+			assert.Equal(t, status.Code(test.err), status.Code(err))
+		})
+	}
+}
@@ -33,6 +33,8 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpjson"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpresponse"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
@@ -143,7 +145,7 @@ func (d *client) UploadMetrics(ctx context.Context, protoMetrics []*metricpb.Res
 	pbRequest := &colmetricpb.ExportMetricsServiceRequest{
 		ResourceMetrics: protoMetrics,
 	}
-	rawRequest, err := proto.Marshal(pbRequest)
+	rawRequest, err := d.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -155,33 +157,53 @@ func (d *client) send(ctx context.Context, rawRequest []byte) error {
 	var cancel context.CancelFunc
 	ctx, cancel = d.contextWithStop(ctx)
 	defer cancel()
+	var last *otlpmetric.ExportError
 	for i := 0; i < d.generalCfg.MaxAttempts; i++ {
 		response, err := d.singleSend(ctx, rawRequest, address)
 		if err != nil {
-			return err
+			return &otlpmetric.ExportError{Kind: otlpmetric.TransportFailure, Err: err}
+		}
+		// The body is only used to report partial successes and
+		// throttling, the rest of it is discarded to facilitate
+		// connection reuse.
+		body, err := otlpresponse.ReadBody(response.Body)
+		if err != nil {
+			return &otlpmetric.ExportError{Kind: otlpmetric.TransportFailure, Err: err}
 		}
-		// We don't care about the body, so try to read it
-		// into /dev/null and close it immediately. The
-		// reading part is to facilitate connection reuse.
-		_, _ = io.Copy(ioutil.Discard, response.Body)
-		_ = response.Body.Close()
 		switch response.StatusCode {
 		case http.StatusOK:
+			otlpresponse.ReportPartialSuccess(response.Header.Get("Content-Type"), body)
 			return nil
 		case http.StatusTooManyRequests:
 			fallthrough
 		case http.StatusServiceUnavailable:
+			last = otlpresponse.HTTPError(fmt.Errorf("failed to send data to %s after %d tries", address, d.generalCfg.MaxAttempts), response, body)
+			if i == d.generalCfg.MaxAttempts-1 {
+				// Do not wait when there are no attempts left.
+				break
+			}
+			// Respect server throttling.
+			delay := getWaitDuration(d.generalCfg.Backoff, i)
+			if last.RetryAfter > delay {
+				delay = last.RetryAfter
+			}
 			select {
-			case <-time.After(getWaitDuration(d.generalCfg.Backoff, i)):
+			case <-time.After(delay):
 				continue
 			case <-ctx.Done():
-				return ctx.Err()
+				return &otlpmetric.ExportError{Kind: last.Kind, RetryAfter: last.RetryAfter, Err: ctx.Err()}
 			}
 		default:
-			return fmt.Errorf("failed to send %s to %s with HTTP status %s", d.name, address, response.Status)
+			return otlpresponse.HTTPError(fmt.Errorf("failed to send %s to %s with HTTP status %s", d.name, address, response.Status), response, body)
+		}
+	}
+	if last == nil {
+		return &otlpmetric.ExportError{
+			Kind: otlpmetric.TransportFailure,
+			Err:  fmt.Errorf("failed to send data to %s after %d tries", address, d.generalCfg.MaxAttempts),
 		}
 	}
-	return fmt.Errorf("failed to send data to %s after %d tries", address, d.generalCfg.MaxAttempts)
+	return last
 }
 
 func (d *client) getScheme() string {
@@ -252,7 +274,7 @@ func (d *client) prepareBody(rawRequest []byte) (io.ReadCloser, int64, http.Head
 		headers.Set(k, v)
 	}
 	contentLength := (int64)(len(rawRequest))
-	headers.Set("Content-Type", contentTypeProto)
+	headers.Set("Content-Type", d.contentType())
 	requestReader := bytes.NewBuffer(rawRequest)
 	switch Compression(d.cfg.Compression) {
 	case NoCompression:
@@ -274,3 +296,19 @@ func (d *client) prepareBody(rawRequest []byte) (io.ReadCloser, int64, http.Head
 	}
 	return bodyReader, contentLength, headers
 }
+
+// marshal encodes msg in the format configured for d.
+func (d *client) marshal(msg proto.Message) ([]byte, error) {
+	if Marshaler(d.cfg.Marshaler) == MarshalJSON {
+		return otlpjson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
+
+// contentType returns the content type of the format configured for d.
+func (d *client) contentType() string {
+	if Marshaler(d.cfg.Marshaler) == MarshalJSON {
+		return otlpjson.ContentType
+	}
+	return contentTypeProto
+}
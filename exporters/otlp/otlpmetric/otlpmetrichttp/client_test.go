@@ -16,15 +16,22 @@ package otlpmetrichttp_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpmetrictest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
@@ -66,6 +73,19 @@ func TestEndToEnd(t *testing.T) {
 				otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression),
 			},
 		},
+		{
+			name: "with JSON",
+			opts: []otlpmetrichttp.Option{
+				otlpmetrichttp.WithMarshal(otlpmetrichttp.MarshalJSON),
+			},
+		},
+		{
+			name: "with JSON and gzip compression",
+			opts: []otlpmetrichttp.Option{
+				otlpmetrichttp.WithMarshal(otlpmetrichttp.MarshalJSON),
+				otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression),
+			},
+		},
 		{
 			name: "with empty paths (forced to defaults)",
 			opts: []otlpmetrichttp.Option{
@@ -188,7 +208,10 @@ func TestTimeout(t *testing.T) {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
 	err = exporter.Export(ctx, testResource, oneRecord)
-	assert.Equal(t, true, os.IsTimeout(err))
+	var e *otlpmetric.ExportError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, otlpmetric.TransportFailure, e.Kind)
+	assert.Equal(t, true, os.IsTimeout(e.Err))
 }
 
 func TestRetryFailed(t *testing.T) {
@@ -240,6 +263,9 @@ func TestNoRetry(t *testing.T) {
 	err = exporter.Export(ctx, testResource, oneRecord)
 	assert.Error(t, err)
 	assert.Equal(t, fmt.Sprintf("failed to send metrics to http://%s/v1/metrics with HTTP status 400 Bad Request", mc.endpoint), err.Error())
+	var e *otlpmetric.ExportError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, otlpmetric.Rejected, e.Kind)
 	assert.Empty(t, mc.GetMetrics())
 }
 
@@ -263,6 +289,50 @@ func TestEmptyData(t *testing.T) {
 	assert.NotEmpty(t, mc.GetMetrics())
 }
 
+func TestJSONEncoding(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlpmetrichttp.NewClient(
+		otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithMarshal(otlpmetrichttp.MarshalJSON),
+	)
+	ctx := context.Background()
+	exporter, err := otlpmetric.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.Export(ctx, testResource, oneRecord))
+
+	// The request is decoded by the collector.
+	metrics := mc.GetMetrics()
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "foo", metrics[0].Name)
+
+	requests := mc.GetJSONRequests()
+	require.Len(t, requests, 1)
+	var got struct {
+		ResourceMetrics []struct {
+			InstrumentationLibraryMetrics []struct {
+				Metrics []struct {
+					Name string
+					Sum  map[string]interface{}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(requests[0], &got))
+	m := got.ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0]
+	assert.Equal(t, "foo", m.Name)
+	assert.Equal(t, "AGGREGATION_TEMPORALITY_CUMULATIVE", m.Sum["aggregationTemporality"])
+	assert.Equal(t, true, m.Sum["isMonotonic"])
+	require.Len(t, m.Sum["dataPoints"], 1)
+	dp := m.Sum["dataPoints"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "42", dp["asInt"])
+	assert.Equal(t, "1607454900000000000", dp["startTimeUnixNano"])
+}
+
 func TestUnreasonableMaxAttempts(t *testing.T) {
 	// Max attempts is 5, we set collector to fail 7 times and try
 	// to configure max attempts to be either negative or too
@@ -430,3 +500,180 @@ func TestStopWhileExporting(t *testing.T) {
 	assert.NoError(t, err)
 	<-doneCh
 }
+
+func TestThrottledError(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusTooManyRequests},
+		InjectResponseHeader: []map[string]string{
+			{"Retry-After": "3"},
+		},
+	})
+	defer mc.MustStop(t)
+	driver := otlpmetrichttp.NewClient(
+		otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithMaxAttempts(1),
+	)
+	ctx := context.Background()
+	exporter, err := otlpmetric.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.Export(ctx, testResource, oneRecord)
+	var e *otlpmetric.ExportError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, otlpmetric.Throttled, e.Kind)
+	assert.Equal(t, 3*time.Second, e.RetryAfter)
+	assert.Empty(t, mc.GetMetrics())
+}
+
+func TestRetryAfter(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+		InjectResponseHeader: []map[string]string{
+			{"Retry-After": "1"},
+		},
+	})
+	defer mc.MustStop(t)
+	driver := otlpmetrichttp.NewClient(
+		otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithMaxAttempts(2),
+		otlpmetrichttp.WithBackoff(time.Millisecond),
+	)
+	ctx := context.Background()
+	exporter, err := otlpmetric.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	start := time.Now()
+	require.NoError(t, exporter.Export(ctx, testResource, oneRecord))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(time.Second))
+	assert.Len(t, mc.GetMetrics(), 1)
+}
+
+// errorHandler sends the errors it handles to the channel of the running
+// test.
+type errorHandler struct {
+	mu sync.Mutex
+	ch chan error
+}
+
+func (h *errorHandler) Handle(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ch != nil {
+		h.ch <- err
+	}
+}
+
+func (h *errorHandler) set(ch chan error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ch = ch
+}
+
+// The global error handler can only be set once.
+var testErrorHandler = &errorHandler{}
+
+func init() {
+	otel.SetErrorHandler(testErrorHandler)
+}
+
+func TestPartialSuccess(t *testing.T) {
+	var partialSuccess []byte
+	partialSuccess = protowire.AppendTag(partialSuccess, 1, protowire.VarintType)
+	partialSuccess = protowire.AppendVarint(partialSuccess, 2)
+	partialSuccess = protowire.AppendTag(partialSuccess, 2, protowire.BytesType)
+	partialSuccess = protowire.AppendString(partialSuccess, "invalid data points")
+	var protoResponse []byte
+	protoResponse = protowire.AppendTag(protoResponse, 1, protowire.BytesType)
+	protoResponse = protowire.AppendBytes(protoResponse, partialSuccess)
+
+	tests := []struct {
+		name  string
+		mcCfg mockCollectorConfig
+		opts  []otlpmetrichttp.Option
+	}{
+		{
+			name: "protobuf",
+			mcCfg: mockCollectorConfig{
+				InjectResponseBody: protoResponse,
+			},
+		},
+		{
+			name: "JSON",
+			mcCfg: mockCollectorConfig{
+				InjectContentType:  "application/json",
+				InjectResponseBody: []byte(`{"partialSuccess":{"rejectedDataPoints":"2","errorMessage":"invalid data points"}}`),
+			},
+			opts: []otlpmetrichttp.Option{
+				otlpmetrichttp.WithMarshal(otlpmetrichttp.MarshalJSON),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			testErrorHandler.set(errs)
+			defer testErrorHandler.set(nil)
+
+			mc := runMockCollector(t, test.mcCfg)
+			defer mc.MustStop(t)
+			opts := append([]otlpmetrichttp.Option{
+				otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+				otlpmetrichttp.WithInsecure(),
+			}, test.opts...)
+			ctx := context.Background()
+			exporter, err := otlpmetric.New(ctx, otlpmetrichttp.NewClient(opts...))
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(ctx))
+			}()
+			require.NoError(t, exporter.Export(ctx, testResource, oneRecord))
+			assert.Len(t, mc.GetMetrics(), 1)
+
+			select {
+			case err := <-errs:
+				assert.Equal(t, otlpmetric.PartialSuccess{RejectedDataPoints: 2, ErrorMessage: "invalid data points"}, err)
+			default:
+				t.Error("partial success not reported")
+			}
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	clientCert, err := generateWeakCertificate()
+	require.NoError(t, err)
+	mc := runMockCollector(t, mockCollectorConfig{
+		WithTLS:           true,
+		ClientCertificate: clientCert.Certificate,
+	})
+	defer mc.MustStop(t)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, mc.Certificate(), 0600))
+	require.NoError(t, ioutil.WriteFile(certFile, clientCert.Certificate, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, clientCert.PrivateKey, 0600))
+
+	driver := otlpmetrichttp.NewClient(
+		otlpmetrichttp.WithEndpoint(mc.Endpoint()),
+		otlpmetrichttp.WithCertificateFile(caFile),
+		otlpmetrichttp.WithClientCertificateFiles(certFile, keyFile),
+		otlpmetrichttp.WithMaxAttempts(1),
+	)
+	ctx := context.Background()
+	exporter, err := otlpmetric.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.Export(ctx, testResource, oneRecord))
+	assert.Len(t, mc.GetMetrics(), 1)
+}
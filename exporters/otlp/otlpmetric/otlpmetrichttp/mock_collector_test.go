@@ -19,6 +19,7 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -33,6 +34,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpjson"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otlpmetrictest"
 	collectormetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
@@ -44,12 +46,16 @@ type mockCollector struct {
 
 	spanLock       sync.Mutex
 	metricsStorage otlpmetrictest.MetricsStorage
+	jsonRequests   [][]byte
 
-	injectHTTPStatus  []int
-	injectContentType string
-	injectDelay       time.Duration
+	injectHTTPStatus     []int
+	injectResponseHeader []map[string]string
+	injectContentType    string
+	injectResponseBody   []byte
+	injectDelay          time.Duration
 
 	clientTLSConfig *tls.Config
+	certificate     []byte
 	expectedHeaders map[string]string
 }
 
@@ -67,6 +73,14 @@ func (c *mockCollector) GetMetrics() []*metricpb.Metric {
 	return c.metricsStorage.GetMetrics()
 }
 
+// GetJSONRequests returns the bodies of the requests received in the
+// OTLP/JSON format.
+func (c *mockCollector) GetJSONRequests() [][]byte {
+	c.spanLock.Lock()
+	defer c.spanLock.Unlock()
+	return c.jsonRequests
+}
+
 func (c *mockCollector) Endpoint() string {
 	return c.endpoint
 }
@@ -75,6 +89,12 @@ func (c *mockCollector) ClientTLSConfig() *tls.Config {
 	return c.clientTLSConfig
 }
 
+// Certificate returns the PEM encoded certificate of the collector when it
+// runs with TLS.
+func (c *mockCollector) Certificate() []byte {
+	return c.certificate
+}
+
 func (c *mockCollector) serveMetrics(w http.ResponseWriter, r *http.Request) {
 	if c.injectDelay != 0 {
 		time.Sleep(c.injectDelay)
@@ -90,8 +110,12 @@ func (c *mockCollector) serveMetrics(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	if c.injectResponseBody != nil {
+		rawResponse = c.injectResponseBody
+	}
+	h := c.getInjectResponseHeader()
 	if injectedStatus := c.getInjectHTTPStatus(); injectedStatus != 0 {
-		writeReply(w, rawResponse, injectedStatus, c.injectContentType)
+		writeReply(w, rawResponse, injectedStatus, c.injectContentType, h)
 		return
 	}
 	rawRequest, err := readRequest(r)
@@ -100,24 +124,30 @@ func (c *mockCollector) serveMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	request, err := unmarshalMetricsRequest(rawRequest, r.Header.Get("content-type"))
+	contentType := r.Header.Get("content-type")
+	request, err := unmarshalMetricsRequest(rawRequest, contentType)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	writeReply(w, rawResponse, 0, c.injectContentType)
+	writeReply(w, rawResponse, 0, c.injectContentType, h)
 	c.spanLock.Lock()
 	defer c.spanLock.Unlock()
 	c.metricsStorage.AddMetrics(request)
+	if contentType == otlpjson.ContentType {
+		c.jsonRequests = append(c.jsonRequests, rawRequest)
+	}
 }
 
 func unmarshalMetricsRequest(rawRequest []byte, contentType string) (*collectormetricpb.ExportMetricsServiceRequest, error) {
 	request := &collectormetricpb.ExportMetricsServiceRequest{}
-	if contentType != "application/x-protobuf" {
-		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf is supported", contentType)
+	switch contentType {
+	case "application/x-protobuf":
+		return request, proto.Unmarshal(rawRequest, request)
+	case otlpjson.ContentType:
+		return request, otlpjson.Unmarshal(rawRequest, request)
 	}
-	err := proto.Unmarshal(rawRequest, request)
-	return request, err
+	return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf and application/json are supported", contentType)
 }
 
 func (c *mockCollector) checkHeaders(r *http.Request) bool {
@@ -142,6 +172,17 @@ func (c *mockCollector) getInjectHTTPStatus() int {
 	return status
 }
 
+func (c *mockCollector) getInjectResponseHeader() (h map[string]string) {
+	if len(c.injectResponseHeader) == 0 {
+		return
+	}
+	h, c.injectResponseHeader = c.injectResponseHeader[0], c.injectResponseHeader[1:]
+	if len(c.injectResponseHeader) == 0 {
+		c.injectResponseHeader = nil
+	}
+	return
+}
+
 func readRequest(r *http.Request) ([]byte, error) {
 	if r.Header.Get("Content-Encoding") == "gzip" {
 		return readGzipBody(r.Body)
@@ -163,7 +204,7 @@ func readGzipBody(body io.Reader) ([]byte, error) {
 	return rawRequest.Bytes(), nil
 }
 
-func writeReply(w http.ResponseWriter, rawResponse []byte, injectHTTPStatus int, injectContentType string) {
+func writeReply(w http.ResponseWriter, rawResponse []byte, injectHTTPStatus int, injectContentType string, injectResponseHeader map[string]string) {
 	status := http.StatusOK
 	if injectHTTPStatus != 0 {
 		status = injectHTTPStatus
@@ -173,18 +214,24 @@ func writeReply(w http.ResponseWriter, rawResponse []byte, injectHTTPStatus int,
 		contentType = injectContentType
 	}
 	w.Header().Set("Content-Type", contentType)
+	for k, v := range injectResponseHeader {
+		w.Header().Add(k, v)
+	}
 	w.WriteHeader(status)
 	_, _ = w.Write(rawResponse)
 }
 
 type mockCollectorConfig struct {
-	MetricsURLPath    string
-	Port              int
-	InjectHTTPStatus  []int
-	InjectContentType string
-	InjectDelay       time.Duration
-	WithTLS           bool
-	ExpectedHeaders   map[string]string
+	MetricsURLPath       string
+	Port                 int
+	InjectHTTPStatus     []int
+	InjectContentType    string
+	InjectResponseHeader []map[string]string
+	InjectResponseBody   []byte
+	InjectDelay          time.Duration
+	WithTLS              bool
+	ClientCertificate    []byte
+	ExpectedHeaders      map[string]string
 }
 
 func (c *mockCollectorConfig) fillInDefaults() {
@@ -200,12 +247,14 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 	_, portStr, err := net.SplitHostPort(ln.Addr().String())
 	require.NoError(t, err)
 	m := &mockCollector{
-		endpoint:          fmt.Sprintf("localhost:%s", portStr),
-		metricsStorage:    otlpmetrictest.NewMetricsStorage(),
-		injectHTTPStatus:  cfg.InjectHTTPStatus,
-		injectContentType: cfg.InjectContentType,
-		injectDelay:       cfg.InjectDelay,
-		expectedHeaders:   cfg.ExpectedHeaders,
+		endpoint:             fmt.Sprintf("localhost:%s", portStr),
+		metricsStorage:       otlpmetrictest.NewMetricsStorage(),
+		injectHTTPStatus:     cfg.InjectHTTPStatus,
+		injectResponseHeader: cfg.InjectResponseHeader,
+		injectContentType:    cfg.InjectContentType,
+		injectResponseBody:   cfg.InjectResponseBody,
+		injectDelay:          cfg.InjectDelay,
+		expectedHeaders:      cfg.ExpectedHeaders,
 	}
 	mux := http.NewServeMux()
 	mux.Handle(cfg.MetricsURLPath, http.HandlerFunc(m.serveMetrics))
@@ -220,6 +269,13 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		server.TLSConfig = &tls.Config{
 			Certificates: []tls.Certificate{tlsCertificate},
 		}
+		if cfg.ClientCertificate != nil {
+			clientCAs := x509.NewCertPool()
+			require.True(t, clientCAs.AppendCertsFromPEM(cfg.ClientCertificate))
+			server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			server.TLSConfig.ClientCAs = clientCAs
+		}
+		m.certificate = pem.Certificate
 
 		m.clientTLSConfig = &tls.Config{
 			InsecureSkipVerify: true,
@@ -22,7 +22,6 @@ import (
 	"time"
 	"unsafe"
 
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/encoding/gzip"
@@ -30,6 +29,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpresponse"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/retry"
 )
 
@@ -289,18 +289,27 @@ func (c *Connection) ContextWithStop(ctx context.Context) (context.Context, cont
 	return ctx, cancel
 }
 
+// DoRequest calls fn, retrying it as configured. The error returned is an
+// *otlptrace.ExportError classified according to the status returned by the
+// last call.
 func (c *Connection) DoRequest(ctx context.Context, fn func(context.Context) error) error {
 	ctx, cancel := c.ContextWithStop(ctx)
 	defer cancel()
-	return c.requestFunc(ctx, func(ctx context.Context) error {
+	var last error
+	err := c.requestFunc(ctx, func(ctx context.Context) error {
 		err := fn(ctx)
 		// nil is converted to OK.
 		if status.Code(err) == codes.OK {
 			// Success.
 			return nil
 		}
+		last = err
 		return err
 	})
+	if err != nil {
+		return otlpresponse.GRPCError(err, last)
+	}
+	return nil
 }
 
 // evaluate returns if err is retry-able and a duration to wait for if an
@@ -325,10 +334,6 @@ func evaluate(err error) (bool, time.Duration) {
 // throttleDelay returns a duration to wait for if an explicit throttle time
 // is included in the response status.
 func throttleDelay(status *status.Status) time.Duration {
-	for _, detail := range status.Details() {
-		if t, ok := detail.(*errdetails.RetryInfo); ok {
-			return t.RetryDelay.AsDuration()
-		}
-	}
-	return 0
+	delay, _ := otlpresponse.RetryInfoDelay(status.Details())
+	return delay
 }
@@ -15,7 +15,6 @@
 package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 
 import (
-	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -92,18 +91,18 @@ func (e *EnvOptionsReader) GetOptionsFromEnv() []GenericOption {
 
 	// Certificate File
 	if path, ok := e.getEnvValue("CERTIFICATE"); ok {
-		if tls, err := e.readTLSConfig(path); err == nil {
-			opts = append(opts, WithTLSClientConfig(tls))
-		} else {
-			otel.Handle(fmt.Errorf("failed to configure otlp exporter certificate '%s': %w", path, err))
-		}
+		opts = append(opts, withCertificateFile(path, e.ReadFile))
 	}
 	if path, ok := e.getEnvValue("TRACES_CERTIFICATE"); ok {
-		if tls, err := e.readTLSConfig(path); err == nil {
-			opts = append(opts, WithTLSClientConfig(tls))
-		} else {
-			otel.Handle(fmt.Errorf("failed to configure otlp traces exporter certificate '%s': %w", path, err))
-		}
+		opts = append(opts, withCertificateFile(path, e.ReadFile))
+	}
+
+	// Client Certificate and Key Files
+	if opt, ok := e.clientCertificateOption("CLIENT_CERTIFICATE", "CLIENT_KEY"); ok {
+		opts = append(opts, opt)
+	}
+	if opt, ok := e.clientCertificateOption("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY"); ok {
+		opts = append(opts, opt)
 	}
 
 	// Headers
@@ -121,6 +120,17 @@ func (e *EnvOptionsReader) GetOptionsFromEnv() []GenericOption {
 	if c, ok := e.getEnvValue("TRACES_COMPRESSION"); ok {
 		opts = append(opts, WithCompression(stringToCompression(c)))
 	}
+	// Protocol
+	if p, ok := e.getEnvValue("PROTOCOL"); ok {
+		if m, ok := stringToMarshaler(p); ok {
+			opts = append(opts, WithMarshal(m))
+		}
+	}
+	if p, ok := e.getEnvValue("TRACES_PROTOCOL"); ok {
+		if m, ok := stringToMarshaler(p); ok {
+			opts = append(opts, WithMarshal(m))
+		}
+	}
 	// Timeout
 	if t, ok := e.getEnvValue("TIMEOUT"); ok {
 		if d, err := strconv.Atoi(t); err == nil {
@@ -151,12 +161,32 @@ func (e *EnvOptionsReader) getEnvValue(key string) (string, bool) {
 	return v, v != ""
 }
 
-func (e *EnvOptionsReader) readTLSConfig(path string) (*tls.Config, error) {
-	b, err := e.ReadFile(path)
-	if err != nil {
-		return nil, err
+// clientCertificateOption returns the option setting the client certificate
+// and key files of the environment variables certKey and keyKey. It returns
+// false if they are not set, and reports an error if only one of them is.
+func (e *EnvOptionsReader) clientCertificateOption(certKey, keyKey string) (GenericOption, bool) {
+	certFile, certOk := e.getEnvValue(certKey)
+	keyFile, keyOk := e.getEnvValue(keyKey)
+	if certOk != keyOk {
+		otel.Handle(fmt.Errorf("failed to configure otlp exporter client certificate: both OTEL_EXPORTER_OTLP_%s and OTEL_EXPORTER_OTLP_%s must be set", certKey, keyKey))
+		return nil, false
+	}
+	if !certOk {
+		return nil, false
+	}
+	return withClientCertificateFiles(certFile, keyFile, e.ReadFile), true
+}
+
+// stringToMarshaler returns the Marshaler of the HTTP protocol value. It
+// returns false for other protocols.
+func stringToMarshaler(value string) (Marshaler, bool) {
+	switch value {
+	case "http/protobuf":
+		return MarshalProto, true
+	case "http/json":
+		return MarshalJSON, true
 	}
-	return CreateTLSConfig(b)
+	return MarshalProto, false
 }
 
 func stringToCompression(value string) Compression {
@@ -17,6 +17,7 @@ package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/
 import (
 	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"time"
 
 	"google.golang.org/grpc"
@@ -43,9 +44,14 @@ type (
 		Compression Compression
 		Timeout     time.Duration
 		URLPath     string
+		Marshaler   Marshaler
 
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
+
+		// serverVerifier verifies the server certificates for TLSCfg if
+		// they are verified with a reloaded certificate file.
+		serverVerifier *serverVerifier
 	}
 
 	Config struct {
@@ -194,6 +200,12 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithMarshal(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg *Config) {
+		cfg.Traces.Marshaler = m
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg *Config) {
 		cfg.Traces.URLPath = urlPath
@@ -209,11 +221,63 @@ func WithRetry(rc retry.Config) GenericOption {
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg *Config) {
 		cfg.Traces.TLSCfg = tlsCfg.Clone()
+		cfg.Traces.serverVerifier = nil
 	}, func(cfg *Config) {
+		// The configuration is kept so the certificate files options
+		// can extend it.
+		cfg.Traces.TLSCfg = tlsCfg.Clone()
+		cfg.Traces.serverVerifier = nil
 		cfg.Traces.GRPCCredentials = credentials.NewTLS(tlsCfg)
 	})
 }
 
+// WithCertificateFile sets the TLS configuration to verify the server
+// certificates with the PEM encoded certificates of the file path. The file
+// is read again when it changes.
+func WithCertificateFile(path string) GenericOption {
+	return withCertificateFile(path, ioutil.ReadFile)
+}
+
+func withCertificateFile(path string, readFile func(string) ([]byte, error)) GenericOption {
+	r := newFileReloader(readFile, parseCertPool, path)
+	return newTLSOption(func(cfg *Config, tlsCfg *tls.Config) {
+		reloadRootCAs(cfg, tlsCfg, path, r)
+	})
+}
+
+// WithClientCertificateFiles sets the TLS configuration to authenticate with
+// the PEM encoded client certificate and private key of the files certFile
+// and keyFile. The files are read again when they change.
+func WithClientCertificateFiles(certFile, keyFile string) GenericOption {
+	return withClientCertificateFiles(certFile, keyFile, ioutil.ReadFile)
+}
+
+func withClientCertificateFiles(certFile, keyFile string, readFile func(string) ([]byte, error)) GenericOption {
+	r := newFileReloader(readFile, parseKeyPair, certFile, keyFile)
+	return newTLSOption(func(_ *Config, tlsCfg *tls.Config) {
+		reloadClientCertificate(tlsCfg, certFile, keyFile, r)
+	})
+}
+
+// newTLSOption returns an option updating a copy of the TLS configuration
+// with fn.
+func newTLSOption(fn func(*Config, *tls.Config)) GenericOption {
+	update := func(cfg *Config) *tls.Config {
+		tlsCfg := &tls.Config{}
+		if cfg.Traces.TLSCfg != nil {
+			tlsCfg = cfg.Traces.TLSCfg.Clone()
+		}
+		fn(cfg, tlsCfg)
+		cfg.Traces.TLSCfg = tlsCfg
+		return tlsCfg
+	}
+	return newSplitOption(func(cfg *Config) {
+		update(cfg)
+	}, func(cfg *Config) {
+		cfg.Traces.GRPCCredentials = credentials.NewTLS(update(cfg))
+	})
+}
+
 func WithInsecure() GenericOption {
 	return newGenericOption(func(cfg *Config) {
 		cfg.Traces.Insecure = true
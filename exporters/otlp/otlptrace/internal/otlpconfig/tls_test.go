@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+)
+
+type keyPair struct {
+	cert    tls.Certificate
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newKeyPair returns a self-signed certificate for hosts, or localhost if
+// none is given, usable by both servers and clients.
+func newKeyPair(t *testing.T, org string, hosts ...string) keyPair {
+	if len(hosts) == 0 {
+		hosts = []string{"localhost"}
+	}
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{Organization: []string{org}},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	kp := keyPair{
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+	}
+	kp.cert, err = tls.X509KeyPair(kp.certPEM, kp.keyPEM)
+	require.NoError(t, err)
+	return kp
+}
+
+// handshake runs a TLS handshake between a client using clientCfg and a
+// server using serverCfg. It returns the error of the client, or else of
+// the server, and the client certificates received by the server.
+func handshake(clientCfg, serverCfg *tls.Config) ([]*x509.Certificate, error) {
+	return handshakeWithServerName("localhost", clientCfg, serverCfg)
+}
+
+// handshakeWithServerName runs a handshake like handshake, with the
+// client dialing serverName.
+func handshakeWithServerName(serverName string, clientCfg, serverCfg *tls.Config) ([]*x509.Certificate, error) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	type result struct {
+		certs []*x509.Certificate
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		conn := tls.Server(serverConn, serverCfg)
+		err := conn.Handshake()
+		if err != nil {
+			serverConn.Close()
+		}
+		done <- result{conn.ConnectionState().PeerCertificates, err}
+	}()
+
+	clientCfg = clientCfg.Clone()
+	clientCfg.ServerName = serverName
+	conn := tls.Client(clientConn, clientCfg)
+	err := conn.Handshake()
+	if err == nil {
+		// Complete the handshake of the server in TLS 1.3.
+		_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		_, _ = conn.Read(make([]byte, 1))
+	}
+	clientConn.Close()
+	r := <-done
+	if err != nil {
+		return nil, err
+	}
+	return r.certs, r.err
+}
+
+func newHTTPConfig(opts ...otlpconfig.GenericOption) otlpconfig.Config {
+	cfg := otlpconfig.NewDefaultConfig()
+	for _, opt := range opts {
+		opt.ApplyHTTPOption(&cfg)
+	}
+	return cfg
+}
+
+func TestCertificateFileReload(t *testing.T) {
+	first, second := newKeyPair(t, "first"), newKeyPair(t, "second")
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(path, first.certPEM, 0600))
+
+	cfg := newHTTPConfig(otlpconfig.WithCertificateFile(path))
+	require.NotNil(t, cfg.Traces.TLSCfg)
+
+	_, err := handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{first.cert}})
+	assert.NoError(t, err)
+
+	// The server certificate is renewed before the trusted certificates.
+	_, err = handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{second.cert}})
+	assert.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, second.certPEM, 0600))
+	_, err = handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{second.cert}})
+	assert.NoError(t, err)
+
+	// An invalid file does not replace the previous certificates.
+	require.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0600))
+	_, err = handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{second.cert}})
+	assert.NoError(t, err)
+}
+
+func TestClientCertificateFilesReload(t *testing.T) {
+	server, first, second := newKeyPair(t, "server"), newKeyPair(t, "first"), newKeyPair(t, "second")
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, server.certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(certFile, first.certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, first.keyPEM, 0600))
+
+	cfg := newHTTPConfig(
+		otlpconfig.WithCertificateFile(caFile),
+		otlpconfig.WithClientCertificateFiles(certFile, keyFile),
+	)
+	require.NotNil(t, cfg.Traces.TLSCfg)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(first.certPEM)
+	clientCAs.AppendCertsFromPEM(second.certPEM)
+	serverCfg := &tls.Config{
+		Certificates: []tls.Certificate{server.cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+
+	certs, err := handshake(cfg.Traces.TLSCfg, serverCfg)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, []string{"first"}, certs[0].Subject.Organization)
+
+	require.NoError(t, ioutil.WriteFile(certFile, second.certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, second.keyPEM, 0600))
+	certs, err = handshake(cfg.Traces.TLSCfg, serverCfg)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, []string{"second"}, certs[0].Subject.Organization)
+}
+
+func TestCertificateFileMissing(t *testing.T) {
+	kp := newKeyPair(t, "server")
+	path := filepath.Join(t.TempDir(), "ca.pem")
+
+	cfg := newHTTPConfig(otlpconfig.WithCertificateFile(path))
+	require.NotNil(t, cfg.Traces.TLSCfg)
+
+	// The connections are refused until the file is written.
+	_, err := handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{kp.cert}})
+	assert.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, kp.certPEM, 0600))
+	_, err = handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{kp.cert}})
+	assert.NoError(t, err)
+}
+
+func TestCertificateFileIPEndpoint(t *testing.T) {
+	localhost, ip := newKeyPair(t, "localhost"), newKeyPair(t, "ip", "127.0.0.1")
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(path, localhost.certPEM, 0600))
+
+	// The transports set the ServerName to the dialed host, the client
+	// does not send it for IP addresses.
+	cfg := newHTTPConfig(
+		otlpconfig.WithEndpoint("127.0.0.1:4318"),
+		otlpconfig.WithCertificateFile(path),
+	)
+	_, err := handshakeWithServerName("127.0.0.1", cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{localhost.cert}})
+	assert.Error(t, err, "certificate issued for another name")
+
+	require.NoError(t, ioutil.WriteFile(path, ip.certPEM, 0600))
+	_, err = handshakeWithServerName("127.0.0.1", cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{ip.cert}})
+	assert.NoError(t, err)
+}
+
+func TestCertificateFileNoServerName(t *testing.T) {
+	kp := newKeyPair(t, "server", "127.0.0.1")
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(path, kp.certPEM, 0600))
+
+	cfg := newHTTPConfig(
+		otlpconfig.WithEndpoint(""),
+		otlpconfig.WithCertificateFile(path),
+	)
+	_, err := handshakeWithServerName("", cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{kp.cert}})
+	assert.Error(t, err)
+}
+
+func TestCertificateFileVerifyConnection(t *testing.T) {
+	kp := newKeyPair(t, "server")
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(path, kp.certPEM, 0600))
+
+	errVerify := errors.New("verify")
+	var called int
+	cfg := newHTTPConfig(
+		otlpconfig.WithTLSClientConfig(&tls.Config{
+			VerifyConnection: func(tls.ConnectionState) error {
+				called++
+				return errVerify
+			},
+		}),
+		otlpconfig.WithCertificateFile(path),
+		// A second file replaces the certificates of the first one.
+		otlpconfig.WithCertificateFile(path),
+	)
+	_, err := handshake(cfg.Traces.TLSCfg, &tls.Config{Certificates: []tls.Certificate{kp.cert}})
+	assert.ErrorIs(t, err, errVerify)
+	assert.Equal(t, 1, called)
+}
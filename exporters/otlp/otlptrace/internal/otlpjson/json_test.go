@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func TestMarshal(t *testing.T) {
+	span := &tracepb.Span{
+		TraceId:           []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanId:            []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		ParentSpanId:      []byte{0xff, 0, 0, 0, 0, 0, 0, 0},
+		Kind:              tracepb.Span_SPAN_KIND_SERVER,
+		StartTimeUnixNano: 1600000000000000000,
+		Links: []*tracepb.Span_Link{{
+			TraceId: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+			SpanId:  []byte{0x0a, 0, 0, 0, 0, 0, 0, 0},
+		}},
+	}
+	b, err := Marshal(span)
+	require.NoError(t, err)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", got["traceId"])
+	assert.Equal(t, "0102030405060708", got["spanId"])
+	assert.Equal(t, "ff00000000000000", got["parentSpanId"])
+	assert.Equal(t, "SPAN_KIND_SERVER", got["kind"])
+	assert.Equal(t, "1600000000000000000", got["startTimeUnixNano"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"traceId": "0102030405060708090a0b0c0d0e0f10",
+		"spanId":  "0a00000000000000",
+	}}, got["links"])
+
+	decoded := new(tracepb.Span)
+	require.NoError(t, Unmarshal(b, decoded))
+	assert.True(t, proto.Equal(span, decoded))
+}
+
+func TestUnmarshalInvalidID(t *testing.T) {
+	err := Unmarshal([]byte(`{"traceId":"not hex"}`), new(tracepb.Span))
+	assert.Error(t, err)
+}
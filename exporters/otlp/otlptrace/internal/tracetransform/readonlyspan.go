@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetransform // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/tracetransform"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// ReadOnlySpans transforms a slice of OTLP ResourceSpans into a slice of
+// OpenTelemetry spans. It is the inverse of Spans.
+//
+// The spans are reported as sampled. Attribute values that cannot be
+// represented by an attribute.Value, such as nested arrays, maps, and bytes,
+// are dropped.
+func ReadOnlySpans(rss []*tracepb.ResourceSpans) []tracesdk.ReadOnlySpan {
+	var out []tracesdk.ReadOnlySpan
+	for _, rs := range rss {
+		if rs == nil {
+			continue
+		}
+		res := resource.NewWithAttributes(rs.SchemaUrl, keyValuesFromProto(rs.GetResource().GetAttributes())...)
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			if ils == nil {
+				continue
+			}
+			il := instrumentation.Library{
+				Name:      ils.GetInstrumentationLibrary().GetName(),
+				Version:   ils.GetInstrumentationLibrary().GetVersion(),
+				SchemaURL: ils.SchemaUrl,
+			}
+			for _, s := range ils.Spans {
+				if s == nil {
+					continue
+				}
+				stub := spanStub(s)
+				stub.Resource = res
+				stub.InstrumentationLibrary = il
+				out = append(out, stub.Snapshot())
+			}
+		}
+	}
+	return out
+}
+
+// spanStub transforms an OTLP span into a SpanStub.
+func spanStub(s *tracepb.Span) tracetest.SpanStub {
+	sc := spanContextFromProto(s.TraceId, s.SpanId, s.TraceState)
+	stub := tracetest.SpanStub{
+		Name:              s.Name,
+		SpanContext:       sc,
+		SpanKind:          spanKindFromProto(s.Kind),
+		StartTime:         timeFromProto(s.StartTimeUnixNano),
+		EndTime:           timeFromProto(s.EndTimeUnixNano),
+		Attributes:        keyValuesFromProto(s.Attributes),
+		Events:            spanEventsFromProto(s.Events),
+		Links:             linksFromProto(s.Links),
+		Status:            statusFromProto(s.Status),
+		DroppedAttributes: int(s.DroppedAttributesCount),
+		DroppedEvents:     int(s.DroppedEventsCount),
+		DroppedLinks:      int(s.DroppedLinksCount),
+	}
+	var psid trace.SpanID
+	copy(psid[:], s.ParentSpanId)
+	if psid.IsValid() {
+		stub.Parent = sc.WithSpanID(psid).WithTraceState(trace.TraceState{})
+	}
+	return stub
+}
+
+// spanContextFromProto returns the sampled SpanContext identified by the
+// OTLP encoded trace and span IDs and trace state.
+func spanContextFromProto(traceID, spanID []byte, traceState string) trace.SpanContext {
+	cfg := trace.SpanContextConfig{TraceFlags: trace.FlagsSampled}
+	copy(cfg.TraceID[:], traceID)
+	copy(cfg.SpanID[:], spanID)
+	// An invalid trace state is dropped.
+	cfg.TraceState, _ = trace.ParseTraceState(traceState)
+	return trace.NewSpanContext(cfg)
+}
+
+// timeFromProto transforms an OTLP timestamp into a time.Time.
+func timeFromProto(nsec uint64) time.Time {
+	if nsec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nsec))
+}
+
+// statusFromProto transforms an OTLP span status into a span status.
+func statusFromProto(status *tracepb.Status) tracesdk.Status {
+	switch status.GetCode() {
+	case tracepb.Status_STATUS_CODE_OK:
+		return tracesdk.Status{Code: codes.Ok}
+	case tracepb.Status_STATUS_CODE_ERROR:
+		return tracesdk.Status{Code: codes.Error, Description: status.GetMessage()}
+	default:
+		return tracesdk.Status{Code: codes.Unset}
+	}
+}
+
+// linksFromProto transforms OTLP span links into span Links.
+func linksFromProto(links []*tracepb.Span_Link) []tracesdk.Link {
+	if len(links) == 0 {
+		return nil
+	}
+
+	out := make([]tracesdk.Link, 0, len(links))
+	for _, l := range links {
+		out = append(out, tracesdk.Link{
+			SpanContext:           spanContextFromProto(l.TraceId, l.SpanId, l.TraceState),
+			Attributes:            keyValuesFromProto(l.Attributes),
+			DroppedAttributeCount: int(l.DroppedAttributesCount),
+		})
+	}
+	return out
+}
+
+// spanEventsFromProto transforms OTLP span events into span Events.
+func spanEventsFromProto(es []*tracepb.Span_Event) []tracesdk.Event {
+	if len(es) == 0 {
+		return nil
+	}
+
+	out := make([]tracesdk.Event, 0, len(es))
+	for _, e := range es {
+		out = append(out, tracesdk.Event{
+			Name:                  e.Name,
+			Attributes:            keyValuesFromProto(e.Attributes),
+			DroppedAttributeCount: int(e.DroppedAttributesCount),
+			Time:                  timeFromProto(e.TimeUnixNano),
+		})
+	}
+	return out
+}
+
+// spanKindFromProto transforms an OTLP span kind into a SpanKind.
+func spanKindFromProto(kind tracepb.Span_SpanKind) trace.SpanKind {
+	switch kind {
+	case tracepb.Span_SPAN_KIND_INTERNAL:
+		return trace.SpanKindInternal
+	case tracepb.Span_SPAN_KIND_CLIENT:
+		return trace.SpanKindClient
+	case tracepb.Span_SPAN_KIND_SERVER:
+		return trace.SpanKindServer
+	case tracepb.Span_SPAN_KIND_PRODUCER:
+		return trace.SpanKindProducer
+	case tracepb.Span_SPAN_KIND_CONSUMER:
+		return trace.SpanKindConsumer
+	default:
+		return trace.SpanKindUnspecified
+	}
+}
+
+// keyValuesFromProto transforms OTLP key-values into attribute KeyValues.
+// The key-values with a value that cannot be represented are dropped.
+func keyValuesFromProto(kvs []*commonpb.KeyValue) []attribute.KeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	out := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		if v, ok := valueFromProto(kv.GetValue()); ok {
+			out = append(out, attribute.KeyValue{Key: attribute.Key(kv.GetKey()), Value: v})
+		}
+	}
+	return out
+}
+
+// valueFromProto transforms an OTLP AnyValue into an attribute Value. It
+// returns false if av cannot be represented by an attribute Value.
+func valueFromProto(av *commonpb.AnyValue) (attribute.Value, bool) {
+	switch v := av.GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		return attribute.BoolValue(v.BoolValue), true
+	case *commonpb.AnyValue_IntValue:
+		return attribute.Int64Value(v.IntValue), true
+	case *commonpb.AnyValue_DoubleValue:
+		return attribute.Float64Value(v.DoubleValue), true
+	case *commonpb.AnyValue_StringValue:
+		return attribute.StringValue(v.StringValue), true
+	case *commonpb.AnyValue_ArrayValue:
+		return sliceValueFromProto(v.ArrayValue.GetValues())
+	}
+	return attribute.Value{}, false
+}
+
+// sliceValueFromProto transforms the values of an OTLP ArrayValue into a
+// slice attribute Value. It returns false if the values are not all of the
+// same scalar type.
+func sliceValueFromProto(vals []*commonpb.AnyValue) (attribute.Value, bool) {
+	if len(vals) == 0 {
+		return attribute.StringSliceValue(nil), true
+	}
+
+	switch vals[0].GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		s := make([]bool, len(vals))
+		for i, v := range vals {
+			bv, ok := v.GetValue().(*commonpb.AnyValue_BoolValue)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			s[i] = bv.BoolValue
+		}
+		return attribute.BoolSliceValue(s), true
+	case *commonpb.AnyValue_IntValue:
+		s := make([]int64, len(vals))
+		for i, v := range vals {
+			iv, ok := v.GetValue().(*commonpb.AnyValue_IntValue)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			s[i] = iv.IntValue
+		}
+		return attribute.Int64SliceValue(s), true
+	case *commonpb.AnyValue_DoubleValue:
+		s := make([]float64, len(vals))
+		for i, v := range vals {
+			dv, ok := v.GetValue().(*commonpb.AnyValue_DoubleValue)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			s[i] = dv.DoubleValue
+		}
+		return attribute.Float64SliceValue(s), true
+	case *commonpb.AnyValue_StringValue:
+		s := make([]string, len(vals))
+		for i, v := range vals {
+			sv, ok := v.GetValue().(*commonpb.AnyValue_StringValue)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			s[i] = sv.StringValue
+		}
+		return attribute.StringSliceValue(s), true
+	}
+	return attribute.Value{}, false
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetransform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func TestReadOnlySpansRoundTrip(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	want := tracetest.SpanStub{
+		Name:        "span",
+		SpanContext: sc,
+		Parent:      sc.WithSpanID(trace.SpanID{0x03}),
+		SpanKind:    trace.SpanKindClient,
+		StartTime:   start,
+		EndTime:     start.Add(time.Second),
+		Attributes: []attribute.KeyValue{
+			attribute.Bool("bool", true),
+			attribute.Int64("int", 1),
+			attribute.Float64("float", 1.5),
+			attribute.String("string", "value"),
+			attribute.BoolSlice("bools", []bool{true, false}),
+			attribute.Int64Slice("ints", []int64{1, 2}),
+			attribute.Float64Slice("floats", []float64{1.5, 2.5}),
+			attribute.StringSlice("strings", []string{"a", "b"}),
+		},
+		Events: []tracesdk.Event{
+			{Name: "event", Time: start.Add(time.Millisecond), Attributes: []attribute.KeyValue{attribute.Int("n", 1)}},
+		},
+		Links: []tracesdk.Link{
+			{SpanContext: sc.WithSpanID(trace.SpanID{0x04}), Attributes: []attribute.KeyValue{attribute.String("k", "v")}},
+		},
+		Status:                 tracesdk.Status{Code: codes.Error, Description: "failed"},
+		DroppedAttributes:      1,
+		DroppedEvents:          2,
+		DroppedLinks:           3,
+		Resource:               resource.NewWithAttributes("https://example.com/schema", attribute.String("service.name", "test")),
+		InstrumentationLibrary: instrumentation.Library{Name: "lib", Version: "v1", SchemaURL: "https://example.com/schema"},
+	}
+
+	got := ReadOnlySpans(Spans([]tracesdk.ReadOnlySpan{want.Snapshot()}))
+	require.Len(t, got, 1)
+	stub := tracetest.SpanStubFromReadOnlySpan(got[0])
+	assert.True(t, want.Resource.Equal(stub.Resource))
+	want.Resource, stub.Resource = nil, nil
+	assert.Equal(t, want, stub)
+}
+
+func TestReadOnlySpansEmpty(t *testing.T) {
+	assert.Nil(t, ReadOnlySpans(nil))
+	assert.Nil(t, ReadOnlySpans([]*tracepb.ResourceSpans{nil}))
+}
+
+func TestKeyValuesFromProtoDropsUnsupported(t *testing.T) {
+	kvs := []*commonpb.KeyValue{
+		{Key: "bytes", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: []byte{1}}}},
+		{Key: "mixed", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{
+			Values: []*commonpb.AnyValue{
+				{Value: &commonpb.AnyValue_IntValue{IntValue: 1}},
+				{Value: &commonpb.AnyValue_StringValue{StringValue: "a"}},
+			},
+		}}}},
+		{Key: "unset"},
+		{Key: "string", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "a"}}},
+	}
+	assert.Equal(t, []attribute.KeyValue{attribute.String("string", "a")}, keyValuesFromProto(kvs))
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpjson"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var errStopped = errors.New("the client is stopped")
+
+type client struct {
+	path string
+	cfg  config
+
+	mu   sync.Mutex
+	file *file
+}
+
+var _ otlptrace.Client = (*client)(nil)
+
+// NewClient creates a new file trace client writing to the file at path.
+// The rotated files are written to the same directory, with the time of
+// their rotation inserted before their extension.
+func NewClient(path string, opts ...Option) otlptrace.Client {
+	return &client{
+		path: path,
+		cfg:  newConfig(opts),
+	}
+}
+
+// Start opens the file, creating it if it does not exist. The requests are
+// appended to an existing file.
+func (c *client) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f := newFile(c.path, c.cfg)
+	if err := f.open(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.file = f
+	c.mu.Unlock()
+	return nil
+}
+
+// Stop closes the file.
+func (c *client) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.close()
+	c.file = nil
+	return err
+}
+
+// UploadTraces writes protoSpans to the file as an OTLP/JSON encoded
+// ExportTraceServiceRequest followed by a newline.
+func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	line, err := otlpjson.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return errStopped
+	}
+	return c.file.write(line)
+}
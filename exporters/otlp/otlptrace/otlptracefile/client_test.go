@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracefile_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var (
+	traceID = trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	spanID  = trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+)
+
+func testSpans(name string) []sdktrace.ReadOnlySpan {
+	start := time.Unix(1600000000, 0)
+	return tracetest.SpanStubs{{
+		Name: name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		SpanKind:   trace.SpanKindServer,
+		StartTime:  start,
+		EndTime:    start.Add(time.Second),
+		Attributes: []attribute.KeyValue{attribute.Int64("count", 1)},
+		Resource:   resource.NewSchemaless(attribute.String("service.name", "test")),
+	}}.Snapshots()
+}
+
+// export exports a batch of spans per name to the file at path.
+func export(t *testing.T, path string, names []string, opts ...otlptracefile.Option) {
+	ctx := context.Background()
+	exp, err := otlptracefile.New(ctx, path, opts...)
+	require.NoError(t, err)
+	for _, name := range names {
+		require.NoError(t, exp.ExportSpans(ctx, testSpans(name)))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+}
+
+// replaySpanNames returns the names of the spans replayed from the file at
+// path.
+func replaySpanNames(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	exp := tracetest.NewInMemoryExporter()
+	require.NoError(t, otlptracefile.ReplaySpans(context.Background(), f, exp))
+	var names []string
+	for _, s := range exp.GetSpans() {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestExporterWritesOTLPJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	export(t, path, []string{"a", "b"})
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var got struct {
+		ResourceSpans []struct {
+			InstrumentationLibrarySpans []struct {
+				Spans []map[string]interface{}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	s := got.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0]
+	assert.Equal(t, "a", s["name"])
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", s["traceId"])
+	assert.Equal(t, "0102030405060708", s["spanId"])
+	assert.Equal(t, "SPAN_KIND_SERVER", s["kind"])
+	assert.Equal(t, "1600000000000000000", s["startTimeUnixNano"])
+}
+
+func TestExporterAppendsToExistingFile(t *testing.T) {
+	for _, c := range []otlptracefile.Compression{otlptracefile.NoCompression, otlptracefile.GzipCompression} {
+		path := filepath.Join(t.TempDir(), "traces.jsonl")
+		export(t, path, []string{"a"}, otlptracefile.WithCompression(c))
+		export(t, path, []string{"b"}, otlptracefile.WithCompression(c))
+		assert.Equal(t, []string{"a", "b"}, replaySpanNames(t, path))
+	}
+}
+
+func TestExporterGzipCompression(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl.gz")
+	export(t, path, []string{"a", "b"}, otlptracefile.WithCompression(otlptracefile.GzipCompression))
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b, []byte{0x1f, 0x8b}), "not gzip compressed")
+	assert.Equal(t, []string{"a", "b"}, replaySpanNames(t, path))
+}
+
+func TestExporterRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "traces.jsonl")
+	export(t, path, []string{"a", "b", "c"}, otlptracefile.WithMaxSize(1))
+
+	rotated, err := filepath.Glob(filepath.Join(dir, "traces-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+	// The rotated files are named after their rotation time, so they sort
+	// in the order they were written.
+	assert.Equal(t, []string{"a"}, replaySpanNames(t, rotated[0]))
+	assert.Equal(t, []string{"b"}, replaySpanNames(t, rotated[1]))
+	assert.Equal(t, []string{"c"}, replaySpanNames(t, path))
+}
+
+func TestClientUploadAfterStop(t *testing.T) {
+	ctx := context.Background()
+	client := otlptracefile.NewClient(filepath.Join(t.TempDir(), "traces.jsonl"))
+	require.NoError(t, client.Start(ctx))
+	require.NoError(t, client.Stop(ctx))
+	assert.Error(t, client.UploadTraces(ctx, nil))
+}
+
+func TestClientUploadCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := otlptracefile.NewClient(filepath.Join(t.TempDir(), "traces.jsonl"))
+	require.NoError(t, client.Start(ctx))
+	defer func() { assert.NoError(t, client.Stop(context.Background())) }()
+	cancel()
+	assert.ErrorIs(t, client.UploadTraces(ctx, nil), context.Canceled)
+}
+
+type recordingClient struct {
+	mu       sync.Mutex
+	requests [][]*tracepb.ResourceSpans
+}
+
+var _ otlptrace.Client = (*recordingClient)(nil)
+
+func (c *recordingClient) Start(context.Context) error { return nil }
+func (c *recordingClient) Stop(context.Context) error  { return nil }
+
+func (c *recordingClient) UploadTraces(_ context.Context, rss []*tracepb.ResourceSpans) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, rss)
+	return nil
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	export(t, path, []string{"a", "b"})
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	client := &recordingClient{}
+	require.NoError(t, otlptracefile.Replay(context.Background(), f, client))
+	require.Len(t, client.requests, 2)
+	span := client.requests[1][0].InstrumentationLibrarySpans[0].Spans[0]
+	assert.Equal(t, "b", span.Name)
+	assert.Equal(t, traceID[:], span.TraceId)
+	assert.Equal(t, spanID[:], span.SpanId)
+}
+
+func TestReplaySpans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	export(t, path, []string{"a"})
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	exp := tracetest.NewInMemoryExporter()
+	require.NoError(t, otlptracefile.ReplaySpans(context.Background(), f, exp))
+	want := tracetest.SpanStubFromReadOnlySpan(testSpans("a")[0])
+	got := exp.GetSpans()
+	require.Len(t, got, 1)
+	assert.True(t, want.Resource.Equal(got[0].Resource))
+	want.Resource, got[0].Resource = nil, nil
+	assert.Equal(t, want, got[0])
+}
+
+func TestReaderTruncatedGzipFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traces.jsonl.gz")
+	exp, err := otlptracefile.New(ctx, path, otlptracefile.WithCompression(otlptracefile.GzipCompression))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, exp.Shutdown(ctx)) }()
+	require.NoError(t, exp.ExportSpans(ctx, testSpans("a")))
+
+	// The file is read before it is closed, as if the process had stopped.
+	assert.Equal(t, []string{"a"}, replaySpanNames(t, path))
+}
+
+func TestReaderInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	export(t, path, []string{"a"})
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("\n{invalid\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	f, err = os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := otlptracefile.NewReader(f)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/connection"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpresponse"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -95,9 +96,14 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			return errNoClient
 		}
 		return c.connection.DoRequest(ctx, func(ctx context.Context) error {
-			_, err := c.tracesClient.Export(ctx, &coltracepb.ExportTraceServiceRequest{
+			resp, err := c.tracesClient.Export(ctx, &coltracepb.ExportTraceServiceRequest{
 				ResourceSpans: protoSpans,
 			})
+			if err == nil && resp != nil {
+				// The partial success is not known to the generated
+				// types and is kept with the unknown fields.
+				otlpresponse.ReportPartialSuccess(otlpresponse.ContentTypeProto, resp.ProtoReflect().GetUnknown())
+			}
 			return err
 		})
 	}()
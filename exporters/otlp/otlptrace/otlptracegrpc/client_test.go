@@ -15,6 +15,7 @@ package otlptracegrpc_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -582,3 +583,50 @@ func TestEmptyData(t *testing.T) {
 
 	assert.NoError(t, exp.ExportSpans(ctx, nil))
 }
+
+func TestExportErrorKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind otlptrace.ErrorKind
+	}{
+		{
+			name: "rejected",
+			err:  status.Error(codes.InvalidArgument, "invalid spans"),
+			kind: otlptrace.Rejected,
+		},
+		{
+			name: "throttled",
+			err:  status.Error(codes.ResourceExhausted, "slow down"),
+			kind: otlptrace.Throttled,
+		},
+		{
+			name: "unavailable",
+			err:  status.Error(codes.Unavailable, "unavailable"),
+			kind: otlptrace.TransportFailure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mc := runMockCollectorWithConfig(t, &mockConfig{
+				errors:   []error{test.err},
+				endpoint: "localhost:0",
+			})
+			defer func() {
+				_ = mc.stop()
+			}()
+
+			ctx := context.Background()
+			exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}))
+			defer func() {
+				_ = exp.Shutdown(ctx)
+			}()
+
+			err := exp.ExportSpans(ctx, roSpans)
+			var e *otlptrace.ExportError
+			require.True(t, errors.As(err, &e))
+			assert.Equal(t, test.kind, e.Kind)
+			assert.Equal(t, status.Code(test.err), status.Code(err))
+		})
+	}
+}
@@ -100,6 +100,32 @@ func WithTLSCredentials(creds credentials.TransportCredentials) Option {
 	})}
 }
 
+// WithCertificateFile sets the client to verify the certificate of the
+// collector with the PEM encoded certificates of the file at path. The file
+// is read again when it changes, so renewed certificates are used by the next
+// connections without restarting the exporter.
+//
+// It replaces the credentials set with WithTLSCredentials. This option can
+// also be set with the OTEL_EXPORTER_OTLP_CERTIFICATE or
+// OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE environment variables.
+func WithCertificateFile(path string) Option {
+	return wrappedOption{otlpconfig.WithCertificateFile(path)}
+}
+
+// WithClientCertificateFiles sets the client to authenticate to the
+// collector with mutual TLS using the PEM encoded certificate and private key
+// of the files certFile and keyFile. The files are read again when they
+// change, so renewed certificates are used by the next connections without
+// restarting the exporter.
+//
+// It replaces the credentials set with WithTLSCredentials. This option can
+// also be set with the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and
+// OTEL_EXPORTER_OTLP_CLIENT_KEY, or OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE
+// and OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY environment variables.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return wrappedOption{otlpconfig.WithClientCertificateFiles(certFile, keyFile)}
+}
+
 // WithServiceConfig defines the default gRPC service config used.
 func WithServiceConfig(serviceConfig string) Option {
 	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg *otlpconfig.Config) {
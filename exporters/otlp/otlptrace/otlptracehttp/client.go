@@ -18,13 +18,13 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"path"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +33,8 @@ import (
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpjson"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpresponse"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/retry"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
@@ -113,7 +115,7 @@ func NewClient(opts ...Option) otlptrace.Client {
 		name:        "traces",
 		cfg:         cfg.Traces,
 		generalCfg:  cfg,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
+		requestFunc: cfg.RetryConfig.RequestFunc(otlpresponse.Retryable),
 		stopCh:      stopCh,
 		client:      httpClient,
 	}
@@ -146,7 +148,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 	pbRequest := &coltracepb.ExportTraceServiceRequest{
 		ResourceSpans: protoSpans,
 	}
-	rawRequest, err := proto.Marshal(pbRequest)
+	rawRequest, err := d.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -159,7 +161,8 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return err
 	}
 
-	return d.requestFunc(ctx, func(ctx context.Context) error {
+	var last *otlptrace.ExportError
+	err = d.requestFunc(ctx, func(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -169,32 +172,32 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		request.reset(ctx)
 		resp, err := d.client.Do(request.Request)
 		if err != nil {
-			return err
+			last = &otlptrace.ExportError{Kind: otlptrace.TransportFailure, Err: err}
+			return last
 		}
 
-		var rErr error
-		switch resp.StatusCode {
-		case http.StatusOK:
-			// Success, do not retry.
-		case http.StatusTooManyRequests,
-			http.StatusServiceUnavailable:
-			// Retry-able failure.
-			rErr = newResponseError(resp.Header)
-
-			// Going to retry, drain the body to reuse the connection.
-			if _, err := io.Copy(ioutil.Discard, resp.Body); err != nil {
-				_ = resp.Body.Close()
-				return err
-			}
-		default:
-			rErr = fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status)
+		body, err := otlpresponse.ReadBody(resp.Body)
+		if err != nil {
+			last = &otlptrace.ExportError{Kind: otlptrace.TransportFailure, Err: err}
+			return last
 		}
-
-		if err := resp.Body.Close(); err != nil {
-			return err
+		if resp.StatusCode == http.StatusOK {
+			otlpresponse.ReportPartialSuccess(resp.Header.Get("Content-Type"), body)
+			return nil
 		}
-		return rErr
+		rErr := fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status)
+		last = otlpresponse.HTTPError(rErr, resp, body)
+		return last
 	})
+	if err != nil && !errors.As(err, new(*otlptrace.ExportError)) {
+		// The retries were interrupted.
+		e := &otlptrace.ExportError{Kind: otlptrace.TransportFailure, Err: err}
+		if last != nil {
+			e.Kind, e.RetryAfter = last.Kind, last.RetryAfter
+		}
+		return e
+	}
+	return err
 }
 
 func (d *client) newRequest(body []byte) (request, error) {
@@ -207,7 +210,7 @@ func (d *client) newRequest(body []byte) (request, error) {
 	for k, v := range d.cfg.Headers {
 		r.Header.Set(k, v)
 	}
-	r.Header.Set("Content-Type", contentTypeProto)
+	r.Header.Set("Content-Type", d.contentType())
 
 	req := request{Request: r}
 	switch Compression(d.cfg.Compression) {
@@ -260,42 +263,6 @@ func (r *request) reset(ctx context.Context) {
 	r.Request = r.Request.WithContext(ctx)
 }
 
-// retryableError represents a request failure that can be retried.
-type retryableError struct {
-	throttle int64
-}
-
-// newResponseError returns a retryableError and will extract any explicit
-// throttle delay contained in headers.
-func newResponseError(header http.Header) error {
-	var rErr retryableError
-	if s, ok := header["Retry-After"]; ok {
-		if t, err := strconv.ParseInt(s[0], 10, 64); err == nil {
-			rErr.throttle = t
-		}
-	}
-	return rErr
-}
-
-func (e retryableError) Error() string {
-	return "retry-able request failure"
-}
-
-// evaluate returns if err is retry-able. If it is and it includes an explicit
-// throttling delay, that delay is also returned.
-func evaluate(err error) (bool, time.Duration) {
-	if err == nil {
-		return false, 0
-	}
-
-	rErr, ok := err.(retryableError)
-	if !ok {
-		return false, 0
-	}
-
-	return true, time.Duration(rErr.throttle)
-}
-
 func (d *client) getScheme() string {
 	if d.cfg.Insecure {
 		return "http"
@@ -318,3 +285,19 @@ func (d *client) contextWithStop(ctx context.Context) (context.Context, context.
 	}(ctx, cancel)
 	return ctx, cancel
 }
+
+// marshal encodes msg in the format configured for d.
+func (d *client) marshal(msg proto.Message) ([]byte, error) {
+	if Marshaler(d.cfg.Marshaler) == MarshalJSON {
+		return otlpjson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
+
+// contentType returns the content type of the format configured for d.
+func (d *client) contentType() string {
+	if Marshaler(d.cfg.Marshaler) == MarshalJSON {
+		return otlpjson.ContentType
+	}
+	return contentTypeProto
+}
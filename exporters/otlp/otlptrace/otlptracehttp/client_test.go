@@ -16,18 +16,30 @@ package otlptracehttp_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -104,10 +116,23 @@ func TestEndToEnd(t *testing.T) {
 			mcCfg: mockCollectorConfig{
 				InjectHTTPStatus: []int{503},
 				InjectResponseHeader: []map[string]string{
-					{"Retry-After": "10"},
+					{"Retry-After": "1"},
 				},
 			},
 		},
+		{
+			name: "with JSON",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithMarshal(otlptracehttp.MarshalJSON),
+			},
+		},
+		{
+			name: "with JSON and gzip compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithMarshal(otlptracehttp.MarshalJSON),
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+			},
+		},
 		{
 			name: "with empty paths (forced to defaults)",
 			opts: []otlptracehttp.Option{
@@ -204,7 +229,10 @@ func TestTimeout(t *testing.T) {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
 	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
-	assert.Equal(t, true, os.IsTimeout(err))
+	var e *otlptrace.ExportError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, otlptrace.TransportFailure, e.Kind)
+	assert.Equal(t, true, os.IsTimeout(e.Err))
 }
 
 func TestNoRetry(t *testing.T) {
@@ -232,6 +260,9 @@ func TestNoRetry(t *testing.T) {
 	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Equal(t, fmt.Sprintf("failed to send traces to http://%s/v1/traces: 400 Bad Request", mc.endpoint), err.Error())
+	var e *otlptrace.ExportError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, otlptrace.Rejected, e.Kind)
 	assert.Empty(t, mc.GetSpans())
 }
 
@@ -255,6 +286,66 @@ func TestEmptyData(t *testing.T) {
 	assert.Empty(t, mc.GetSpans())
 }
 
+func TestJSONEncoding(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	client := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithMarshal(otlptracehttp.MarshalJSON),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:  trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+	})
+	start := time.Unix(0, 1600000000000000000)
+	span := tracetest.SpanStub{
+		Name:        "span",
+		SpanContext: sc,
+		Parent:      sc.WithSpanID(trace.SpanID{0xff}),
+		SpanKind:    trace.SpanKindServer,
+		StartTime:   start,
+		EndTime:     start.Add(time.Second),
+		Attributes:  []attribute.KeyValue{attribute.Int64("count", 1)},
+		Status:      sdktrace.Status{Code: codes.Error},
+	}.Snapshot()
+	require.NoError(t, exporter.ExportSpans(ctx, []sdktrace.ReadOnlySpan{span}))
+
+	// The request is decoded by the collector.
+	spans := mc.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, sc.TraceID().String(), fmt.Sprintf("%x", spans[0].TraceId))
+
+	requests := mc.GetJSONRequests()
+	require.Len(t, requests, 1)
+	var got struct {
+		ResourceSpans []struct {
+			InstrumentationLibrarySpans []struct {
+				Spans []map[string]interface{}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(requests[0], &got))
+	s := got.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0]
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", s["traceId"])
+	assert.Equal(t, "0102030405060708", s["spanId"])
+	assert.Equal(t, "ff00000000000000", s["parentSpanId"])
+	assert.Equal(t, "SPAN_KIND_SERVER", s["kind"])
+	assert.Equal(t, "1600000000000000000", s["startTimeUnixNano"])
+	assert.Equal(t, map[string]interface{}{"code": "STATUS_CODE_ERROR"}, s["status"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"key":   "count",
+		"value": map[string]interface{}{"intValue": "1"},
+	}}, s["attributes"])
+}
+
 func TestCancelledContext(t *testing.T) {
 	mcCfg := mockCollectorConfig{}
 	mc := runMockCollector(t, mcCfg)
@@ -348,3 +439,154 @@ func TestStopWhileExporting(t *testing.T) {
 	assert.NoError(t, err)
 	<-doneCh
 }
+
+func TestThrottledError(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusTooManyRequests},
+		InjectResponseHeader: []map[string]string{
+			{"Retry-After": "3"},
+		},
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	var e *otlptrace.ExportError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, otlptrace.Throttled, e.Kind)
+	assert.Equal(t, 3*time.Second, e.RetryAfter)
+	assert.Empty(t, mc.GetSpans())
+}
+
+// errorHandler sends the errors it handles to the channel of the running
+// test.
+type errorHandler struct {
+	mu sync.Mutex
+	ch chan error
+}
+
+func (h *errorHandler) Handle(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ch != nil {
+		h.ch <- err
+	}
+}
+
+func (h *errorHandler) set(ch chan error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ch = ch
+}
+
+// The global error handler can only be set once.
+var testErrorHandler = &errorHandler{}
+
+func init() {
+	otel.SetErrorHandler(testErrorHandler)
+}
+
+func TestPartialSuccess(t *testing.T) {
+	var partialSuccess []byte
+	partialSuccess = protowire.AppendTag(partialSuccess, 1, protowire.VarintType)
+	partialSuccess = protowire.AppendVarint(partialSuccess, 2)
+	partialSuccess = protowire.AppendTag(partialSuccess, 2, protowire.BytesType)
+	partialSuccess = protowire.AppendString(partialSuccess, "invalid spans")
+	var protoResponse []byte
+	protoResponse = protowire.AppendTag(protoResponse, 1, protowire.BytesType)
+	protoResponse = protowire.AppendBytes(protoResponse, partialSuccess)
+
+	tests := []struct {
+		name  string
+		mcCfg mockCollectorConfig
+		opts  []otlptracehttp.Option
+	}{
+		{
+			name: "protobuf",
+			mcCfg: mockCollectorConfig{
+				InjectResponseBody: protoResponse,
+			},
+		},
+		{
+			name: "JSON",
+			mcCfg: mockCollectorConfig{
+				InjectContentType:  "application/json",
+				InjectResponseBody: []byte(`{"partialSuccess":{"rejectedSpans":"2","errorMessage":"invalid spans"}}`),
+			},
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithMarshal(otlptracehttp.MarshalJSON),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			testErrorHandler.set(errs)
+			defer testErrorHandler.set(nil)
+
+			mc := runMockCollector(t, test.mcCfg)
+			defer mc.MustStop(t)
+			opts := append([]otlptracehttp.Option{
+				otlptracehttp.WithEndpoint(mc.Endpoint()),
+				otlptracehttp.WithInsecure(),
+			}, test.opts...)
+			ctx := context.Background()
+			exporter, err := otlptrace.New(ctx, otlptracehttp.NewClient(opts...))
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(ctx))
+			}()
+			require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+			assert.Len(t, mc.GetSpans(), 1)
+
+			select {
+			case err := <-errs:
+				assert.Equal(t, otlptrace.PartialSuccess{RejectedSpans: 2, ErrorMessage: "invalid spans"}, err)
+			default:
+				t.Error("partial success not reported")
+			}
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	clientCert, err := generateWeakCertificate()
+	require.NoError(t, err)
+	mc := runMockCollector(t, mockCollectorConfig{
+		WithTLS:           true,
+		ClientCertificate: clientCert.Certificate,
+	})
+	defer mc.MustStop(t)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, mc.Certificate(), 0600))
+	require.NoError(t, ioutil.WriteFile(certFile, clientCert.Certificate, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, clientCert.PrivateKey, 0600))
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithCertificateFile(caFile),
+		otlptracehttp.WithClientCertificateFiles(certFile, keyFile),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+}
@@ -19,6 +19,7 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -33,6 +34,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpjson"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
@@ -44,13 +46,16 @@ type mockCollector struct {
 
 	spanLock     sync.Mutex
 	spansStorage otlptracetest.SpansStorage
+	jsonRequests [][]byte
 
 	injectHTTPStatus     []int
 	injectResponseHeader []map[string]string
 	injectContentType    string
+	injectResponseBody   []byte
 	injectDelay          time.Duration
 
 	clientTLSConfig *tls.Config
+	certificate     []byte
 	expectedHeaders map[string]string
 }
 
@@ -74,6 +79,14 @@ func (c *mockCollector) GetResourceSpans() []*tracepb.ResourceSpans {
 	return c.spansStorage.GetResourceSpans()
 }
 
+// GetJSONRequests returns the bodies of the requests received in the
+// OTLP/JSON format.
+func (c *mockCollector) GetJSONRequests() [][]byte {
+	c.spanLock.Lock()
+	defer c.spanLock.Unlock()
+	return c.jsonRequests
+}
+
 func (c *mockCollector) Endpoint() string {
 	return c.endpoint
 }
@@ -82,6 +95,12 @@ func (c *mockCollector) ClientTLSConfig() *tls.Config {
 	return c.clientTLSConfig
 }
 
+// Certificate returns the PEM encoded certificate of the collector when it
+// runs with TLS.
+func (c *mockCollector) Certificate() []byte {
+	return c.certificate
+}
+
 func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	if c.injectDelay != 0 {
 		time.Sleep(c.injectDelay)
@@ -97,6 +116,9 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	if c.injectResponseBody != nil {
+		rawResponse = c.injectResponseBody
+	}
 	h := c.getInjectResponseHeader()
 	if injectedStatus := c.getInjectHTTPStatus(); injectedStatus != 0 {
 		writeReply(w, rawResponse, injectedStatus, c.injectContentType, h)
@@ -108,7 +130,8 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	request, err := unmarshalTraceRequest(rawRequest, r.Header.Get("content-type"))
+	contentType := r.Header.Get("content-type")
+	request, err := unmarshalTraceRequest(rawRequest, contentType)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -117,15 +140,20 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	c.spanLock.Lock()
 	defer c.spanLock.Unlock()
 	c.spansStorage.AddSpans(request)
+	if contentType == otlpjson.ContentType {
+		c.jsonRequests = append(c.jsonRequests, rawRequest)
+	}
 }
 
 func unmarshalTraceRequest(rawRequest []byte, contentType string) (*collectortracepb.ExportTraceServiceRequest, error) {
 	request := &collectortracepb.ExportTraceServiceRequest{}
-	if contentType != "application/x-protobuf" {
-		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf is supported", contentType)
+	switch contentType {
+	case "application/x-protobuf":
+		return request, proto.Unmarshal(rawRequest, request)
+	case otlpjson.ContentType:
+		return request, otlpjson.Unmarshal(rawRequest, request)
 	}
-	err := proto.Unmarshal(rawRequest, request)
-	return request, err
+	return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf and application/json are supported", contentType)
 }
 
 func (c *mockCollector) checkHeaders(r *http.Request) bool {
@@ -205,8 +233,10 @@ type mockCollectorConfig struct {
 	InjectHTTPStatus     []int
 	InjectContentType    string
 	InjectResponseHeader []map[string]string
+	InjectResponseBody   []byte
 	InjectDelay          time.Duration
 	WithTLS              bool
+	ClientCertificate    []byte
 	ExpectedHeaders      map[string]string
 }
 
@@ -228,6 +258,7 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		injectHTTPStatus:     cfg.InjectHTTPStatus,
 		injectResponseHeader: cfg.InjectResponseHeader,
 		injectContentType:    cfg.InjectContentType,
+		injectResponseBody:   cfg.InjectResponseBody,
 		injectDelay:          cfg.InjectDelay,
 		expectedHeaders:      cfg.ExpectedHeaders,
 	}
@@ -244,6 +275,13 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		server.TLSConfig = &tls.Config{
 			Certificates: []tls.Certificate{tlsCertificate},
 		}
+		if cfg.ClientCertificate != nil {
+			clientCAs := x509.NewCertPool()
+			require.True(t, clientCAs.AppendCertsFromPEM(cfg.ClientCertificate))
+			server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			server.TLSConfig.ClientCAs = clientCAs
+		}
+		m.certificate = pem.Certificate
 
 		m.clientTLSConfig = &tls.Config{
 			InsecureSkipVerify: true,
@@ -35,6 +35,17 @@ const (
 	GzipCompression = Compression(otlpconfig.GzipCompression)
 )
 
+// Marshaler describes the kind of message format sent to the collector.
+type Marshaler otlpconfig.Marshaler
+
+const (
+	// MarshalProto tells the driver to send using the protobuf binary
+	// format.
+	MarshalProto = Marshaler(otlpconfig.MarshalProto)
+	// MarshalJSON tells the driver to send using the OTLP/JSON format.
+	MarshalJSON = Marshaler(otlpconfig.MarshalJSON)
+)
+
 // Option applies an option to the HTTP client.
 type Option interface {
 	applyHTTPOption(*otlpconfig.Config)
@@ -61,6 +72,14 @@ func WithEndpoint(endpoint string) Option {
 	return wrappedOption{otlpconfig.WithEndpoint(endpoint)}
 }
 
+// WithMarshal tells the driver which format to use when sending payloads
+// to the collector. If unset, MarshalProto is used. The
+// OTEL_EXPORTER_OTLP_PROTOCOL environment variable can also be set to
+// http/json to use MarshalJSON.
+func WithMarshal(m Marshaler) Option {
+	return wrappedOption{otlpconfig.WithMarshal(otlpconfig.Marshaler(m))}
+}
+
 // WithCompression tells the driver to compress the sent data.
 func WithCompression(compression Compression) Option {
 	return wrappedOption{otlpconfig.WithCompression(otlpconfig.Compression(compression))}
@@ -79,6 +98,32 @@ func WithTLSClientConfig(tlsCfg *tls.Config) Option {
 	return wrappedOption{otlpconfig.WithTLSClientConfig(tlsCfg)}
 }
 
+// WithCertificateFile sets the client to verify the certificate of the
+// collector with the PEM encoded certificates of the file at path. The file
+// is read again when it changes, so renewed certificates are used by the next
+// connections without restarting the exporter.
+//
+// It extends the configuration set with WithTLSClientConfig when passed
+// after it. This option can also be set with the OTEL_EXPORTER_OTLP_CERTIFICATE or
+// OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE environment variables.
+func WithCertificateFile(path string) Option {
+	return wrappedOption{otlpconfig.WithCertificateFile(path)}
+}
+
+// WithClientCertificateFiles sets the client to authenticate to the
+// collector with mutual TLS using the PEM encoded certificate and private key
+// of the files certFile and keyFile. The files are read again when they
+// change, so renewed certificates are used by the next connections without
+// restarting the exporter.
+//
+// It extends the configuration set with WithTLSClientConfig when passed
+// after it. This option can also be set with the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE
+// and OTEL_EXPORTER_OTLP_CLIENT_KEY, or OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE
+// and OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY environment variables.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return wrappedOption{otlpconfig.WithClientCertificateFiles(certFile, keyFile)}
+}
+
 // WithInsecure tells the driver to connect to the collector using the
 // HTTP scheme, instead of HTTPS.
 func WithInsecure() Option {
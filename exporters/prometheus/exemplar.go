@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+const (
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+// exemplarMetric decorates a constant Prometheus metric with exemplars.
+// Exemplars are only rendered when the scrape negotiates the OpenMetrics
+// exposition format.
+type exemplarMetric struct {
+	prometheus.Metric
+
+	kind      number.Kind
+	exemplars []aggregation.Exemplar
+}
+
+var _ prometheus.Metric = exemplarMetric{}
+
+// withExemplars returns m decorated with the exemplars held by agg, if any.
+func withExemplars(m prometheus.Metric, agg aggregation.Aggregation, kind number.Kind) (prometheus.Metric, error) {
+	ex, ok := agg.(aggregation.Exemplars)
+	if !ok {
+		return m, nil
+	}
+	exemplars, err := ex.Exemplars()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving exemplars: %w", err)
+	}
+	if len(exemplars) == 0 {
+		return m, nil
+	}
+	return exemplarMetric{Metric: m, kind: kind, exemplars: exemplars}, nil
+}
+
+// Write encodes the wrapped metric into out and attaches the most recent
+// exemplar to the counter, or to each histogram bucket the exemplar falls
+// into. Exemplars above the largest boundary are dropped since the +Inf
+// bucket is implicit.
+func (m exemplarMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+
+	switch {
+	case out.Counter != nil:
+		latest := m.latest(func(float64) bool { return true })
+		if latest == nil {
+			return nil
+		}
+		e, err := m.toProto(*latest)
+		if err != nil {
+			return err
+		}
+		out.Counter.Exemplar = e
+	case out.Histogram != nil:
+		lower := math.Inf(-1)
+		for _, b := range out.Histogram.Bucket {
+			upper := b.GetUpperBound()
+			latest := m.latest(func(v float64) bool { return v > lower && v <= upper })
+			lower = upper
+			if latest == nil {
+				continue
+			}
+			e, err := m.toProto(*latest)
+			if err != nil {
+				return err
+			}
+			b.Exemplar = e
+		}
+	}
+	return nil
+}
+
+// latest returns the most recent exemplar whose value satisfies match.
+func (m exemplarMetric) latest(match func(float64) bool) *aggregation.Exemplar {
+	var latest *aggregation.Exemplar
+	for i := range m.exemplars {
+		e := &m.exemplars[i]
+		if !match(e.Value.CoerceToFloat64(m.kind)) {
+			continue
+		}
+		if latest == nil || e.Time.After(latest.Time) {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// toProto converts e into its Prometheus representation. Only the trace and
+// span IDs are kept as labels: together they already use 63 of the
+// prometheus.ExemplarMaxRunes allowed, leaving no room for the filtered
+// attributes.
+func (m exemplarMetric) toProto(e aggregation.Exemplar) (*dto.Exemplar, error) {
+	ts := timestamppb.New(e.Time)
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	out := &dto.Exemplar{
+		Value:     proto.Float64(e.Value.CoerceToFloat64(m.kind)),
+		Timestamp: ts,
+	}
+	if e.TraceID.IsValid() {
+		out.Label = append(out.Label, &dto.LabelPair{
+			Name:  proto.String(traceIDLabel),
+			Value: proto.String(e.TraceID.String()),
+		})
+	}
+	if e.SpanID.IsValid() {
+		out.Label = append(out.Label, &dto.LabelPair{
+			Name:  proto.String(spanIDLabel),
+			Value: proto.String(e.SpanID.String()),
+		})
+	}
+	return out, nil
+}
@@ -104,7 +104,11 @@ func New(config Config, controller *controller.Controller) (*Exporter, error) {
 	}
 
 	e := &Exporter{
-		handler:                    promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{}),
+		handler: promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{
+			// OpenMetrics is only served to scrapers that ask for it
+			// and is required to expose exemplars.
+			EnableOpenMetrics: true,
+		}),
 		registerer:                 config.Registerer,
 		gatherer:                   config.Gatherer,
 		controller:                 controller,
@@ -179,6 +183,11 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 
 	err := ctrl.ForEach(func(_ instrumentation.Library, reader export.Reader) error {
 		return reader.ForEach(c.exp, func(record export.Record) error {
+			if record.Stale() {
+				// Prometheus marks the series missing from
+				// a scrape as stale itself.
+				return nil
+			}
 
 			agg := record.Aggregation()
 			numberKind := record.Descriptor().NumberKind()
@@ -256,6 +265,9 @@ func (c *collector) exportMonotonicCounter(ch chan<- prometheus.Metric, sum aggr
 	if err != nil {
 		return fmt.Errorf("error creating constant metric: %w", err)
 	}
+	if m, err = withExemplars(m, sum, kind); err != nil {
+		return err
+	}
 
 	ch <- m
 	return nil
@@ -287,6 +299,9 @@ func (c *collector) exportHistogram(ch chan<- prometheus.Metric, hist aggregatio
 	if err != nil {
 		return fmt.Errorf("error creating constant histogram: %w", err)
 	}
+	if m, err = withExemplars(m, hist, kind); err != nil {
+		return err
+	}
 
 	ch <- m
 	return nil
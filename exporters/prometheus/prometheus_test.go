@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -33,6 +34,7 @@ import (
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type expectedMetric struct {
@@ -201,3 +203,47 @@ func TestPrometheusStatefulness(t *testing.T) {
 		expectCounterWithHelp("a_counter", "Counts things", `a_counter{key="value"} 200`),
 	})
 }
+
+func TestPrometheusExemplars(t *testing.T) {
+	exporter, err := newPipeline(
+		prometheus.Config{
+			DefaultHistogramBoundaries: []float64{1, 10},
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("counter")
+	histogram := metric.Must(meter).NewFloat64Histogram("histogram")
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	exemplarLabels := fmt.Sprintf(`{trace_id="%s",span_id="%s"}`, sc.TraceID(), sc.SpanID())
+
+	counter.Add(ctx, 3)
+	histogram.Record(ctx, 5)
+	// Measurements outside of a sampled span are not exemplars.
+	histogram.Record(context.Background(), 0.5)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+	exporter.ServeHTTP(rec, req)
+
+	output := rec.Body.String()
+	assert.Contains(t, output, "counter 3.0 # "+exemplarLabels+" 3.0 ")
+	assert.Contains(t, output, `histogram_bucket{le="1.0"} 1`+"\n")
+	assert.Contains(t, output, `histogram_bucket{le="10.0"} 2 # `+exemplarLabels+" 5.0 ")
+
+	// The text format does not support exemplars.
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/metrics", nil)
+	exporter.ServeHTTP(rec, req)
+	assert.NotContains(t, rec.Body.String(), "trace_id")
+}
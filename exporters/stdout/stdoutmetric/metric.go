@@ -70,6 +70,12 @@ func (e *metricExporter) Export(_ context.Context, res *resource.Resource, reade
 		encodedInstLabels := instSet.Encoded(e.config.LabelEncoder)
 
 		return mr.ForEach(e, func(record exportmetric.Record) error {
+			if record.Stale() {
+				// The last value of a removed series is not
+				// printed again as if it were still updated.
+				return nil
+			}
+
 			desc := record.Descriptor()
 			agg := record.Aggregation()
 			kind := desc.NumberKind()
@@ -29,7 +29,9 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/metric"
+	exportmetric "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
@@ -265,3 +267,58 @@ func TestStdoutResource(t *testing.T) {
 		})
 	}
 }
+
+func TestStdoutSkipsStaleRecords(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exp, err := stdoutmetric.New(stdoutmetric.WithWriter(buf), stdoutmetric.WithoutTimestamps())
+	require.NoError(t, err)
+	proc := processor.NewFactory(
+		processortest.AggregatorSelector(),
+		aggregation.CumulativeTemporalitySelector(),
+		processor.WithIdleSeriesTTL(1),
+		processor.WithStalenessMarkers(true),
+	)
+	cont := controller.New(proc,
+		controller.WithResource(testResource),
+		controller.WithCollectPeriod(0),
+	)
+	ctx := context.Background()
+	// The cumulative sums of observers have staleness markers.
+	observe := true
+	_ = metric.Must(cont.Meter("test")).NewInt64CounterObserver("name.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		if observe {
+			result.Observe(1, attribute.String("A", "B"))
+		}
+	})
+
+	// collect collects and exports once, it returns the number of stale
+	// records collected.
+	collect := func() int {
+		require.NoError(t, cont.Collect(ctx))
+		var stale int
+		require.NoError(t, cont.ForEach(func(_ instrumentation.Library, r exportmetric.Reader) error {
+			return r.ForEach(exp, func(record exportmetric.Record) error {
+				if record.Stale() {
+					stale++
+				}
+				return nil
+			})
+		}))
+		require.NoError(t, exp.Export(ctx, testResource, cont))
+		return stale
+	}
+
+	require.Equal(t, 0, collect())
+	require.Equal(t, `[{"Name":"name.sum{R=V,instrumentation.name=test,A=B}","Sum":1}]`, strings.TrimSpace(buf.String()))
+
+	// The series is removed once idle, its staleness marker is not
+	// printed.
+	observe = false
+	var stale int
+	for i := 0; i < 3 && stale == 0; i++ {
+		buf.Reset()
+		stale = collect()
+	}
+	require.Equal(t, 1, stale)
+	assert.Empty(t, buf.String())
+}
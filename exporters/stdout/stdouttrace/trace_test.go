@@ -173,6 +173,7 @@ func expectedJSON(now time.Time) string {
 	"DroppedAttributes": 0,
 	"DroppedEvents": 0,
 	"DroppedLinks": 0,
+	"TruncatedAttributes": 0,
 	"ChildSpanCount": 0,
 	"Resource": [
 		{
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package log provides an implementation of the logging part of the
+OpenTelemetry API.
+
+This package is currently in a pre-GA phase. Backwards incompatible changes
+may be introduced in subsequent minor version releases as we work to track the
+evolving OpenTelemetry specification and user feedback.
+
+Log records are emitted by a Logger. A Logger is unique to the
+instrumentation and is created from a LoggerProvider:
+
+	var logger log.Logger
+
+	func init() {
+		logger = provider.Logger("instrumentation/package/name")
+	}
+
+	func operation(ctx context.Context) {
+		logger.Emit(ctx, log.Record{
+			Severity: log.SeverityInfo,
+			Body:     "operation started",
+			Attributes: []attribute.KeyValue{
+				attribute.String("key", "value"),
+			},
+		})
+		// ...
+	}
+
+The passed context is used to correlate the emitted log record with the
+active span, if any.
+*/
+package log // import "go.opentelemetry.io/otel/log"
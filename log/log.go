@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/log"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LoggerProvider provides access to instrumentation Loggers.
+type LoggerProvider interface {
+	// Logger creates an implementation of the Logger interface.
+	// The instrumentationName must be the name of the library providing
+	// instrumentation. This name may be the same as the instrumented code
+	// only if that code provides built-in instrumentation. If the
+	// instrumentationName is empty, then a implementation defined default
+	// name will be used instead.
+	//
+	// This method must be concurrency safe.
+	Logger(instrumentationName string, opts ...LoggerOption) Logger
+}
+
+// Logger emits log records.
+type Logger interface {
+	// Emit emits the log record r.
+	//
+	// The passed ctx is used to correlate r with the span it contains, if
+	// any. Implementations should record the trace ID, span ID, and trace
+	// flags of that span with r.
+	//
+	// This method must be concurrency safe.
+	Emit(ctx context.Context, r Record)
+}
+
+// Record is a log record to be emitted by a Logger.
+type Record struct {
+	// Timestamp is the time when the event occurred. If it is zero, the
+	// time the Record is emitted is used instead.
+	Timestamp time.Time
+
+	// Severity is the numerical severity of the event.
+	Severity Severity
+
+	// SeverityText is the original textual severity of the event, also
+	// known as log level. If it is empty, implementations may use the
+	// short name of Severity.
+	SeverityText string
+
+	// Body is the body of the log record, usually a human-readable message.
+	Body string
+
+	// Attributes describe additional information about the event.
+	Attributes []attribute.KeyValue
+}
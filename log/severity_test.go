@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverityString(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		want     string
+	}{
+		{SeverityUndefined, "UNDEFINED"},
+		{SeverityTrace, "TRACE"},
+		{SeverityTrace4, "TRACE4"},
+		{SeverityDebug, "DEBUG"},
+		{SeverityDebug2, "DEBUG2"},
+		{SeverityInfo, "INFO"},
+		{SeverityInfo3, "INFO3"},
+		{SeverityWarn, "WARN"},
+		{SeverityError, "ERROR"},
+		{SeverityError4, "ERROR4"},
+		{SeverityFatal, "FATAL"},
+		{SeverityFatal4, "FATAL4"},
+		{Severity(25), "Severity(25)"},
+		{Severity(-1), "Severity(-1)"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.severity.String())
+	}
+}
+
+func TestNewLoggerConfig(t *testing.T) {
+	c := NewLoggerConfig(
+		WithInstrumentationVersion("v0.1.0"),
+		WithSchemaURL("https://opentelemetry.io/schemas/1.7.0"),
+	)
+	assert.Equal(t, "v0.1.0", c.InstrumentationVersion())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.7.0", c.SchemaURL())
+}
@@ -72,3 +72,19 @@ replace go.opentelemetry.io/otel/bridge/opencensus/test => ../bridge/opencensus/
 replace go.opentelemetry.io/otel/example/fib => ../example/fib
 
 replace go.opentelemetry.io/otel/schema => ../schema
+
+replace go.opentelemetry.io/otel/log => ../log
+
+replace go.opentelemetry.io/otel/sdk/log => ../sdk/log
+
+replace go.opentelemetry.io/otel/exporters/otlp/otlplog => ../exporters/otlp/otlplog
+
+replace go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc => ../exporters/otlp/otlplog/otlploggrpc
+
+replace go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp => ../exporters/otlp/otlplog/otlploghttp
+
+replace go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile => ../exporters/otlp/otlptrace/otlptracefile
+
+replace go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile => ../exporters/otlp/otlpmetric/otlpmetricfile
+
+replace go.opentelemetry.io/otel/sdk/trace/tracemetric => ../sdk/trace/tracemetric
@@ -0,0 +1,402 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Default B3 Header names.
+	b3ContextHeader      = "b3"
+	b3DebugFlagHeader    = "x-b3-flags"
+	b3TraceIDHeader      = "x-b3-traceid"
+	b3SpanIDHeader       = "x-b3-spanid"
+	b3SampledHeader      = "x-b3-sampled"
+	b3ParentSpanIDHeader = "x-b3-parentspanid"
+
+	b3TraceIDPadding = "0000000000000000"
+
+	// B3 Single Header encoding widths.
+	b3SeparatorWidth      = 1       // Single "-" character.
+	b3SamplingWidth       = 1       // Single hex character.
+	b3TraceID64BitsWidth  = 64 / 4  // 16 hex character Trace ID.
+	b3TraceID128BitsWidth = 128 / 4 // 32 hex character Trace ID.
+	b3SpanIDWidth         = 16      // 16 hex character ID.
+	b3ParentSpanIDWidth   = 16      // 16 hex character ID.
+)
+
+var (
+	errB3InvalidSampledByte        = errors.New("invalid B3 Sampled found")
+	errB3InvalidSampledHeader      = errors.New("invalid B3 Sampled header found")
+	errB3InvalidTraceIDHeader      = errors.New("invalid B3 traceID header found")
+	errB3InvalidSpanIDHeader       = errors.New("invalid B3 spanID header found")
+	errB3InvalidParentSpanIDHeader = errors.New("invalid B3 ParentSpanID header found")
+	errB3InvalidScope              = errors.New("require either both traceID and spanID or none")
+	errB3InvalidScopeParent        = errors.New("ParentSpanID requires both traceID and spanID to be available")
+	errB3InvalidScopeParentSingle  = errors.New("ParentSpanID requires traceID, spanID and Sampled to be available")
+	errB3EmptyContext              = errors.New("empty request context")
+	errB3InvalidTraceIDValue       = errors.New("invalid B3 traceID value found")
+	errB3InvalidSpanIDValue        = errors.New("invalid B3 spanID value found")
+	errB3InvalidParentSpanIDValue  = errors.New("invalid B3 ParentSpanID value found")
+)
+
+// B3Encoding is a bitmask representation of the B3 encoding type.
+type B3Encoding uint8
+
+// supports returns if e has o bit(s) set.
+func (e B3Encoding) supports(o B3Encoding) bool {
+	return e&o == o
+}
+
+const (
+	// B3MultipleHeader is a B3 encoding that uses multiple headers to
+	// transmit tracing information all prefixed with `x-b3-`.
+	//    x-b3-traceid: {TraceId}
+	//    x-b3-parentspanid: {ParentSpanId}
+	//    x-b3-spanid: {SpanId}
+	//    x-b3-sampled: {SamplingState}
+	//    x-b3-flags: {DebugFlag}
+	B3MultipleHeader B3Encoding = 1 << iota
+	// B3SingleHeader is a B3 encoding that uses a single header named `b3`
+	// to transmit tracing information.
+	//    b3: {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+	B3SingleHeader
+	// B3Unspecified is an unspecified B3 encoding.
+	B3Unspecified B3Encoding = 0
+)
+
+// B3 is a propagator that supports the B3 format used by Zipkin
+// (https://github.com/openzipkin/b3-propagation).
+//
+// Both the single header and multiple header encodings are extracted. If
+// both are present, the single header is preferred. The debug and deferred
+// sampling states extracted from a carrier are retained in the returned
+// Context so that they are propagated by Inject.
+type B3 struct {
+	// InjectEncoding are the B3 encodings used when injecting trace
+	// information. If no encoding is set (i.e. B3Unspecified) then
+	// B3SingleHeader will be used as the default.
+	InjectEncoding B3Encoding
+}
+
+var _ TextMapPropagator = B3{}
+
+// Inject injects a context into the carrier as B3 headers.
+// The parent span ID is omitted because it is not tracked in the
+// SpanContext.
+func (b3 B3) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	if b3.InjectEncoding.supports(B3SingleHeader) || b3.InjectEncoding == B3Unspecified {
+		header := []string{sc.TraceID().String(), sc.SpanID().String()}
+
+		if b3DebugFromContext(ctx) {
+			header = append(header, "d")
+		} else if !b3DeferredFromContext(ctx) {
+			if sc.IsSampled() {
+				header = append(header, "1")
+			} else {
+				header = append(header, "0")
+			}
+		}
+
+		carrier.Set(b3ContextHeader, strings.Join(header, "-"))
+	}
+
+	if b3.InjectEncoding.supports(B3MultipleHeader) {
+		carrier.Set(b3TraceIDHeader, sc.TraceID().String())
+		carrier.Set(b3SpanIDHeader, sc.SpanID().String())
+
+		if b3DebugFromContext(ctx) {
+			// Since Debug implies deferred, don't also send "X-B3-Sampled".
+			carrier.Set(b3DebugFlagHeader, "1")
+		} else if !b3DeferredFromContext(ctx) {
+			if sc.IsSampled() {
+				carrier.Set(b3SampledHeader, "1")
+			} else {
+				carrier.Set(b3SampledHeader, "0")
+			}
+		}
+	}
+}
+
+// Extract extracts a context from the carrier if it contains B3 headers.
+//
+// The returned Context will be a copy of ctx and contain the extracted
+// SpanContext as the remote SpanContext. If no valid B3 headers are found,
+// the passed ctx will be returned directly instead.
+func (b3 B3) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	var (
+		sc  trace.SpanContext
+		err error
+	)
+
+	// Default to Single Header if a valid value exists.
+	if h := carrier.Get(b3ContextHeader); h != "" {
+		var sCtx context.Context
+		sCtx, sc, err = b3ExtractSingle(ctx, h)
+		if err == nil && sc.IsValid() {
+			return trace.ContextWithRemoteSpanContext(sCtx, sc)
+		}
+		// The Single Header value was invalid, fallback to Multiple Header.
+	}
+
+	var (
+		traceID      = carrier.Get(b3TraceIDHeader)
+		spanID       = carrier.Get(b3SpanIDHeader)
+		parentSpanID = carrier.Get(b3ParentSpanIDHeader)
+		sampled      = carrier.Get(b3SampledHeader)
+		debugFlag    = carrier.Get(b3DebugFlagHeader)
+	)
+	mCtx, sc, err := b3ExtractMultiple(ctx, traceID, spanID, parentSpanID, sampled, debugFlag)
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(mCtx, sc)
+}
+
+// Fields returns the keys who's values are set with Inject.
+func (b3 B3) Fields() []string {
+	var header []string
+	if b3.InjectEncoding.supports(B3SingleHeader) || b3.InjectEncoding == B3Unspecified {
+		header = append(header, b3ContextHeader)
+	}
+	if b3.InjectEncoding.supports(B3MultipleHeader) {
+		header = append(header, b3TraceIDHeader, b3SpanIDHeader, b3SampledHeader, b3DebugFlagHeader)
+	}
+	return header
+}
+
+// b3ExtractMultiple reconstructs a SpanContext from header values based on
+// B3 Multiple header. It is based on the implementation found here:
+// https://github.com/openzipkin/zipkin-go/blob/v0.2.2/propagation/b3/spancontext.go
+// and adapted to support a SpanContext.
+func b3ExtractMultiple(ctx context.Context, traceID, spanID, parentSpanID, sampled, flags string) (context.Context, trace.SpanContext, error) {
+	var (
+		err           error
+		requiredCount int
+		scc           = trace.SpanContextConfig{Remote: true}
+	)
+
+	// correct values for an existing sampled header are "0" and "1".
+	// For legacy support and  being lenient to other tracing implementations we
+	// allow "true" and "false" as inputs for interop purposes.
+	switch strings.ToLower(sampled) {
+	case "0", "false":
+		// Zero value for TraceFlags sample bit is unset.
+	case "1", "true":
+		scc.TraceFlags = trace.FlagsSampled
+	case "":
+		ctx = b3WithDeferred(ctx, true)
+	default:
+		return ctx, trace.SpanContext{}, errB3InvalidSampledHeader
+	}
+
+	// The only accepted value for Flags is "1". This will set Debug bitmask and
+	// sampled bitmask to 1 since debug implicitly means sampled. All other
+	// values and omission of header will be ignored. According to the spec. User
+	// shouldn't send X-B3-Sampled header along with X-B3-Flags header. Thus we will
+	// ignore X-B3-Sampled header when X-B3-Flags header is sent and valid.
+	if flags == "1" {
+		ctx = b3WithDeferred(ctx, false)
+		ctx = b3WithDebug(ctx, true)
+		scc.TraceFlags |= trace.FlagsSampled
+	}
+
+	if traceID != "" {
+		requiredCount++
+		id := traceID
+		if len(traceID) == 16 {
+			// Pad 64-bit trace IDs.
+			id = b3TraceIDPadding + traceID
+		}
+		if scc.TraceID, err = trace.TraceIDFromHex(id); err != nil {
+			return ctx, trace.SpanContext{}, errB3InvalidTraceIDHeader
+		}
+	}
+
+	if spanID != "" {
+		requiredCount++
+		if scc.SpanID, err = trace.SpanIDFromHex(spanID); err != nil {
+			return ctx, trace.SpanContext{}, errB3InvalidSpanIDHeader
+		}
+	}
+
+	if requiredCount != 0 && requiredCount != 2 {
+		return ctx, trace.SpanContext{}, errB3InvalidScope
+	}
+
+	if parentSpanID != "" {
+		if requiredCount == 0 {
+			return ctx, trace.SpanContext{}, errB3InvalidScopeParent
+		}
+		// Validate parent span ID but we do not use it so do not save it.
+		if _, err = trace.SpanIDFromHex(parentSpanID); err != nil {
+			return ctx, trace.SpanContext{}, errB3InvalidParentSpanIDHeader
+		}
+	}
+
+	return ctx, trace.NewSpanContext(scc), nil
+}
+
+// b3ExtractSingle reconstructs a SpanContext from contextHeader based on a
+// B3 Single header. It is based on the implementation found here:
+// https://github.com/openzipkin/zipkin-go/blob/v0.2.2/propagation/b3/spancontext.go
+// and adapted to support a SpanContext.
+func b3ExtractSingle(ctx context.Context, contextHeader string) (context.Context, trace.SpanContext, error) {
+	if contextHeader == "" {
+		return ctx, trace.SpanContext{}, errB3EmptyContext
+	}
+
+	var (
+		scc      = trace.SpanContextConfig{Remote: true}
+		sampling string
+	)
+
+	headerLen := len(contextHeader)
+
+	if headerLen == b3SamplingWidth {
+		sampling = contextHeader
+	} else if headerLen == b3TraceID64BitsWidth || headerLen == b3TraceID128BitsWidth {
+		// Trace ID by itself is invalid.
+		return ctx, trace.SpanContext{}, errB3InvalidScope
+	} else if headerLen >= b3TraceID64BitsWidth+b3SpanIDWidth+b3SeparatorWidth {
+		pos := 0
+		var traceID string
+		if string(contextHeader[b3TraceID64BitsWidth]) == "-" {
+			// traceID must be 64 bits
+			pos += b3TraceID64BitsWidth // {traceID}
+			traceID = b3TraceIDPadding + contextHeader[0:pos]
+		} else if string(contextHeader[b3TraceID128BitsWidth]) == "-" {
+			// traceID must be 128 bits
+			pos += b3TraceID128BitsWidth // {traceID}
+			traceID = contextHeader[0:pos]
+		} else {
+			return ctx, trace.SpanContext{}, errB3InvalidTraceIDValue
+		}
+		var err error
+		scc.TraceID, err = trace.TraceIDFromHex(traceID)
+		if err != nil {
+			return ctx, trace.SpanContext{}, errB3InvalidTraceIDValue
+		}
+		pos += b3SeparatorWidth // {traceID}-
+
+		if headerLen < pos+b3SpanIDWidth {
+			return ctx, trace.SpanContext{}, errB3InvalidSpanIDValue
+		}
+		scc.SpanID, err = trace.SpanIDFromHex(contextHeader[pos : pos+b3SpanIDWidth])
+		if err != nil {
+			return ctx, trace.SpanContext{}, errB3InvalidSpanIDValue
+		}
+		pos += b3SpanIDWidth // {traceID}-{spanID}
+
+		if headerLen > pos {
+			if headerLen == pos+b3SeparatorWidth {
+				// {traceID}-{spanID}- is invalid.
+				return ctx, trace.SpanContext{}, errB3InvalidSampledByte
+			}
+			pos += b3SeparatorWidth // {traceID}-{spanID}-
+
+			if headerLen == pos+b3SamplingWidth {
+				sampling = string(contextHeader[pos])
+			} else if headerLen == pos+b3ParentSpanIDWidth {
+				// {traceID}-{spanID}-{parentSpanID} is invalid.
+				return ctx, trace.SpanContext{}, errB3InvalidScopeParentSingle
+			} else if headerLen == pos+b3SamplingWidth+b3SeparatorWidth+b3ParentSpanIDWidth {
+				sampling = string(contextHeader[pos])
+				pos += b3SamplingWidth + b3SeparatorWidth // {traceID}-{spanID}-{sampling}-
+
+				// Validate parent span ID but we do not use it so do not
+				// save it.
+				_, err = trace.SpanIDFromHex(contextHeader[pos:])
+				if err != nil {
+					return ctx, trace.SpanContext{}, errB3InvalidParentSpanIDValue
+				}
+			} else {
+				return ctx, trace.SpanContext{}, errB3InvalidParentSpanIDValue
+			}
+		}
+	} else {
+		return ctx, trace.SpanContext{}, errB3InvalidTraceIDValue
+	}
+	switch sampling {
+	case "":
+		ctx = b3WithDeferred(ctx, true)
+	case "d":
+		ctx = b3WithDebug(ctx, true)
+		scc.TraceFlags = trace.FlagsSampled
+	case "1":
+		scc.TraceFlags = trace.FlagsSampled
+	case "0":
+		// Zero value for TraceFlags sample bit is unset.
+	default:
+		return ctx, trace.SpanContext{}, errB3InvalidSampledByte
+	}
+
+	return ctx, trace.NewSpanContext(scc), nil
+}
+
+type b3KeyType int
+
+const (
+	b3DebugKey b3KeyType = iota
+	b3DeferredKey
+)
+
+// b3WithDebug returns a copy of parent with debug set as the debug flag
+// value.
+func b3WithDebug(parent context.Context, debug bool) context.Context {
+	return context.WithValue(parent, b3DebugKey, debug)
+}
+
+// b3DebugFromContext returns the debug value stored in ctx.
+//
+// If no debug value is stored in ctx false is returned.
+func b3DebugFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if debug, ok := ctx.Value(b3DebugKey).(bool); ok {
+		return debug
+	}
+	return false
+}
+
+// b3WithDeferred returns a copy of parent with deferred set as the deferred
+// flag value.
+func b3WithDeferred(parent context.Context, deferred bool) context.Context {
+	return context.WithValue(parent, b3DeferredKey, deferred)
+}
+
+// b3DeferredFromContext returns the deferred value stored in ctx.
+//
+// If no deferred value is stored in ctx false is returned.
+func b3DeferredFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if deferred, ok := ctx.Value(b3DeferredKey).(bool); ok {
+		return deferred
+	}
+	return false
+}
@@ -0,0 +1,423 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	b3TraceID64Str   = "a3ce929d0e0e4736"
+	b3ParentSpanID   = "00f067aa0ba90200"
+	b3PaddedTraceID  = "0000000000000000" + b3TraceID64Str
+	b3InvalidHexChar = "4bf92f3577b34da6a3ce929d0e0e473z"
+)
+
+type b3ExtractTest struct {
+	name    string
+	carrier propagation.MapCarrier
+	sc      trace.SpanContext
+}
+
+func TestB3ExtractValid(t *testing.T) {
+	sampled := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	notSampled := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
+	})
+
+	tests := []b3ExtractTest{
+		{
+			name: "multiple: sampled",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+			sc: sampled,
+		},
+		{
+			name: "multiple: not sampled",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "0",
+			},
+			sc: notSampled,
+		},
+		{
+			name: "multiple: legacy sampled",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "true",
+			},
+			sc: sampled,
+		},
+		{
+			name: "multiple: deferred",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+			},
+			sc: notSampled,
+		},
+		{
+			name: "multiple: debug",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-flags":   "1",
+			},
+			sc: sampled,
+		},
+		{
+			name: "multiple: parent span ID",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid":      traceIDStr,
+				"x-b3-spanid":       spanIDStr,
+				"x-b3-parentspanid": b3ParentSpanID,
+				"x-b3-sampled":      "1",
+			},
+			sc: sampled,
+		},
+		{
+			name: "multiple: 64-bit trace ID",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": b3TraceID64Str,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    mustTraceIDFromHex(b3PaddedTraceID),
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "single: sampled",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-1",
+			},
+			sc: sampled,
+		},
+		{
+			name: "single: not sampled",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-0",
+			},
+			sc: notSampled,
+		},
+		{
+			name: "single: deferred",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr,
+			},
+			sc: notSampled,
+		},
+		{
+			name: "single: debug",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-d",
+			},
+			sc: sampled,
+		},
+		{
+			name: "single: parent span ID",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-1-" + b3ParentSpanID,
+			},
+			sc: sampled,
+		},
+		{
+			name: "single: 64-bit trace ID",
+			carrier: propagation.MapCarrier{
+				"b3": b3TraceID64Str + "-" + spanIDStr + "-1",
+			},
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    mustTraceIDFromHex(b3PaddedTraceID),
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "single header preferred",
+			carrier: propagation.MapCarrier{
+				"b3":           traceIDStr + "-" + spanIDStr + "-1",
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "0",
+			},
+			sc: sampled,
+		},
+		{
+			name: "invalid single header falls back to multiple",
+			carrier: propagation.MapCarrier{
+				"b3":           traceIDStr + "-" + spanIDStr + "-x",
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "0",
+			},
+			sc: notSampled,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := propagation.B3{}.Extract(context.Background(), tc.carrier)
+			assert.Equal(t, tc.sc, trace.SpanContextFromContext(ctx))
+		})
+	}
+}
+
+func TestB3ExtractInvalid(t *testing.T) {
+	tests := []b3ExtractTest{
+		{
+			name:    "no headers",
+			carrier: propagation.MapCarrier{},
+		},
+		{
+			name: "multiple: missing span ID",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-sampled": "1",
+			},
+		},
+		{
+			name: "multiple: invalid trace ID",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": b3InvalidHexChar,
+				"x-b3-spanid":  spanIDStr,
+			},
+		},
+		{
+			name: "multiple: invalid sampled",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "2",
+			},
+		},
+		{
+			name: "multiple: parent span ID without scope",
+			carrier: propagation.MapCarrier{
+				"x-b3-parentspanid": b3ParentSpanID,
+			},
+		},
+		{
+			name: "multiple: invalid parent span ID",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid":      traceIDStr,
+				"x-b3-spanid":       spanIDStr,
+				"x-b3-parentspanid": "invalid",
+			},
+		},
+		{
+			name: "single: sampling only",
+			carrier: propagation.MapCarrier{
+				"b3": "1",
+			},
+		},
+		{
+			name: "single: trace ID only",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr,
+			},
+		},
+		{
+			name: "single: trailing separator",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-",
+			},
+		},
+		{
+			name: "single: parent span ID without sampling",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-" + b3ParentSpanID,
+			},
+		},
+		{
+			name: "single: invalid trace ID",
+			carrier: propagation.MapCarrier{
+				"b3": b3InvalidHexChar + "-" + spanIDStr + "-1",
+			},
+		},
+		{
+			name: "single: truncated span ID",
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr[:8],
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			got := propagation.B3{}.Extract(ctx, tc.carrier)
+			assert.Equal(t, ctx, got, "context should be returned unmodified")
+		})
+	}
+}
+
+func TestB3Inject(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	tests := []struct {
+		name     string
+		encoding propagation.B3Encoding
+		want     propagation.MapCarrier
+	}{
+		{
+			name:     "unspecified",
+			encoding: propagation.B3Unspecified,
+			want: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-1",
+			},
+		},
+		{
+			name:     "single",
+			encoding: propagation.B3SingleHeader,
+			want: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-1",
+			},
+		},
+		{
+			name:     "multiple",
+			encoding: propagation.B3MultipleHeader,
+			want: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+		},
+		{
+			name:     "single and multiple",
+			encoding: propagation.B3SingleHeader | propagation.B3MultipleHeader,
+			want: propagation.MapCarrier{
+				"b3":           traceIDStr + "-" + spanIDStr + "-1",
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := propagation.MapCarrier{}
+			propagation.B3{InjectEncoding: tc.encoding}.Inject(ctx, got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestB3InjectInvalidSpanContext(t *testing.T) {
+	got := propagation.MapCarrier{}
+	propagation.B3{InjectEncoding: propagation.B3SingleHeader | propagation.B3MultipleHeader}.Inject(context.Background(), got)
+	assert.Empty(t, got)
+}
+
+func TestB3RoundTripSamplingState(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding propagation.B3Encoding
+		carrier  propagation.MapCarrier
+	}{
+		{
+			name:     "single: debug",
+			encoding: propagation.B3SingleHeader,
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr + "-d",
+			},
+		},
+		{
+			name:     "single: deferred",
+			encoding: propagation.B3SingleHeader,
+			carrier: propagation.MapCarrier{
+				"b3": traceIDStr + "-" + spanIDStr,
+			},
+		},
+		{
+			name:     "multiple: debug",
+			encoding: propagation.B3MultipleHeader,
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-flags":   "1",
+			},
+		},
+		{
+			name:     "multiple: deferred",
+			encoding: propagation.B3MultipleHeader,
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := propagation.B3{InjectEncoding: tc.encoding}
+			ctx := p.Extract(context.Background(), tc.carrier)
+			got := propagation.MapCarrier{}
+			p.Inject(ctx, got)
+			assert.Equal(t, tc.carrier, got)
+		})
+	}
+}
+
+func TestB3Fields(t *testing.T) {
+	assert.Equal(t, []string{"b3"}, propagation.B3{}.Fields())
+	assert.Equal(t,
+		[]string{"x-b3-traceid", "x-b3-spanid", "x-b3-sampled", "x-b3-flags"},
+		propagation.B3{InjectEncoding: propagation.B3MultipleHeader}.Fields(),
+	)
+}
+
+func TestB3CompositePropagator(t *testing.T) {
+	p := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.B3{})
+	ctx := p.Extract(context.Background(), propagation.MapCarrier{
+		"b3": traceIDStr + "-" + spanIDStr + "-1",
+	})
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, traceID, sc.TraceID())
+	assert.Equal(t, spanID, sc.SpanID())
+	assert.True(t, sc.IsSampled())
+
+	got := propagation.MapCarrier{}
+	p.Inject(ctx, got)
+	assert.Equal(t, traceIDStr+"-"+spanIDStr+"-1", got.Get("b3"))
+	assert.Equal(t, "00-"+traceIDStr+"-"+spanIDStr+"-01", got.Get("traceparent"))
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	jaegerHeader              = "uber-trace-id"
+	jaegerBaggageHeaderPrefix = "uberctx-"
+	jaegerSeparator           = ":"
+	jaegerTraceID128bitsWidth = 32
+	jaegerSpanIDWidth         = 16
+
+	jaegerIDPaddingChar = "0"
+
+	jaegerFlagsDebug      = 0x02
+	jaegerFlagsSampled    = 0x01
+	jaegerFlagsNotSampled = 0x00
+
+	jaegerDeprecatedParentSpanID = "0"
+)
+
+var (
+	errJaegerMalformedTraceContextVal = errors.New("header value of uber-trace-id should contain four different part separated by : ")
+	errJaegerInvalidTraceIDLength     = errors.New("invalid trace id length, must be either 16 or 32")
+	errJaegerMalformedTraceID         = errors.New("cannot decode trace id from header, should be a string of hex, lowercase trace id can't be all zero")
+	errJaegerInvalidSpanIDLength      = errors.New("invalid span id length, must be 16")
+	errJaegerMalformedSpanID          = errors.New("cannot decode span id from header, should be a string of hex, lowercase span id can't be all zero")
+	errJaegerMalformedFlag            = errors.New("cannot decode flag")
+)
+
+// Jaeger is a propagator that supports the Jaeger native propagation format
+// (https://www.jaegertracing.io/docs/1.28/client-libraries/#propagation-format).
+//
+// The trace context is propagated with the uber-trace-id header and baggage
+// is propagated with uberctx-{key} headers. Trace IDs shorter than 128 bits
+// (i.e. 64-bit trace IDs) are left-padded with zeros when extracted.
+//
+// Since the uberctx-{key} headers depend on the baggage being propagated,
+// only the uber-trace-id header is reported by Fields.
+type Jaeger struct{}
+
+var _ TextMapPropagator = Jaeger{}
+
+// Inject injects a context and its baggage into the carrier as Jaeger
+// headers. The parent span ID is set to the deprecated "0" value because it
+// is not tracked in the SpanContext.
+func (j Jaeger) Inject(ctx context.Context, carrier TextMapCarrier) {
+	for _, m := range baggage.FromContext(ctx).Members() {
+		value, err := url.PathUnescape(m.Value())
+		if err != nil {
+			value = m.Value()
+		}
+		carrier.Set(jaegerBaggageHeaderPrefix+m.Key(), url.QueryEscape(value))
+	}
+
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
+		return
+	}
+
+	var flags int
+	if jaegerDebugFromContext(ctx) {
+		flags = jaegerFlagsDebug | jaegerFlagsSampled
+	} else if sc.IsSampled() {
+		flags = jaegerFlagsSampled
+	} else {
+		flags = jaegerFlagsNotSampled
+	}
+
+	carrier.Set(jaegerHeader, strings.Join([]string{
+		sc.TraceID().String(),
+		sc.SpanID().String(),
+		jaegerDeprecatedParentSpanID,
+		fmt.Sprintf("%x", flags),
+	}, jaegerSeparator))
+}
+
+// Extract extracts a context and baggage from the carrier if it contains
+// Jaeger headers.
+//
+// The extracted baggage members are added to any baggage already contained
+// in ctx. If the uber-trace-id header is missing or invalid, no remote
+// SpanContext is set in the returned Context.
+func (j Jaeger) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	ctx = jaegerExtractBaggage(ctx, carrier)
+
+	if h := carrier.Get(jaegerHeader); h != "" {
+		sCtx, sc, err := jaegerExtract(ctx, h)
+		if err == nil && sc.IsValid() {
+			return trace.ContextWithRemoteSpanContext(sCtx, sc)
+		}
+	}
+
+	return ctx
+}
+
+// Fields returns the keys who's values are set with Inject.
+func (j Jaeger) Fields() []string {
+	return []string{jaegerHeader}
+}
+
+// jaegerExtractBaggage returns a copy of ctx containing the baggage members
+// found in the uberctx-{key} headers of carrier. Members that are not valid
+// W3C baggage members are dropped.
+func jaegerExtractBaggage(ctx context.Context, carrier TextMapCarrier) context.Context {
+	bag := baggage.FromContext(ctx)
+	found := false
+	for _, k := range carrier.Keys() {
+		// Keys are compared case-insensitively as HTTP header carriers
+		// canonicalize them.
+		lower := strings.ToLower(k)
+		if !strings.HasPrefix(lower, jaegerBaggageHeaderPrefix) {
+			continue
+		}
+
+		value, err := url.QueryUnescape(carrier.Get(k))
+		if err != nil {
+			continue
+		}
+		m, err := baggage.NewMember(strings.TrimPrefix(lower, jaegerBaggageHeaderPrefix), url.PathEscape(value))
+		if err != nil {
+			continue
+		}
+		if b, err := bag.SetMember(m); err == nil {
+			bag = b
+			found = true
+		}
+	}
+
+	if !found {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+func jaegerExtract(ctx context.Context, headerVal string) (context.Context, trace.SpanContext, error) {
+	var (
+		scc = trace.SpanContextConfig{Remote: true}
+		err error
+	)
+
+	parts := strings.Split(headerVal, jaegerSeparator)
+	if len(parts) != 4 {
+		return ctx, trace.SpanContext{}, errJaegerMalformedTraceContextVal
+	}
+
+	// extract trace ID
+	if parts[0] != "" {
+		id := parts[0]
+		if len(id) > jaegerTraceID128bitsWidth {
+			return ctx, trace.SpanContext{}, errJaegerInvalidTraceIDLength
+		}
+		// padding when length is less than 32
+		if len(id) < jaegerTraceID128bitsWidth {
+			padCharCount := jaegerTraceID128bitsWidth - len(id)
+			id = strings.Repeat(jaegerIDPaddingChar, padCharCount) + id
+		}
+		scc.TraceID, err = trace.TraceIDFromHex(id)
+		if err != nil {
+			return ctx, trace.SpanContext{}, errJaegerMalformedTraceID
+		}
+	}
+
+	// extract span ID
+	if parts[1] != "" {
+		id := parts[1]
+		if len(id) > jaegerSpanIDWidth {
+			return ctx, trace.SpanContext{}, errJaegerInvalidSpanIDLength
+		}
+		// padding when length is less than 16
+		if len(id) < jaegerSpanIDWidth {
+			padCharCount := jaegerSpanIDWidth - len(id)
+			id = strings.Repeat(jaegerIDPaddingChar, padCharCount) + id
+		}
+		scc.SpanID, err = trace.SpanIDFromHex(id)
+		if err != nil {
+			return ctx, trace.SpanContext{}, errJaegerMalformedSpanID
+		}
+	}
+
+	// skip third part as it is deprecated
+
+	// extract flag
+	if parts[3] != "" {
+		flag, err := strconv.ParseInt(parts[3], 16, 64)
+		if err != nil {
+			return ctx, trace.SpanContext{}, errJaegerMalformedFlag
+		}
+		if flag&jaegerFlagsSampled == jaegerFlagsSampled {
+			scc.TraceFlags |= trace.FlagsSampled
+			// if sample bit is set, we check if debug bit is also set
+			if flag&jaegerFlagsDebug == jaegerFlagsDebug {
+				ctx = jaegerWithDebug(ctx, true)
+			}
+		}
+		// ignore other bit, including firehose since we don't have corresponding flag in trace context.
+	}
+	return ctx, trace.NewSpanContext(scc), nil
+}
+
+type jaegerKeyType int
+
+const jaegerDebugKey jaegerKeyType = iota
+
+// jaegerWithDebug returns a copy of parent with debug set as the debug flag
+// value.
+func jaegerWithDebug(parent context.Context, debug bool) context.Context {
+	return context.WithValue(parent, jaegerDebugKey, debug)
+}
+
+// jaegerDebugFromContext returns the debug value stored in ctx.
+//
+// If no debug value is stored in ctx false is returned.
+func jaegerDebugFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if debug, ok := ctx.Value(jaegerDebugKey).(bool); ok {
+		return debug
+	}
+	return false
+}
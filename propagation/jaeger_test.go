@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestJaegerExtractValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		sc     trace.SpanContext
+		debug  bool
+	}{
+		{
+			name:   "sampled",
+			header: traceIDStr + ":" + spanIDStr + ":0:1",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "not sampled",
+			header: traceIDStr + ":" + spanIDStr + ":0:0",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name:   "debug",
+			header: traceIDStr + ":" + spanIDStr + ":0:3",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+			debug: true,
+		},
+		{
+			name:   "64-bit trace ID",
+			header: "a3ce929d0e0e4736:" + spanIDStr + ":0:1",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    mustTraceIDFromHex("0000000000000000a3ce929d0e0e4736"),
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "short IDs are padded",
+			header: "3ce929d0e0e4736:f067aa0ba902b7:0:1",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    mustTraceIDFromHex("000000000000000003ce929d0e0e4736"),
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "parent span ID is ignored",
+			header: traceIDStr + ":" + spanIDStr + ":00f067aa0ba90200:1",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{"uber-trace-id": tc.header}
+			ctx := propagation.Jaeger{}.Extract(context.Background(), carrier)
+			assert.Equal(t, tc.sc, trace.SpanContextFromContext(ctx))
+
+			// The debug flag should round trip.
+			got := propagation.MapCarrier{}
+			propagation.Jaeger{}.Inject(ctx, got)
+			want := "0"
+			if tc.sc.IsSampled() {
+				want = "1"
+			}
+			if tc.debug {
+				want = "3"
+			}
+			assert.Equal(t, tc.sc.TraceID().String()+":"+tc.sc.SpanID().String()+":0:"+want, got.Get("uber-trace-id"))
+		})
+	}
+}
+
+func TestJaegerExtractInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"empty", ""},
+		{"missing parts", traceIDStr + ":" + spanIDStr + ":1"},
+		{"too many parts", traceIDStr + ":" + spanIDStr + ":0:1:1"},
+		{"trace ID too long", traceIDStr + "0:" + spanIDStr + ":0:1"},
+		{"malformed trace ID", "4bf92f3577b34da6a3ce929d0e0e473z:" + spanIDStr + ":0:1"},
+		{"all zero trace ID", "00000000000000000000000000000000:" + spanIDStr + ":0:1"},
+		{"span ID too long", traceIDStr + ":" + spanIDStr + "0:0:1"},
+		{"malformed span ID", traceIDStr + ":00f067aa0ba902bz:0:1"},
+		{"malformed flag", traceIDStr + ":" + spanIDStr + ":0:x"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{"uber-trace-id": tc.header}
+			ctx := propagation.Jaeger{}.Extract(context.Background(), carrier)
+			assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
+
+func TestJaegerInjectInvalidSpanContext(t *testing.T) {
+	got := propagation.MapCarrier{}
+	propagation.Jaeger{}.Inject(context.Background(), got)
+	assert.Empty(t, got)
+}
+
+func TestJaegerBaggage(t *testing.T) {
+	header := http.Header{}
+	header.Set("uberctx-user-id", "42")
+	header.Set("uberctx-query", "a b,c;d")
+	header.Set("uberctx-", "ignored")
+	header.Set("other", "ignored")
+
+	ctx := propagation.Jaeger{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+	bag := baggage.FromContext(ctx)
+	assert.Equal(t, 2, bag.Len())
+	assert.Equal(t, "42", bag.Member("user-id").Value())
+	assert.Equal(t, "a%20b%2Cc%3Bd", bag.Member("query").Value())
+
+	got := http.Header{}
+	propagation.Jaeger{}.Inject(ctx, propagation.HeaderCarrier(got))
+	assert.Equal(t, "42", got.Get("uberctx-user-id"))
+	assert.Equal(t, "a+b%2Cc%3Bd", got.Get("uberctx-query"))
+}
+
+func TestJaegerBaggageMergesWithExisting(t *testing.T) {
+	m, err := baggage.NewMember("existing", "value")
+	require.NoError(t, err)
+	bag, err := baggage.New(m)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	ctx = propagation.Jaeger{}.Extract(ctx, propagation.MapCarrier{"uberctx-key": "v"})
+	got := baggage.FromContext(ctx)
+	assert.Equal(t, "value", got.Member("existing").Value())
+	assert.Equal(t, "v", got.Member("key").Value())
+}
+
+func TestJaegerFields(t *testing.T) {
+	assert.Equal(t, []string{"uber-trace-id"}, propagation.Jaeger{}.Fields())
+}
@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // These interfaces describe the various ways to access state from an
@@ -85,6 +87,69 @@ type (
 		Histogram() (Buckets, error)
 	}
 
+	// ExponentialBuckets represents a contiguous range of exponential
+	// histogram buckets.
+	//
+	// Counts[i] holds the count of the bucket with index Offset+i.  At
+	// scale s, the bucket with index i counts the values greater than or
+	// equal to base^i and less than base^(i+1), where base = 2^(2^-s).
+	ExponentialBuckets struct {
+		// Offset is the index of the bucket counted by Counts[0].
+		Offset int32
+
+		// Counts holds the count in each bucket.
+		Counts []uint64
+	}
+
+	// ExponentialHistogram returns the count of events in base-2
+	// exponential buckets, whose resolution is determined by the
+	// scale.
+	ExponentialHistogram interface {
+		Aggregation
+		Count() (uint64, error)
+		Sum() (number.Number, error)
+
+		// Scale returns the resolution of the histogram.  Larger
+		// scales have smaller buckets.
+		Scale() (int32, error)
+
+		// ZeroCount returns the number of values equal to zero.
+		ZeroCount() (uint64, error)
+
+		// Positive returns the buckets of the positive values.
+		Positive() (ExponentialBuckets, error)
+
+		// Negative returns the buckets of the absolute values of
+		// the negative values.
+		Negative() (ExponentialBuckets, error)
+	}
+
+	// Exemplar is a measurement sampled by an Aggregator, along with
+	// the trace context it was recorded in.
+	Exemplar struct {
+		// Value is the value of the measurement.
+		Value number.Number
+
+		// Time is the time the measurement was recorded.
+		Time time.Time
+
+		// FilteredAttributes are the attributes of the
+		// measurement that were removed before aggregation.
+		FilteredAttributes []attribute.KeyValue
+
+		// TraceID and SpanID identify the span the measurement
+		// was recorded in, if any.
+		TraceID trace.TraceID
+		SpanID  trace.SpanID
+	}
+
+	// Exemplars returns the measurements sampled by an Aggregator.
+	// It is implemented in addition to the interfaces above.
+	Exemplars interface {
+		Aggregation
+		Exemplars() ([]Exemplar, error)
+	}
+
 	// MinMaxSumCount supports the Min, Max, Sum, and Count interfaces.
 	MinMaxSumCount interface {
 		Aggregation
@@ -114,10 +179,11 @@ type (
 
 // Kind description constants.
 const (
-	SumKind            Kind = "Sum"
-	MinMaxSumCountKind Kind = "MinMaxSumCount"
-	HistogramKind      Kind = "Histogram"
-	LastValueKind      Kind = "Lastvalue"
+	SumKind                  Kind = "Sum"
+	MinMaxSumCountKind       Kind = "MinMaxSumCount"
+	HistogramKind            Kind = "Histogram"
+	ExponentialHistogramKind Kind = "ExponentialHistogram"
+	LastValueKind            Kind = "Lastvalue"
 )
 
 // Sentinel errors for Aggregation interface.
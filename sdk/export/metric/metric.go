@@ -269,6 +269,8 @@ type Metadata struct {
 type Accumulation struct {
 	Metadata
 	aggregator Aggregator
+	selector   AggregatorSelector
+	library    instrumentation.Library
 }
 
 // Record contains the exported data for a single metric instrument
@@ -279,6 +281,7 @@ type Record struct {
 	aggregation aggregation.Aggregation
 	start       time.Time
 	end         time.Time
+	stale       bool
 }
 
 // Descriptor describes the metric instrument being exported.
@@ -296,14 +299,50 @@ func (m Metadata) Labels() *attribute.Set {
 // Accumulations to send to Processors. The Descriptor, Labels,
 // and Aggregator represent aggregate metric events received over a single
 // collection period.
-func NewAccumulation(descriptor *sdkapi.Descriptor, labels *attribute.Set, aggregator Aggregator) Accumulation {
-	return Accumulation{
+func NewAccumulation(descriptor *sdkapi.Descriptor, labels *attribute.Set, aggregator Aggregator, opts ...AccumulationOption) Accumulation {
+	a := Accumulation{
 		Metadata: Metadata{
 			descriptor: descriptor,
 			labels:     labels,
 		},
 		aggregator: aggregator,
 	}
+	for _, opt := range opts {
+		opt.applyAccumulation(&a)
+	}
+	return a
+}
+
+// AccumulationOption sets an optional property of an Accumulation.
+type AccumulationOption interface {
+	applyAccumulation(*Accumulation)
+}
+
+// WithAggregatorSelector sets the AggregatorSelector that chose the
+// Aggregator of an Accumulation when it differs from the one embedded in
+// the Processor, as configured by a View.
+func WithAggregatorSelector(selector AggregatorSelector) AccumulationOption {
+	return selectorOption{selector}
+}
+
+type selectorOption struct{ selector AggregatorSelector }
+
+func (o selectorOption) applyAccumulation(a *Accumulation) {
+	a.selector = o.selector
+}
+
+// WithInstrumentationLibrary sets the instrumentation library of the
+// instrument of an Accumulation, so that Processors receiving
+// Accumulations from the Accumulators of several libraries can tell their
+// instruments apart.
+func WithInstrumentationLibrary(library instrumentation.Library) AccumulationOption {
+	return libraryOption(library)
+}
+
+type libraryOption instrumentation.Library
+
+func (o libraryOption) applyAccumulation(a *Accumulation) {
+	a.library = instrumentation.Library(o)
 }
 
 // Aggregator returns the checkpointed aggregator. It is safe to
@@ -312,6 +351,20 @@ func (r Accumulation) Aggregator() Aggregator {
 	return r.aggregator
 }
 
+// AggregatorSelector returns the AggregatorSelector that chose the
+// Aggregator of this Accumulation, or nil if it was chosen by the
+// Processor itself. Processors that allocate Aggregators of their own
+// for this Accumulation should use it when it is not nil.
+func (r Accumulation) AggregatorSelector() AggregatorSelector {
+	return r.selector
+}
+
+// Library returns the instrumentation library of the instrument of this
+// Accumulation, which is empty unless set by WithInstrumentationLibrary.
+func (r Accumulation) Library() instrumentation.Library {
+	return r.library
+}
+
 // NewRecord allows Processor implementations to construct export
 // records.  The Descriptor, Labels, and Aggregator represent
 // aggregate metric events received over a single collection period.
@@ -327,6 +380,22 @@ func NewRecord(descriptor *sdkapi.Descriptor, labels *attribute.Set, aggregation
 	}
 }
 
+// NewStaleRecord is like NewRecord, for a record marking the end of a
+// series that the Processor no longer reports because it was not
+// updated for too long.  The aggregation is the last one of the series.
+func NewStaleRecord(descriptor *sdkapi.Descriptor, labels *attribute.Set, aggregation aggregation.Aggregation, start, end time.Time) Record {
+	r := NewRecord(descriptor, labels, aggregation, start, end)
+	r.stale = true
+	return r
+}
+
+// Stale returns whether the record marks the end of a series, created by
+// NewStaleRecord.  Exporters supporting it should export the record as a
+// staleness marker, with no recorded value, or not at all.
+func (r Record) Stale() bool {
+	return r.stale
+}
+
 // Aggregation returns the aggregation, an interface to the record and
 // its aggregator, dependent on the kind of both the input and exporter.
 func (r Record) Aggregation() aggregation.Aggregation {
@@ -16,10 +16,13 @@ package metric
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 )
 
 var testSlice = []attribute.KeyValue{
@@ -69,3 +72,34 @@ func TestIteratorToSlice(t *testing.T) {
 	got = iter.ToSlice()
 	require.Nil(t, got)
 }
+
+func TestAccumulationLibrary(t *testing.T) {
+	labels := attribute.NewSet(testSlice...)
+	require.Equal(t, instrumentation.Library{}, NewAccumulation(nil, &labels, nil).Library())
+
+	library := instrumentation.Library{Name: "test", Version: "v1"}
+	accum := NewAccumulation(nil, &labels, nil, WithInstrumentationLibrary(library))
+	require.Equal(t, library, accum.Library())
+	require.Equal(t, &labels, accum.Labels())
+	require.Nil(t, accum.AggregatorSelector())
+
+	selector := testSelector{}
+	accum = NewAccumulation(nil, &labels, nil, WithAggregatorSelector(selector), WithInstrumentationLibrary(library))
+	require.Equal(t, library, accum.Library())
+	require.Equal(t, selector, accum.AggregatorSelector())
+}
+
+type testSelector struct{}
+
+func (testSelector) AggregatorFor(*sdkapi.Descriptor, ...*Aggregator) {}
+
+func TestStaleRecord(t *testing.T) {
+	labels := attribute.NewSet(testSlice...)
+	require.False(t, NewRecord(nil, &labels, nil, time.Time{}, time.Time{}).Stale())
+
+	end := time.Now()
+	record := NewStaleRecord(nil, &labels, nil, time.Time{}, end)
+	require.True(t, record.Stale())
+	require.Equal(t, &labels, record.Labels())
+	require.Equal(t, end, record.EndTime())
+}
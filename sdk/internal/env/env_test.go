@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+)
+
+func TestIntEnvOr(t *testing.T) {
+	testCases := []struct {
+		name          string
+		envValue      string
+		defaultValue  int
+		expectedValue int
+	}{
+		{
+			name:          "valid value",
+			envValue:      "2500",
+			defaultValue:  500,
+			expectedValue: 2500,
+		},
+		{
+			name:          "invalid value",
+			envValue:      "localhost",
+			defaultValue:  500,
+			expectedValue: 500,
+		},
+	}
+
+	envStore := ottest.NewEnvStore()
+	envStore.Record(BatchSpanProcessorMaxQueueSizeKey)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, os.Setenv(BatchSpanProcessorMaxQueueSizeKey, tc.envValue))
+			assert.Equal(t, tc.expectedValue, IntEnvOr(BatchSpanProcessorMaxQueueSizeKey, tc.defaultValue))
+		})
+	}
+}
+
+func TestIntEnvOrUnset(t *testing.T) {
+	envStore := ottest.NewEnvStore()
+	envStore.Record(SpanEventCountKey)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	require.NoError(t, os.Unsetenv(SpanEventCountKey))
+	assert.Equal(t, 128, SpanEventCount(128))
+}
+
+func TestBatchSpanProcessorDurations(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		BatchSpanProcessorScheduleDelayKey: "100",
+		BatchSpanProcessorExportTimeoutKey: "invalid",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	assert.Equal(t, 100*time.Millisecond, BatchSpanProcessorScheduleDelay(time.Second))
+	assert.Equal(t, time.Second, BatchSpanProcessorExportTimeout(time.Second))
+}
+
+func TestSpanAttributeValueLength(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		AttributeValueLengthKey: "100",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	assert.Equal(t, 100, SpanAttributeValueLength(-1))
+
+	// The span specific limit takes precedence.
+	require.NoError(t, os.Setenv(SpanAttributeValueLengthKey, "10"))
+	defer func() { require.NoError(t, os.Unsetenv(SpanAttributeValueLengthKey)) }()
+	assert.Equal(t, 10, SpanAttributeValueLength(-1))
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides the token bucket used by the rate limiting
+// samplers of the SDK.
+package ratelimit // import "go.opentelemetry.io/otel/sdk/internal/ratelimit"
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket that is refilled at a constant rate of
+// creditsPerSecond and holds at most maxBalance credits. It is safe for
+// concurrent use.
+type Limiter struct {
+	lock sync.Mutex
+
+	creditsPerSecond float64
+	balance          float64
+	maxBalance       float64
+	lastTick         time.Time
+
+	now func() time.Time
+}
+
+// New returns a Limiter that starts with a full bucket, unless it is never
+// refilled.
+func New(creditsPerSecond, maxBalance float64) *Limiter {
+	return NewWithClock(creditsPerSecond, maxBalance, time.Now)
+}
+
+// NewWithClock returns a Limiter like New that reads the current time from
+// now.
+func NewWithClock(creditsPerSecond, maxBalance float64, now func() time.Time) *Limiter {
+	l := &Limiter{
+		creditsPerSecond: creditsPerSecond,
+		maxBalance:       maxBalance,
+		lastTick:         now(),
+		now:              now,
+	}
+	if creditsPerSecond > 0 {
+		l.balance = maxBalance
+	}
+	return l
+}
+
+// Allow reports whether a credit is available and, if so, consumes it.
+func (l *Limiter) Allow() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill()
+	if l.balance >= 1 {
+		l.balance--
+		return true
+	}
+	return false
+}
+
+// Update changes the rate and capacity of l. The accumulated credits are
+// kept, up to the new capacity.
+func (l *Limiter) Update(creditsPerSecond, maxBalance float64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refill()
+	l.creditsPerSecond = creditsPerSecond
+	l.maxBalance = maxBalance
+	l.balance = math.Min(l.balance, maxBalance)
+}
+
+// refill adds the credits accumulated since the last tick.
+func (l *Limiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.lastTick).Seconds()
+	l.lastTick = now
+	if elapsed > 0 {
+		l.balance = math.Min(l.balance+elapsed*l.creditsPerSecond, l.maxBalance)
+	}
+}
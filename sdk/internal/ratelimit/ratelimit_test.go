@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := NewWithClock(2, 2, clock.Now)
+
+	assert.True(t, l.Allow())
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	clock.advance(250 * time.Millisecond)
+	assert.False(t, l.Allow())
+	clock.advance(250 * time.Millisecond)
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	// The balance never exceeds its maximum.
+	clock.advance(time.Hour)
+	assert.True(t, l.Allow())
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	l.Update(10, 1)
+	clock.advance(time.Second)
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+}
+
+func TestLimiterNeverRefilled(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := NewWithClock(0, 1, clock.Now)
+	assert.False(t, l.Allow())
+	clock.advance(time.Hour)
+	assert.False(t, l.Allow())
+}
@@ -0,0 +1,382 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Defaults for BatchLogRecordProcessorOptions.
+const (
+	DefaultMaxQueueSize       = 2048
+	DefaultBatchTimeout       = 1000 * time.Millisecond
+	DefaultExportTimeout      = 30000 * time.Millisecond
+	DefaultMaxExportBatchSize = 512
+)
+
+// BatchLogRecordProcessorOption configures a BatchLogRecordProcessor.
+type BatchLogRecordProcessorOption func(o *BatchLogRecordProcessorOptions)
+
+// BatchLogRecordProcessorOptions is configuration settings for a
+// BatchLogRecordProcessor.
+type BatchLogRecordProcessorOptions struct {
+	// MaxQueueSize is the maximum queue size to buffer log records for
+	// delayed processing. If the queue gets full it drops the log records.
+	// Use BlockOnQueueFull to change this behavior.
+	// The default value of MaxQueueSize is 2048.
+	MaxQueueSize int
+
+	// BatchTimeout is the maximum duration for constructing a batch.
+	// Processor forcefully sends available log records when timeout is
+	// reached.
+	// The default value of BatchTimeout is 1000 msec.
+	BatchTimeout time.Duration
+
+	// ExportTimeout specifies the maximum duration for exporting log
+	// records. If the timeout is reached, the export will be cancelled.
+	// The default value of ExportTimeout is 30000 msec.
+	ExportTimeout time.Duration
+
+	// MaxExportBatchSize is the maximum number of log records to process in
+	// a single batch. If there are more than one batch worth of log records
+	// then it processes multiple batches of log records one batch after the
+	// other without any delay.
+	// The default value of MaxExportBatchSize is 512.
+	MaxExportBatchSize int
+
+	// BlockOnQueueFull blocks OnEmit() if the queue is full AND if
+	// BlockOnQueueFull is set to true.
+	// Blocking option should be used carefully as it can severely affect the
+	// performance of an application.
+	BlockOnQueueFull bool
+}
+
+// batchLogRecordProcessor is a LogRecordProcessor that batches
+// asynchronously-received log records and sends them to a LogRecordExporter
+// when complete.
+type batchLogRecordProcessor struct {
+	e LogRecordExporter
+	o BatchLogRecordProcessorOptions
+
+	queue   chan ReadOnlyLogRecord
+	dropped uint32
+
+	batch      []ReadOnlyLogRecord
+	batchMutex sync.Mutex
+	timer      *time.Timer
+	stopWait   sync.WaitGroup
+	stopOnce   sync.Once
+	stopCh     chan struct{}
+}
+
+var _ LogRecordProcessor = (*batchLogRecordProcessor)(nil)
+
+// NewBatchLogRecordProcessor creates a new LogRecordProcessor that will send
+// emitted log record batches to the exporter with the supplied options.
+//
+// If the exporter is nil, the log record processor will preform no action.
+func NewBatchLogRecordProcessor(exporter LogRecordExporter, options ...BatchLogRecordProcessorOption) LogRecordProcessor {
+	o := BatchLogRecordProcessorOptions{
+		BatchTimeout:       DefaultBatchTimeout,
+		ExportTimeout:      DefaultExportTimeout,
+		MaxQueueSize:       DefaultMaxQueueSize,
+		MaxExportBatchSize: DefaultMaxExportBatchSize,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	blp := &batchLogRecordProcessor{
+		e:      exporter,
+		o:      o,
+		batch:  make([]ReadOnlyLogRecord, 0, o.MaxExportBatchSize),
+		timer:  time.NewTimer(o.BatchTimeout),
+		queue:  make(chan ReadOnlyLogRecord, o.MaxQueueSize),
+		stopCh: make(chan struct{}),
+	}
+
+	blp.stopWait.Add(1)
+	go func() {
+		defer blp.stopWait.Done()
+		blp.processQueue()
+		blp.drainQueue()
+	}()
+
+	return blp
+}
+
+// OnEmit method enqueues a ReadOnlyLogRecord for later processing.
+func (blp *batchLogRecordProcessor) OnEmit(_ context.Context, r ReadOnlyLogRecord) {
+	// Do not enqueue log records if we are just going to drop them.
+	if blp.e == nil {
+		return
+	}
+	blp.enqueueBlockOnQueueFull(context.TODO(), r, blp.o.BlockOnQueueFull)
+}
+
+// Shutdown flushes the queue and waits until all log records are processed.
+// It only executes once. Subsequent call does nothing.
+func (blp *batchLogRecordProcessor) Shutdown(ctx context.Context) error {
+	var err error
+	blp.stopOnce.Do(func() {
+		wait := make(chan struct{})
+		go func() {
+			close(blp.stopCh)
+			blp.stopWait.Wait()
+			if blp.e != nil {
+				if err := blp.e.Shutdown(ctx); err != nil {
+					otel.Handle(err)
+				}
+			}
+			close(wait)
+		}()
+		// Wait until the wait group is done or the context is cancelled
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	})
+	return err
+}
+
+// forceFlushLogRecord is a marker enqueued by ForceFlush to signal when all
+// log records enqueued before it have been processed.
+type forceFlushLogRecord struct {
+	ReadOnlyLogRecord
+	flushed chan struct{}
+}
+
+// ForceFlush exports all emitted log records that have not yet been
+// exported.
+func (blp *batchLogRecordProcessor) ForceFlush(ctx context.Context) error {
+	var err error
+	if blp.e != nil {
+		flushCh := make(chan struct{})
+		if blp.enqueueBlockOnQueueFull(ctx, forceFlushLogRecord{flushed: flushCh}, true) {
+			select {
+			case <-flushCh:
+				// Processed any items in queue prior to ForceFlush being called
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		wait := make(chan error)
+		go func() {
+			wait <- blp.exportLogRecords(ctx)
+			close(wait)
+		}()
+		// Wait until the export is finished or the context is cancelled/timed out
+		select {
+		case err = <-wait:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	return err
+}
+
+// WithMaxQueueSize returns a BatchLogRecordProcessorOption that configures the
+// maximum queue size allowed for a BatchLogRecordProcessor.
+func WithMaxQueueSize(size int) BatchLogRecordProcessorOption {
+	return func(o *BatchLogRecordProcessorOptions) {
+		o.MaxQueueSize = size
+	}
+}
+
+// WithMaxExportBatchSize returns a BatchLogRecordProcessorOption that
+// configures the maximum export batch size allowed for a
+// BatchLogRecordProcessor.
+func WithMaxExportBatchSize(size int) BatchLogRecordProcessorOption {
+	return func(o *BatchLogRecordProcessorOptions) {
+		o.MaxExportBatchSize = size
+	}
+}
+
+// WithBatchTimeout returns a BatchLogRecordProcessorOption that configures
+// the maximum delay allowed for a BatchLogRecordProcessor before it will
+// export any held log records (whether the queue is full or not).
+func WithBatchTimeout(delay time.Duration) BatchLogRecordProcessorOption {
+	return func(o *BatchLogRecordProcessorOptions) {
+		o.BatchTimeout = delay
+	}
+}
+
+// WithExportTimeout returns a BatchLogRecordProcessorOption that configures
+// the amount of time a BatchLogRecordProcessor waits for an exporter to
+// export before abandoning the export.
+func WithExportTimeout(timeout time.Duration) BatchLogRecordProcessorOption {
+	return func(o *BatchLogRecordProcessorOptions) {
+		o.ExportTimeout = timeout
+	}
+}
+
+// WithBlocking returns a BatchLogRecordProcessorOption that configures a
+// BatchLogRecordProcessor to wait for enqueue operations to succeed instead
+// of dropping data when the queue is full.
+func WithBlocking() BatchLogRecordProcessorOption {
+	return func(o *BatchLogRecordProcessorOptions) {
+		o.BlockOnQueueFull = true
+	}
+}
+
+// exportLogRecords is a subroutine of processing and draining the queue.
+func (blp *batchLogRecordProcessor) exportLogRecords(ctx context.Context) error {
+	blp.timer.Reset(blp.o.BatchTimeout)
+
+	blp.batchMutex.Lock()
+	defer blp.batchMutex.Unlock()
+
+	if blp.o.ExportTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, blp.o.ExportTimeout)
+		defer cancel()
+	}
+
+	if l := len(blp.batch); l > 0 {
+		err := blp.e.ExportLogRecords(ctx, blp.batch)
+
+		// A new batch is always created after exporting, even if the batch
+		// failed to be exported.
+		//
+		// It is up to the exporter to implement any type of retry logic if a
+		// batch is failing to be exported, since it is specific to the
+		// protocol and backend being sent to.
+		blp.batch = blp.batch[:0]
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processQueue removes log records from the `queue` channel until processor
+// is shut down. It calls the exporter in batches of up to MaxExportBatchSize
+// waiting up to BatchTimeout to form a batch.
+func (blp *batchLogRecordProcessor) processQueue() {
+	defer blp.timer.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		select {
+		case <-blp.stopCh:
+			return
+		case <-blp.timer.C:
+			if err := blp.exportLogRecords(ctx); err != nil {
+				otel.Handle(err)
+			}
+		case r := <-blp.queue:
+			if ffr, ok := r.(forceFlushLogRecord); ok {
+				close(ffr.flushed)
+				continue
+			}
+			blp.batchMutex.Lock()
+			blp.batch = append(blp.batch, r)
+			shouldExport := len(blp.batch) >= blp.o.MaxExportBatchSize
+			blp.batchMutex.Unlock()
+			if shouldExport {
+				if !blp.timer.Stop() {
+					<-blp.timer.C
+				}
+				if err := blp.exportLogRecords(ctx); err != nil {
+					otel.Handle(err)
+				}
+			}
+		}
+	}
+}
+
+// drainQueue awaits the any caller that had added to blp.stopWait
+// to finish the enqueue, then exports the final batch.
+func (blp *batchLogRecordProcessor) drainQueue() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		select {
+		case r := <-blp.queue:
+			if r == nil {
+				if err := blp.exportLogRecords(ctx); err != nil {
+					otel.Handle(err)
+				}
+				return
+			}
+			if ffr, ok := r.(forceFlushLogRecord); ok {
+				close(ffr.flushed)
+				continue
+			}
+
+			blp.batchMutex.Lock()
+			blp.batch = append(blp.batch, r)
+			shouldExport := len(blp.batch) == blp.o.MaxExportBatchSize
+			blp.batchMutex.Unlock()
+
+			if shouldExport {
+				if err := blp.exportLogRecords(ctx); err != nil {
+					otel.Handle(err)
+				}
+			}
+		default:
+			close(blp.queue)
+		}
+	}
+}
+
+func (blp *batchLogRecordProcessor) enqueueBlockOnQueueFull(ctx context.Context, r ReadOnlyLogRecord, block bool) bool {
+	// This ensures the blp.queue<- below does not panic as the
+	// processor shuts down.
+	defer func() {
+		x := recover()
+		switch err := x.(type) {
+		case nil:
+			return
+		case runtime.Error:
+			if err.Error() == "send on closed channel" {
+				return
+			}
+		}
+		panic(x)
+	}()
+
+	select {
+	case <-blp.stopCh:
+		return false
+	default:
+	}
+
+	if block {
+		select {
+		case blp.queue <- r:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	select {
+	case blp.queue <- r:
+		return true
+	default:
+		atomic.AddUint32(&blp.dropped, 1)
+	}
+	return false
+}
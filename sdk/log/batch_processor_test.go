@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+type testBatchExporter struct {
+	mu            sync.Mutex
+	records       []sdklog.ReadOnlyLogRecord
+	sizes         []int
+	batchCount    int
+	shutdownCount int
+	err           error
+}
+
+func (t *testBatchExporter) ExportLogRecords(ctx context.Context, records []sdklog.ReadOnlyLogRecord) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		t.err = ctx.Err()
+		return ctx.Err()
+	default:
+	}
+
+	t.records = append(t.records, records...)
+	t.sizes = append(t.sizes, len(records))
+	t.batchCount++
+	return nil
+}
+
+func (t *testBatchExporter) Shutdown(context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.shutdownCount++
+	return nil
+}
+
+func (t *testBatchExporter) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.records)
+}
+
+func (t *testBatchExporter) getBatchCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.batchCount
+}
+
+var _ sdklog.LogRecordExporter = (*testBatchExporter)(nil)
+
+func emitRecords(lp *sdklog.LoggerProvider, n int, parallel bool) {
+	l := lp.Logger("BatchLogRecordProcessor")
+	wg := &sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		f := func() {
+			defer wg.Done()
+			l.Emit(context.Background(), log.Record{Body: "body"})
+		}
+		if parallel {
+			go f()
+		} else {
+			f()
+		}
+	}
+	wg.Wait()
+}
+
+func TestNewBatchLogRecordProcessorWithNilExporter(t *testing.T) {
+	blp := sdklog.NewBatchLogRecordProcessor(nil)
+	lp := sdklog.NewLoggerProvider(sdklog.WithLogRecordProcessor(blp))
+
+	// These should not panic.
+	emitRecords(lp, 1, false)
+	assert.NoError(t, blp.ForceFlush(context.Background()))
+	assert.NoError(t, blp.Shutdown(context.Background()))
+}
+
+func TestNewBatchLogRecordProcessorWithOptions(t *testing.T) {
+	schDelay := 200 * time.Millisecond
+	tests := []struct {
+		name           string
+		o              []sdklog.BatchLogRecordProcessorOption
+		wantNumRecords int
+		wantBatchCount int
+		genNumRecords  int
+		parallel       bool
+	}{
+		{
+			name:           "default BatchLogRecordProcessorOptions",
+			wantNumRecords: 2053,
+			wantBatchCount: 4,
+			genNumRecords:  2053,
+		},
+		{
+			name: "non-default MaxQueueSize and BatchTimeout",
+			o: []sdklog.BatchLogRecordProcessorOption{
+				sdklog.WithBatchTimeout(schDelay),
+				sdklog.WithMaxQueueSize(200),
+			},
+			wantNumRecords: 205,
+			wantBatchCount: 1,
+			genNumRecords:  205,
+		},
+		{
+			name: "non-default MaxQueueSize, BatchTimeout and MaxExportBatchSize",
+			o: []sdklog.BatchLogRecordProcessorOption{
+				sdklog.WithBatchTimeout(schDelay),
+				sdklog.WithMaxQueueSize(205),
+				sdklog.WithMaxExportBatchSize(20),
+			},
+			wantNumRecords: 210,
+			wantBatchCount: 11,
+			genNumRecords:  210,
+		},
+		{
+			name: "parallel emit",
+			o: []sdklog.BatchLogRecordProcessorOption{
+				sdklog.WithBatchTimeout(schDelay),
+				sdklog.WithMaxExportBatchSize(200),
+			},
+			wantNumRecords: 2000,
+			wantBatchCount: 10,
+			genNumRecords:  2000,
+			parallel:       true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			te := testBatchExporter{}
+			// Always use blocking queue to avoid flaky tests.
+			opts := append(test.o, sdklog.WithBlocking())
+			blp := sdklog.NewBatchLogRecordProcessor(&te, opts...)
+			lp := sdklog.NewLoggerProvider(sdklog.WithLogRecordProcessor(blp))
+
+			emitRecords(lp, test.genNumRecords, test.parallel)
+
+			lp.UnregisterLogRecordProcessor(blp)
+
+			assert.Equal(t, test.wantNumRecords, te.len())
+			if gotBatchCount := te.getBatchCount(); gotBatchCount < test.wantBatchCount {
+				t.Errorf("number batches: got %+v, want >= %+v\nBatches %v\n",
+					gotBatchCount, test.wantBatchCount, te.sizes)
+			}
+			assert.Equal(t, 1, te.shutdownCount)
+		})
+	}
+}
+
+type stuckExporter struct {
+	testBatchExporter
+}
+
+// ExportLogRecords waits for ctx to expire and returns that error.
+func (e *stuckExporter) ExportLogRecords(ctx context.Context, _ []sdklog.ReadOnlyLogRecord) error {
+	<-ctx.Done()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = ctx.Err()
+	return ctx.Err()
+}
+
+func TestBatchLogRecordProcessorExportTimeout(t *testing.T) {
+	exp := new(stuckExporter)
+	blp := sdklog.NewBatchLogRecordProcessor(
+		exp,
+		// Set a non-zero export timeout so a deadline is set.
+		sdklog.WithExportTimeout(1*time.Microsecond),
+		sdklog.WithBlocking(),
+	)
+	lp := sdklog.NewLoggerProvider(sdklog.WithLogRecordProcessor(blp))
+
+	emitRecords(lp, 1, false)
+	lp.UnregisterLogRecordProcessor(blp)
+
+	if !errors.Is(exp.err, context.DeadlineExceeded) {
+		t.Errorf("context deadline error not returned: got %+v", exp.err)
+	}
+}
+
+func TestBatchLogRecordProcessorShutdown(t *testing.T) {
+	var be testBatchExporter
+	blp := sdklog.NewBatchLogRecordProcessor(&be)
+
+	require.NoError(t, blp.Shutdown(context.Background()))
+	assert.Equal(t, 1, be.shutdownCount)
+
+	// Multiple call to Shutdown() should not panic.
+	require.NoError(t, blp.Shutdown(context.Background()))
+	assert.Equal(t, 1, be.shutdownCount)
+}
+
+func TestBatchLogRecordProcessorPostShutdown(t *testing.T) {
+	be := testBatchExporter{}
+	blp := sdklog.NewBatchLogRecordProcessor(&be, sdklog.WithMaxExportBatchSize(50))
+	lp := sdklog.NewLoggerProvider(sdklog.WithLogRecordProcessor(blp))
+
+	emitRecords(lp, 60, false)
+	require.NoError(t, blp.Shutdown(context.Background()))
+	lenBeforeShutdown := be.len()
+
+	emitRecords(lp, 10, false)
+	assert.NoError(t, blp.ForceFlush(context.Background()))
+	assert.Equal(t, lenBeforeShutdown, be.len(), "OnEmit and ForceFlush should have no effect after Shutdown")
+}
+
+func TestBatchLogRecordProcessorForceFlushSucceeds(t *testing.T) {
+	te := testBatchExporter{}
+	blp := sdklog.NewBatchLogRecordProcessor(&te,
+		sdklog.WithBatchTimeout(time.Hour),
+		sdklog.WithBlocking(),
+	)
+	lp := sdklog.NewLoggerProvider(sdklog.WithLogRecordProcessor(blp))
+
+	emitRecords(lp, 10, false)
+	require.NoError(t, blp.ForceFlush(context.Background()))
+	assert.Equal(t, 10, te.len())
+	assert.NoError(t, blp.Shutdown(context.Background()))
+}
+
+func TestBatchLogRecordProcessorForceFlushCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// Cancel the context
+	cancel()
+
+	blp := sdklog.NewBatchLogRecordProcessor(&testBatchExporter{})
+	if got, want := blp.ForceFlush(ctx), context.Canceled; !errors.Is(got, want) {
+		t.Errorf("expected %q error, got %v", want, got)
+	}
+	assert.NoError(t, blp.Shutdown(context.Background()))
+}
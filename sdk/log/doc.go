@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package log contains support for OpenTelemetry logging.
+
+This package is currently in a pre-GA phase. Backwards incompatible changes
+may be introduced in subsequent minor version releases as we work to track the
+evolving OpenTelemetry specification and user feedback.
+
+The LoggerProvider is the SDK implementation of the LoggerProvider interface
+defined in the go.opentelemetry.io/otel/log package. Log records emitted by
+the Loggers it creates are associated with the LoggerProvider's Resource and
+the instrumentation library of the Logger, correlated with the span contained
+in the context they are emitted with, and passed to the registered
+LogRecordProcessors in the order they were registered.
+
+Two LogRecordProcessors are provided: a simple processor that synchronously
+exports each log record, and a batching processor that asynchronously
+exports log records in batches and is recommended for production use.
+*/
+package log // import "go.opentelemetry.io/otel/sdk/log"
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type logger struct {
+	provider               *LoggerProvider
+	instrumentationLibrary instrumentation.Library
+}
+
+var _ log.Logger = &logger{}
+
+// Emit passes the log record r to all the LogRecordProcessors registered with
+// the LoggerProvider of l.
+//
+// The log record is correlated with the span contained in ctx, if any. If
+// the Timestamp of r is zero, the current time is used instead.
+func (l *logger) Emit(ctx context.Context, r log.Record) {
+	lrps, _ := l.provider.logRecordProcessors.Load().(logRecordProcessorStates)
+	if len(lrps) == 0 {
+		return
+	}
+
+	now := time.Now()
+	rec := logRecord{
+		timestamp:              r.Timestamp,
+		observedTimestamp:      now,
+		severity:               r.Severity,
+		severityText:           r.SeverityText,
+		body:                   r.Body,
+		spanContext:            trace.SpanContextFromContext(ctx),
+		instrumentationLibrary: l.instrumentationLibrary,
+		resource:               l.provider.resource,
+	}
+	if rec.timestamp.IsZero() {
+		rec.timestamp = now
+	}
+	if len(r.Attributes) > 0 {
+		// Copy the attributes so the caller can reuse the passed slice.
+		rec.attributes = make([]attribute.KeyValue, len(r.Attributes))
+		copy(rec.attributes, r.Attributes)
+	}
+
+	for _, s := range lrps {
+		s.lp.OnEmit(ctx, rec)
+	}
+}
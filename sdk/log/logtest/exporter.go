@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logtest is a testing helper package for the log SDK. User can
+// configure no-op or in-memory exporters to verify different SDK behaviors or
+// custom instrumentation.
+package logtest // import "go.opentelemetry.io/otel/sdk/log/logtest"
+
+import (
+	"context"
+	"sync"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+var _ sdklog.LogRecordExporter = (*NoopExporter)(nil)
+
+// NewNoopExporter returns a new no-op exporter.
+func NewNoopExporter() *NoopExporter {
+	return new(NoopExporter)
+}
+
+// NoopExporter is an exporter that drops all received log records and
+// performs no action.
+type NoopExporter struct{}
+
+// ExportLogRecords handles export of log records by dropping them.
+func (e *NoopExporter) ExportLogRecords(context.Context, []sdklog.ReadOnlyLogRecord) error {
+	return nil
+}
+
+// Shutdown stops the exporter by doing nothing.
+func (e *NoopExporter) Shutdown(context.Context) error { return nil }
+
+var _ sdklog.LogRecordExporter = (*InMemoryExporter)(nil)
+
+// NewInMemoryExporter returns a new InMemoryExporter.
+func NewInMemoryExporter() *InMemoryExporter {
+	return new(InMemoryExporter)
+}
+
+// InMemoryExporter is an exporter that stores all received log records
+// in-memory.
+type InMemoryExporter struct {
+	mu sync.Mutex
+	rs LogRecordStubs
+}
+
+// ExportLogRecords handles export of log records by storing them in memory.
+func (e *InMemoryExporter) ExportLogRecords(_ context.Context, records []sdklog.ReadOnlyLogRecord) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rs = append(e.rs, LogRecordStubsFromReadOnlyLogRecords(records)...)
+	return nil
+}
+
+// Shutdown stops the exporter by clearing log records held in memory.
+func (e *InMemoryExporter) Shutdown(context.Context) error {
+	e.Reset()
+	return nil
+}
+
+// Reset the current in-memory storage.
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rs = nil
+}
+
+// GetLogRecords returns the current in-memory stored log records.
+func (e *InMemoryExporter) GetLogRecords() LogRecordStubs {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ret := make(LogRecordStubs, len(e.rs))
+	copy(ret, e.rs)
+	return ret
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNoop tests only that the no-op does not crash in different scenarios.
+func TestNoop(t *testing.T) {
+	e := NewNoopExporter()
+
+	require.NoError(t, e.ExportLogRecords(context.Background(), nil))
+	require.NoError(t, e.ExportLogRecords(context.Background(), make(LogRecordStubs, 10).Snapshots()))
+	require.NoError(t, e.ExportLogRecords(context.Background(), make(LogRecordStubs, 0, 10).Snapshots()))
+}
+
+func TestNewInMemoryExporter(t *testing.T) {
+	e := NewInMemoryExporter()
+
+	require.NoError(t, e.ExportLogRecords(context.Background(), nil))
+	assert.Len(t, e.GetLogRecords(), 0)
+
+	input := make(LogRecordStubs, 10)
+	for i := 0; i < 10; i++ {
+		input[i] = LogRecordStub{Body: fmt.Sprintf("log record %d", i)}
+	}
+	require.NoError(t, e.ExportLogRecords(context.Background(), input.Snapshots()))
+	rs := e.GetLogRecords()
+	assert.Len(t, rs, 10)
+	for i, r := range rs {
+		assert.Equal(t, input[i], r)
+	}
+	e.Reset()
+	// Ensure that operations on the internal storage does not change the previously returned value.
+	assert.Len(t, rs, 10)
+	assert.Len(t, e.GetLogRecords(), 0)
+
+	require.NoError(t, e.ExportLogRecords(context.Background(), input.Snapshots()[0:1]))
+	rs = e.GetLogRecords()
+	assert.Len(t, rs, 1)
+	assert.Equal(t, input[0], rs[0])
+}
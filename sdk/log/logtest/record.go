@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest // import "go.opentelemetry.io/otel/sdk/log/logtest"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type LogRecordStubs []LogRecordStub
+
+// LogRecordStubsFromReadOnlyLogRecords returns LogRecordStubs populated from
+// ro.
+func LogRecordStubsFromReadOnlyLogRecords(ro []sdklog.ReadOnlyLogRecord) LogRecordStubs {
+	if len(ro) == 0 {
+		return nil
+	}
+
+	s := make(LogRecordStubs, 0, len(ro))
+	for _, r := range ro {
+		s = append(s, LogRecordStubFromReadOnlyLogRecord(r))
+	}
+
+	return s
+}
+
+// Snapshots returns s as a slice of ReadOnlyLogRecords.
+func (s LogRecordStubs) Snapshots() []sdklog.ReadOnlyLogRecord {
+	if len(s) == 0 {
+		return nil
+	}
+
+	ro := make([]sdklog.ReadOnlyLogRecord, len(s))
+	for i := 0; i < len(s); i++ {
+		ro[i] = s[i].Snapshot()
+	}
+	return ro
+}
+
+// LogRecordStub is a stand-in for an emitted log record.
+type LogRecordStub struct {
+	Timestamp              time.Time
+	ObservedTimestamp      time.Time
+	Severity               log.Severity
+	SeverityText           string
+	Body                   string
+	Attributes             []attribute.KeyValue
+	SpanContext            trace.SpanContext
+	InstrumentationLibrary instrumentation.Library
+	Resource               *resource.Resource
+}
+
+// LogRecordStubFromReadOnlyLogRecord returns a LogRecordStub populated from
+// ro.
+func LogRecordStubFromReadOnlyLogRecord(ro sdklog.ReadOnlyLogRecord) LogRecordStub {
+	if ro == nil {
+		return LogRecordStub{}
+	}
+
+	return LogRecordStub{
+		Timestamp:              ro.Timestamp(),
+		ObservedTimestamp:      ro.ObservedTimestamp(),
+		Severity:               ro.Severity(),
+		SeverityText:           ro.SeverityText(),
+		Body:                   ro.Body(),
+		Attributes:             ro.Attributes(),
+		SpanContext:            ro.SpanContext(),
+		InstrumentationLibrary: ro.InstrumentationLibrary(),
+		Resource:               ro.Resource(),
+	}
+}
+
+// Snapshot returns a read-only copy of the LogRecordStub.
+func (s LogRecordStub) Snapshot() sdklog.ReadOnlyLogRecord {
+	return logRecordSnapshot{
+		timestamp:              s.Timestamp,
+		observedTimestamp:      s.ObservedTimestamp,
+		severity:               s.Severity,
+		severityText:           s.SeverityText,
+		body:                   s.Body,
+		attributes:             s.Attributes,
+		spanContext:            s.SpanContext,
+		instrumentationLibrary: s.InstrumentationLibrary,
+		resource:               s.Resource,
+	}
+}
+
+type logRecordSnapshot struct {
+	// Embed the interface to implement the private method.
+	sdklog.ReadOnlyLogRecord
+
+	timestamp              time.Time
+	observedTimestamp      time.Time
+	severity               log.Severity
+	severityText           string
+	body                   string
+	attributes             []attribute.KeyValue
+	spanContext            trace.SpanContext
+	instrumentationLibrary instrumentation.Library
+	resource               *resource.Resource
+}
+
+func (s logRecordSnapshot) Timestamp() time.Time             { return s.timestamp }
+func (s logRecordSnapshot) ObservedTimestamp() time.Time     { return s.observedTimestamp }
+func (s logRecordSnapshot) Severity() log.Severity           { return s.severity }
+func (s logRecordSnapshot) SeverityText() string             { return s.severityText }
+func (s logRecordSnapshot) Body() string                     { return s.body }
+func (s logRecordSnapshot) Attributes() []attribute.KeyValue { return s.attributes }
+func (s logRecordSnapshot) SpanContext() trace.SpanContext   { return s.spanContext }
+func (s logRecordSnapshot) Resource() *resource.Resource     { return s.resource }
+func (s logRecordSnapshot) InstrumentationLibrary() instrumentation.Library {
+	return s.instrumentationLibrary
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import (
+	"context"
+	"sync"
+)
+
+// LogRecordProcessor is a processing pipeline for log records in the log
+// signal. LogRecordProcessors are registered with a LoggerProvider and are
+// called when a log record is emitted, in the order they are registered.
+type LogRecordProcessor interface {
+	// OnEmit is called when a log record is emitted. It is called
+	// synchronously and should not block.
+	OnEmit(ctx context.Context, r ReadOnlyLogRecord)
+
+	// Shutdown is called when the SDK shuts down. Any cleanup or release of
+	// resources held by the processor should be done in this call.
+	//
+	// Calls to OnEmit or ForceFlush after this has been called should be
+	// ignored.
+	//
+	// All timeouts and cancellations contained in ctx must be honored, this
+	// should not block indefinitely.
+	Shutdown(ctx context.Context) error
+
+	// ForceFlush exports all emitted log records to the configured Exporter
+	// that have not yet been exported. It should only be called when
+	// absolutely necessary, such as when using a FaaS provider that may
+	// suspend the process after an invocation, but before the Processor can
+	// export the emitted log records.
+	ForceFlush(ctx context.Context) error
+}
+
+type logRecordProcessorState struct {
+	lp    LogRecordProcessor
+	state *sync.Once
+}
+type logRecordProcessorStates []*logRecordProcessorState
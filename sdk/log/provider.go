@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	defaultLoggerName = "go.opentelemetry.io/otel/sdk/logger"
+)
+
+// loggerProviderConfig
+type loggerProviderConfig struct {
+	// processors contains collection of LogRecordProcessors that are
+	// processing pipeline for log records in the log signal.
+	// LogRecordProcessors registered with a LoggerProvider are called when
+	// a log record is emitted, in the order they are registered.
+	processors []LogRecordProcessor
+
+	// resource contains attributes representing an entity that produces telemetry.
+	resource *resource.Resource
+}
+
+// LoggerProvider is an OpenTelemetry LoggerProvider. It provides Loggers to
+// instrumentation so it can emit log records.
+type LoggerProvider struct {
+	mu                  sync.Mutex
+	namedLogger         map[instrumentation.Library]*logger
+	logRecordProcessors atomic.Value
+	resource            *resource.Resource
+}
+
+var _ log.LoggerProvider = &LoggerProvider{}
+
+// NewLoggerProvider returns a new and configured LoggerProvider.
+//
+// By default the returned LoggerProvider is configured with:
+//   - the resource.Default() Resource
+//   - no LogRecordProcessors.
+//
+// The passed opts are used to override these default values and configure the
+// returned LoggerProvider appropriately.
+func NewLoggerProvider(opts ...LoggerProviderOption) *LoggerProvider {
+	o := &loggerProviderConfig{}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	ensureValidLoggerProviderConfig(o)
+
+	lp := &LoggerProvider{
+		namedLogger: make(map[instrumentation.Library]*logger),
+		resource:    o.resource,
+	}
+
+	for _, p := range o.processors {
+		lp.RegisterLogRecordProcessor(p)
+	}
+
+	return lp
+}
+
+// Logger returns a Logger with the given name and options. If a Logger for
+// the given name and options does not exist it is created, otherwise the
+// existing Logger is returned.
+//
+// If name is empty, a default name is used instead.
+//
+// This method is safe to be called concurrently.
+func (p *LoggerProvider) Logger(name string, opts ...log.LoggerOption) log.Logger {
+	c := log.NewLoggerConfig(opts...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if name == "" {
+		name = defaultLoggerName
+	}
+	il := instrumentation.Library{
+		Name:      name,
+		Version:   c.InstrumentationVersion(),
+		SchemaURL: c.SchemaURL(),
+	}
+	l, ok := p.namedLogger[il]
+	if !ok {
+		l = &logger{
+			provider:               p,
+			instrumentationLibrary: il,
+		}
+		p.namedLogger[il] = l
+	}
+	return l
+}
+
+// RegisterLogRecordProcessor adds the given LogRecordProcessor to the list of
+// LogRecordProcessors.
+func (p *LoggerProvider) RegisterLogRecordProcessor(lrp LogRecordProcessor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	new := logRecordProcessorStates{}
+	if old, ok := p.logRecordProcessors.Load().(logRecordProcessorStates); ok {
+		new = append(new, old...)
+	}
+	new = append(new, &logRecordProcessorState{
+		lp:    lrp,
+		state: &sync.Once{},
+	})
+	p.logRecordProcessors.Store(new)
+}
+
+// UnregisterLogRecordProcessor removes the given LogRecordProcessor from the
+// list of LogRecordProcessors.
+func (p *LoggerProvider) UnregisterLogRecordProcessor(lrp LogRecordProcessor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	lrps := logRecordProcessorStates{}
+	old, ok := p.logRecordProcessors.Load().(logRecordProcessorStates)
+	if !ok || len(old) == 0 {
+		return
+	}
+	lrps = append(lrps, old...)
+
+	// stop the processor if it is started and remove it from the list
+	var stopOnce *logRecordProcessorState
+	var idx int
+	for i, s := range lrps {
+		if s.lp == lrp {
+			stopOnce = s
+			idx = i
+		}
+	}
+	if stopOnce == nil {
+		return
+	}
+	stopOnce.state.Do(func() {
+		if err := lrp.Shutdown(context.Background()); err != nil {
+			otel.Handle(err)
+		}
+	})
+	copy(lrps[idx:], lrps[idx+1:])
+	lrps[len(lrps)-1] = nil
+	lrps = lrps[:len(lrps)-1]
+
+	p.logRecordProcessors.Store(lrps)
+}
+
+// ForceFlush immediately exports all log records that have not yet been
+// exported for all the registered log record processors.
+func (p *LoggerProvider) ForceFlush(ctx context.Context) error {
+	lrps, ok := p.logRecordProcessors.Load().(logRecordProcessorStates)
+	if !ok {
+		return fmt.Errorf("failed to load log record processors")
+	}
+
+	for _, s := range lrps {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := s.lp.ForceFlush(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts down the log record processors in the order they were
+// registered.
+func (p *LoggerProvider) Shutdown(ctx context.Context) error {
+	lrps, ok := p.logRecordProcessors.Load().(logRecordProcessorStates)
+	if !ok {
+		return fmt.Errorf("failed to load log record processors")
+	}
+
+	for _, s := range lrps {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var err error
+		s.state.Do(func() {
+			err = s.lp.Shutdown(ctx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoggerProviderOption configures a LoggerProvider.
+type LoggerProviderOption interface {
+	apply(*loggerProviderConfig)
+}
+
+type loggerProviderOptionFunc func(*loggerProviderConfig)
+
+func (fn loggerProviderOptionFunc) apply(cfg *loggerProviderConfig) {
+	fn(cfg)
+}
+
+// WithSyncer registers the exporter with the LoggerProvider using a
+// SimpleLogRecordProcessor.
+//
+// This is not recommended for production use. The synchronous nature of the
+// SimpleLogRecordProcessor that will wrap the exporter make it good for
+// testing, debugging, or showing examples of other feature, but it will be
+// slow and have a high computation resource usage overhead. The WithBatcher
+// option is recommended for production use instead.
+func WithSyncer(e LogRecordExporter) LoggerProviderOption {
+	return WithLogRecordProcessor(NewSimpleLogRecordProcessor(e))
+}
+
+// WithBatcher registers the exporter with the LoggerProvider using a
+// BatchLogRecordProcessor configured with the passed opts.
+func WithBatcher(e LogRecordExporter, opts ...BatchLogRecordProcessorOption) LoggerProviderOption {
+	return WithLogRecordProcessor(NewBatchLogRecordProcessor(e, opts...))
+}
+
+// WithLogRecordProcessor registers the LogRecordProcessor with a
+// LoggerProvider.
+func WithLogRecordProcessor(lrp LogRecordProcessor) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg *loggerProviderConfig) {
+		cfg.processors = append(cfg.processors, lrp)
+	})
+}
+
+// WithResource returns a LoggerProviderOption that will configure the
+// Resource r as a LoggerProvider's Resource. The configured Resource is
+// referenced by all the Loggers the LoggerProvider creates. It represents the
+// entity producing telemetry.
+//
+// If this option is not used, the LoggerProvider will use the
+// resource.Default() Resource by default.
+func WithResource(r *resource.Resource) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg *loggerProviderConfig) {
+		var err error
+		cfg.resource, err = resource.Merge(resource.Environment(), r)
+		if err != nil {
+			otel.Handle(err)
+		}
+	})
+}
+
+// ensureValidLoggerProviderConfig ensures that given loggerProviderConfig is
+// valid.
+func ensureValidLoggerProviderConfig(cfg *loggerProviderConfig) {
+	if cfg.resource == nil {
+		cfg.resource = resource.Default()
+	}
+}
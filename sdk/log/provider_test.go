@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type basicProcessor struct {
+	running             bool
+	injectShutdownError error
+	emitted             int
+}
+
+func (p *basicProcessor) OnEmit(context.Context, sdklog.ReadOnlyLogRecord) { p.emitted++ }
+func (p *basicProcessor) ForceFlush(context.Context) error                 { return nil }
+func (p *basicProcessor) Shutdown(context.Context) error {
+	p.running = false
+	return p.injectShutdownError
+}
+
+func TestLoggerIsCached(t *testing.T) {
+	lp := sdklog.NewLoggerProvider()
+	l0 := lp.Logger("name", log.WithInstrumentationVersion("v1"))
+	l1 := lp.Logger("name", log.WithInstrumentationVersion("v1"))
+	l2 := lp.Logger("name", log.WithInstrumentationVersion("v2"))
+	assert.Same(t, l0, l1)
+	assert.NotSame(t, l0, l2)
+}
+
+func TestEmit(t *testing.T) {
+	exp := logtest.NewInMemoryExporter()
+	res := resource.NewSchemaless(attribute.String("service.name", "test"))
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithSyncer(exp),
+		sdklog.WithResource(res),
+	)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	attrs := []attribute.KeyValue{attribute.String("key", "value")}
+	ts := time.Unix(1, 0)
+	l := lp.Logger("test", log.WithInstrumentationVersion("v0.1.0"), log.WithSchemaURL("https://opentelemetry.io/schemas/1.7.0"))
+	l.Emit(ctx, log.Record{
+		Timestamp:    ts,
+		Severity:     log.SeverityWarn,
+		SeverityText: "warning",
+		Body:         "body",
+		Attributes:   attrs,
+	})
+	// Modifying the passed attributes must not modify the emitted record.
+	attrs[0] = attribute.String("key", "changed")
+
+	got := exp.GetLogRecords()
+	require.Len(t, got, 1)
+	assert.Equal(t, ts, got[0].Timestamp)
+	assert.False(t, got[0].ObservedTimestamp.IsZero())
+	assert.Equal(t, log.SeverityWarn, got[0].Severity)
+	assert.Equal(t, "warning", got[0].SeverityText)
+	assert.Equal(t, "body", got[0].Body)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("key", "value")}, got[0].Attributes)
+	assert.Equal(t, sc, got[0].SpanContext)
+	assert.Equal(t, instrumentation.Library{
+		Name:      "test",
+		Version:   "v0.1.0",
+		SchemaURL: "https://opentelemetry.io/schemas/1.7.0",
+	}, got[0].InstrumentationLibrary)
+	v, ok := got[0].Resource.Set().Value("service.name")
+	assert.True(t, ok)
+	assert.Equal(t, "test", v.AsString())
+}
+
+func TestEmitDefaultTimestamp(t *testing.T) {
+	exp := logtest.NewInMemoryExporter()
+	lp := sdklog.NewLoggerProvider(sdklog.WithSyncer(exp))
+
+	before := time.Now()
+	lp.Logger("").Emit(context.Background(), log.Record{Body: "body"})
+	after := time.Now()
+
+	got := exp.GetLogRecords()
+	require.Len(t, got, 1)
+	assert.False(t, got[0].Timestamp.Before(before))
+	assert.False(t, got[0].Timestamp.After(after))
+	assert.Equal(t, got[0].Timestamp, got[0].ObservedTimestamp)
+	assert.False(t, got[0].SpanContext.IsValid())
+	assert.Equal(t, "go.opentelemetry.io/otel/sdk/logger", got[0].InstrumentationLibrary.Name)
+	assert.Equal(t, resource.Default(), got[0].Resource)
+}
+
+func TestRegisterUnregisterProcessor(t *testing.T) {
+	lp := sdklog.NewLoggerProvider()
+	p := &basicProcessor{running: true}
+	lp.RegisterLogRecordProcessor(p)
+
+	l := lp.Logger("test")
+	l.Emit(context.Background(), log.Record{})
+	assert.Equal(t, 1, p.emitted)
+
+	lp.UnregisterLogRecordProcessor(p)
+	assert.False(t, p.running)
+	l.Emit(context.Background(), log.Record{})
+	assert.Equal(t, 1, p.emitted)
+}
+
+func TestShutdownLoggerProvider(t *testing.T) {
+	lp := sdklog.NewLoggerProvider()
+	p := &basicProcessor{running: true}
+	lp.RegisterLogRecordProcessor(p)
+
+	require.NoError(t, lp.Shutdown(context.Background()))
+	assert.False(t, p.running)
+}
+
+func TestFailedProcessorShutdown(t *testing.T) {
+	lp := sdklog.NewLoggerProvider()
+	pErr := errors.New("basic processor shutdown failure")
+	lp.RegisterLogRecordProcessor(&basicProcessor{
+		running:             true,
+		injectShutdownError: pErr,
+	})
+
+	assert.Equal(t, pErr, lp.Shutdown(context.Background()))
+}
+
+func TestNoopLoggerProvider(t *testing.T) {
+	// Ensure the API no-op implementation is usable.
+	log.NewNoopLoggerProvider().Logger("test").Emit(context.Background(), log.Record{})
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ReadOnlyLogRecord allows reading information from the data structure
+// underlying an emitted log record. It is used in places where reading
+// information from a log record is necessary but changing the log record
+// isn't necessary or allowed.
+//
+// Warning: methods may be added to this interface in minor releases.
+type ReadOnlyLogRecord interface {
+	// Timestamp returns the time when the event occurred.
+	Timestamp() time.Time
+	// ObservedTimestamp returns the time when the log record was emitted.
+	ObservedTimestamp() time.Time
+	// Severity returns the numerical severity of the log record.
+	Severity() log.Severity
+	// SeverityText returns the textual severity of the log record.
+	SeverityText() string
+	// Body returns the body of the log record.
+	Body() string
+	// Attributes returns the defining attributes of the log record.
+	Attributes() []attribute.KeyValue
+	// SpanContext returns the SpanContext of the span the log record was
+	// emitted within. It is invalid if the log record was not emitted
+	// within a span.
+	SpanContext() trace.SpanContext
+	// InstrumentationLibrary returns information about the instrumentation
+	// library that emitted the log record.
+	InstrumentationLibrary() instrumentation.Library
+	// Resource returns information about the entity that emitted the log
+	// record.
+	Resource() *resource.Resource
+
+	// A private method to prevent users implementing the
+	// interface and so future additions to it will not
+	// violate compatibility.
+	private()
+}
+
+// logRecord is a record of an emitted log record. It is used as a read-only
+// representation of that log record.
+type logRecord struct {
+	timestamp              time.Time
+	observedTimestamp      time.Time
+	severity               log.Severity
+	severityText           string
+	body                   string
+	attributes             []attribute.KeyValue
+	spanContext            trace.SpanContext
+	instrumentationLibrary instrumentation.Library
+	resource               *resource.Resource
+}
+
+var _ ReadOnlyLogRecord = logRecord{}
+
+func (r logRecord) private() {}
+
+// Timestamp returns the time when the event occurred.
+func (r logRecord) Timestamp() time.Time {
+	return r.timestamp
+}
+
+// ObservedTimestamp returns the time when the log record was emitted.
+func (r logRecord) ObservedTimestamp() time.Time {
+	return r.observedTimestamp
+}
+
+// Severity returns the numerical severity of the log record.
+func (r logRecord) Severity() log.Severity {
+	return r.severity
+}
+
+// SeverityText returns the textual severity of the log record.
+func (r logRecord) SeverityText() string {
+	return r.severityText
+}
+
+// Body returns the body of the log record.
+func (r logRecord) Body() string {
+	return r.body
+}
+
+// Attributes returns the defining attributes of the log record.
+func (r logRecord) Attributes() []attribute.KeyValue {
+	return r.attributes
+}
+
+// SpanContext returns the SpanContext of the span the log record was emitted
+// within.
+func (r logRecord) SpanContext() trace.SpanContext {
+	return r.spanContext
+}
+
+// InstrumentationLibrary returns information about the instrumentation
+// library that emitted the log record.
+func (r logRecord) InstrumentationLibrary() instrumentation.Library {
+	return r.instrumentationLibrary
+}
+
+// Resource returns information about the entity that emitted the log record.
+func (r logRecord) Resource() *resource.Resource {
+	return r.resource
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+// simpleLogRecordProcessor is a LogRecordProcessor that synchronously sends
+// all emitted log records to a LogRecordExporter immediately.
+type simpleLogRecordProcessor struct {
+	exporterMu sync.RWMutex
+	exporter   LogRecordExporter
+	stopOnce   sync.Once
+}
+
+var _ LogRecordProcessor = (*simpleLogRecordProcessor)(nil)
+
+// NewSimpleLogRecordProcessor returns a new LogRecordProcessor that will
+// synchronously send emitted log records to the exporter immediately.
+//
+// This LogRecordProcessor is not recommended for production use. The
+// synchronous nature of this LogRecordProcessor make it good for testing,
+// debugging, or showing examples of other feature, but it will be slow and
+// have a high computation resource usage overhead. The
+// BatchLogRecordProcessor is recommended for production use instead.
+func NewSimpleLogRecordProcessor(exporter LogRecordExporter) LogRecordProcessor {
+	return &simpleLogRecordProcessor{
+		exporter: exporter,
+	}
+}
+
+// OnEmit immediately exports a ReadOnlyLogRecord.
+func (slp *simpleLogRecordProcessor) OnEmit(ctx context.Context, r ReadOnlyLogRecord) {
+	slp.exporterMu.RLock()
+	defer slp.exporterMu.RUnlock()
+
+	if slp.exporter != nil {
+		if err := slp.exporter.ExportLogRecords(context.Background(), []ReadOnlyLogRecord{r}); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
+
+// Shutdown shuts down the exporter this SimpleLogRecordProcessor exports to.
+func (slp *simpleLogRecordProcessor) Shutdown(ctx context.Context) error {
+	var err error
+	slp.stopOnce.Do(func() {
+		// The exporter field needs to be zeroed to signal it is shut down,
+		// meaning all subsequent calls to OnEmit will be gracefully ignored.
+		// The exporter is shut down after the lock is released to avoid a
+		// deadlock if the exporter emits log records while shutting down.
+		slp.exporterMu.Lock()
+		exp := slp.exporter
+		slp.exporter = nil
+		slp.exporterMu.Unlock()
+
+		if exp == nil {
+			return
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- exp.Shutdown(ctx) }()
+
+		// Wait for the exporter to shut down or the deadline to expire.
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			// Prefer the exporter result if it shut down at the same time
+			// the context was done.
+			select {
+			case err = <-done:
+			default:
+				err = ctx.Err()
+			}
+		}
+	})
+	return err
+}
+
+// ForceFlush does nothing as there is no data to flush.
+func (slp *simpleLogRecordProcessor) ForceFlush(context.Context) error {
+	return nil
+}
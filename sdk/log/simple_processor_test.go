@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+)
+
+func TestSimpleLogRecordProcessorOnEmit(t *testing.T) {
+	exp := logtest.NewInMemoryExporter()
+	lp := sdklog.NewLoggerProvider(sdklog.WithSyncer(exp))
+
+	lp.Logger("SimpleLogRecordProcessor").Emit(context.Background(), log.Record{Body: "body"})
+
+	got := exp.GetLogRecords()
+	require.Len(t, got, 1)
+	assert.Equal(t, "body", got[0].Body)
+}
+
+func TestSimpleLogRecordProcessorShutdown(t *testing.T) {
+	exp := &testBatchExporter{}
+	slp := sdklog.NewSimpleLogRecordProcessor(exp)
+	lp := sdklog.NewLoggerProvider(sdklog.WithLogRecordProcessor(slp))
+
+	require.NoError(t, slp.Shutdown(context.Background()))
+	assert.Equal(t, 1, exp.shutdownCount)
+
+	// Log records emitted after shutdown are ignored.
+	lp.Logger("SimpleLogRecordProcessor").Emit(context.Background(), log.Record{})
+	assert.Equal(t, 0, exp.len())
+
+	// Subsequent calls do nothing.
+	require.NoError(t, slp.Shutdown(context.Background()))
+	assert.Equal(t, 1, exp.shutdownCount)
+}
+
+func TestSimpleLogRecordProcessorShutdownHonorsContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	slp := sdklog.NewSimpleLogRecordProcessor(&blockingShutdownExporter{})
+	if got, want := slp.Shutdown(ctx), context.DeadlineExceeded; got != want {
+		t.Errorf("SimpleLogRecordProcessor.Shutdown did not return %v, got %v", want, got)
+	}
+}
+
+type blockingShutdownExporter struct {
+	logtest.NoopExporter
+}
+
+func (e *blockingShutdownExporter) Shutdown(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
@@ -0,0 +1,399 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential // import "go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+)
+
+type (
+	// Aggregator observes events and counts them in base-2 exponential
+	// buckets.  The scale of the buckets is reduced automatically to
+	// keep the number of buckets of each sign within the configured
+	// maximum size.  It also calculates the sum and count of all
+	// events.
+	Aggregator struct {
+		lock    sync.Mutex
+		maxSize int32
+		state   *state
+	}
+
+	// config describes how the histogram is aggregated.
+	config struct {
+		// maxSize is the maximum number of buckets for each of
+		// the positive and negative ranges.
+		maxSize int32
+	}
+
+	// Option configures an exponential histogram config.
+	Option interface {
+		// apply sets one or more config fields.
+		apply(*config)
+	}
+
+	// state represents the state of an exponential histogram.
+	state struct {
+		sum       number.Number
+		count     uint64
+		zeroCount uint64
+		scale     int32
+		positive  buckets
+		negative  buckets
+
+		// exemplars samples the measurements that are
+		// exemplar candidates.
+		exemplars exemplar.Reservoir
+	}
+
+	// buckets is a contiguous range of bucket counts.
+	buckets struct {
+		// offset is the index of the bucket counted by
+		// counts[0].
+		offset int32
+		counts []uint64
+	}
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of buckets for
+	// each of the positive and negative ranges.
+	DefaultMaxSize = 160
+
+	// minMaxSize is the smallest supported maximum number of
+	// buckets, which holds all float64 values at MinScale.
+	minMaxSize = 4
+)
+
+// ErrInfiniteInput is returned by Update when the value is infinite.
+var ErrInfiniteInput = fmt.Errorf("infinite value is an invalid input")
+
+// WithMaxSize sets the maximum number of buckets for each of the positive
+// and negative ranges.  Values smaller than 4 are replaced with 4.
+func WithMaxSize(size int) Option {
+	return maxSizeOption(size)
+}
+
+type maxSizeOption int
+
+func (o maxSizeOption) apply(cfg *config) {
+	cfg.maxSize = int32(o)
+}
+
+var _ export.Aggregator = &Aggregator{}
+var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Count = &Aggregator{}
+var _ aggregation.ExponentialHistogram = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
+
+// New returns cnt new aggregators for computing exponential histograms.
+//
+// The aggregators start at MaxScale and reduce their scale as needed to
+// count the observed values in at most the configured number of buckets.
+func New(cnt int, desc *sdkapi.Descriptor, opts ...Option) []Aggregator {
+	cfg := config{maxSize: DefaultMaxSize}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.maxSize < minMaxSize {
+		cfg.maxSize = minMaxSize
+	}
+
+	aggs := make([]Aggregator, cnt)
+	for i := range aggs {
+		aggs[i] = Aggregator{
+			maxSize: cfg.maxSize,
+			state:   &state{scale: MaxScale},
+		}
+	}
+	return aggs
+}
+
+// Aggregation returns an interface for reading the state of this aggregator.
+func (c *Aggregator) Aggregation() aggregation.Aggregation {
+	return c
+}
+
+// Kind returns aggregation.ExponentialHistogramKind.
+func (c *Aggregator) Kind() aggregation.Kind {
+	return aggregation.ExponentialHistogramKind
+}
+
+// Sum returns the sum of all values in the checkpoint.
+func (c *Aggregator) Sum() (number.Number, error) {
+	return c.state.sum, nil
+}
+
+// Count returns the number of values in the checkpoint.
+func (c *Aggregator) Count() (uint64, error) {
+	return c.state.count, nil
+}
+
+// Scale returns the scale of the buckets in the checkpoint.
+func (c *Aggregator) Scale() (int32, error) {
+	return c.state.scale, nil
+}
+
+// ZeroCount returns the number of zero values in the checkpoint.
+func (c *Aggregator) ZeroCount() (uint64, error) {
+	return c.state.zeroCount, nil
+}
+
+// Positive returns the buckets of the positive values in the checkpoint.
+func (c *Aggregator) Positive() (aggregation.ExponentialBuckets, error) {
+	return c.state.positive.export(), nil
+}
+
+// Negative returns the buckets of the negative values in the checkpoint.
+func (c *Aggregator) Negative() (aggregation.ExponentialBuckets, error) {
+	return c.state.negative.export(), nil
+}
+
+// Exemplars returns the exemplars sampled in the checkpoint.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	return c.state.exemplars.Exemplars(), nil
+}
+
+// SynchronizedMove saves the current state into oa and resets the current
+// state to the empty set.
+func (c *Aggregator) SynchronizedMove(oa export.Aggregator, desc *sdkapi.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+
+	if oa != nil && o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	if o != nil {
+		// Swap case: reset the target state before swapping it
+		// under the lock below.
+		o.state.clear()
+	}
+
+	c.lock.Lock()
+	if o != nil {
+		c.state, o.state = o.state, c.state
+	} else {
+		// No swap case: an asynchronous instrument resets its
+		// single Aggregator.
+		c.state.clear()
+	}
+	c.lock.Unlock()
+
+	return nil
+}
+
+// Update adds the recorded measurement to the current data set.
+func (c *Aggregator) Update(ctx context.Context, number number.Number, desc *sdkapi.Descriptor) error {
+	kind := desc.NumberKind()
+	asFloat := number.CoerceToFloat64(kind)
+	if math.IsInf(asFloat, 0) {
+		return ErrInfiniteInput
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state.count++
+	c.state.sum.AddNumber(kind, number)
+	c.state.exemplars.Offer(ctx, number)
+
+	if asFloat == 0 {
+		c.state.zeroCount++
+		return nil
+	}
+
+	b := &c.state.positive
+	if asFloat < 0 {
+		b = &c.state.negative
+		asFloat = -asFloat
+	}
+	index := mapToIndex(asFloat, c.state.scale)
+	low, high := b.extend(index, index, 0)
+	if change := scaleChange(low, high, c.maxSize); change > 0 {
+		c.state.downscale(change)
+		index = mapToIndex(asFloat, c.state.scale)
+	}
+	b.increment(index, 1)
+	return nil
+}
+
+// Merge combines the histogram of oa into this histogram, reducing the
+// scale as needed to hold the buckets of both.
+func (c *Aggregator) Merge(oa export.Aggregator, desc *sdkapi.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+	if o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	scale := c.state.scale
+	if o.state.scale < scale {
+		scale = o.state.scale
+	}
+	// Find the further reduction needed to fit the union of the
+	// buckets of both histograms at the common scale.
+	change := int32(0)
+	for _, pair := range [][2]*buckets{
+		{&c.state.positive, &o.state.positive},
+		{&c.state.negative, &o.state.negative},
+	} {
+		low, high := int32(math.MaxInt32), int32(math.MinInt32)
+		low, high = pair[0].extend(low, high, c.state.scale-scale)
+		low, high = pair[1].extend(low, high, o.state.scale-scale)
+		if ch := scaleChange(low, high, c.maxSize); ch > change {
+			change = ch
+		}
+	}
+	scale -= change
+
+	c.state.downscale(c.state.scale - scale)
+	c.state.positive.merge(&o.state.positive, o.state.scale-scale)
+	c.state.negative.merge(&o.state.negative, o.state.scale-scale)
+
+	c.state.sum.AddNumber(desc.NumberKind(), o.state.sum)
+	c.state.count += o.state.count
+	c.state.zeroCount += o.state.zeroCount
+	c.state.exemplars.Merge(&o.state.exemplars)
+	return nil
+}
+
+// scaleChange returns the scale reduction needed to hold the buckets from
+// low through high in at most maxSize buckets.
+func scaleChange(low, high, maxSize int32) int32 {
+	change := int32(0)
+	for low < high && int64(high)-int64(low) >= int64(maxSize) {
+		low >>= 1
+		high >>= 1
+		change++
+	}
+	return change
+}
+
+// clear resets s to the empty state at MaxScale.
+func (s *state) clear() {
+	s.sum = 0
+	s.count = 0
+	s.zeroCount = 0
+	s.scale = MaxScale
+	s.positive.clear()
+	s.negative.clear()
+	s.exemplars.Reset()
+}
+
+// downscale reduces the scale of s by change, merging buckets.
+func (s *state) downscale(change int32) {
+	if change <= 0 {
+		return
+	}
+	s.scale -= change
+	s.positive.downscale(change)
+	s.negative.downscale(change)
+}
+
+// empty returns whether b has no buckets.
+func (b *buckets) empty() bool {
+	return len(b.counts) == 0
+}
+
+// high returns the index of the last bucket of b.
+func (b *buckets) high() int32 {
+	return b.offset + int32(len(b.counts)) - 1
+}
+
+// extend returns the range from low through high extended to include the
+// buckets of b downscaled by change.
+func (b *buckets) extend(low, high, change int32) (int32, int32) {
+	if b.empty() {
+		return low, high
+	}
+	if l := b.offset >> change; l < low {
+		low = l
+	}
+	if h := b.high() >> change; h > high {
+		high = h
+	}
+	return low, high
+}
+
+// increment adds n to the count of the bucket with index.
+func (b *buckets) increment(index int32, n uint64) {
+	switch {
+	case b.empty():
+		b.offset = index
+		b.counts = append(b.counts[:0], n)
+		return
+	case index < b.offset:
+		grown := make([]uint64, b.high()-index+1)
+		copy(grown[b.offset-index:], b.counts)
+		b.offset = index
+		b.counts = grown
+	case index > b.high():
+		b.counts = append(b.counts, make([]uint64, index-b.high())...)
+	}
+	b.counts[index-b.offset] += n
+}
+
+// downscale merges the buckets of b into the buckets of a scale smaller
+// by change.
+func (b *buckets) downscale(change int32) {
+	if b.empty() || change <= 0 {
+		return
+	}
+	offset := b.offset >> change
+	size := b.high()>>change - offset + 1
+	for i, count := range b.counts {
+		// Buckets only move to lower positions, which were
+		// already visited.
+		j := (b.offset+int32(i))>>change - offset
+		if int32(i) != j {
+			b.counts[j] += count
+			b.counts[i] = 0
+		}
+	}
+	// Buckets past size were merged into lower buckets above.
+	b.counts = b.counts[:size]
+	b.offset = offset
+}
+
+// merge adds the counts of o, downscaled by change, to b.
+func (b *buckets) merge(o *buckets, change int32) {
+	for i, count := range o.counts {
+		if count != 0 {
+			b.increment((o.offset+int32(i))>>change, count)
+		}
+	}
+}
+
+// clear removes all buckets of b, retaining the allocated memory.
+func (b *buckets) clear() {
+	b.offset = 0
+	b.counts = b.counts[:0]
+}
+
+// export returns the buckets of b.
+func (b *buckets) export() aggregation.ExponentialBuckets {
+	return aggregation.ExponentialBuckets{
+		Offset: b.offset,
+		Counts: b.counts,
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+)
+
+const count = 100
+
+func new2(desc *sdkapi.Descriptor, options ...exponential.Option) (_, _ *exponential.Aggregator) {
+	alloc := exponential.New(2, desc, options...)
+	return &alloc[0], &alloc[1]
+}
+
+func floatDesc() *sdkapi.Descriptor {
+	return aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, number.Float64Kind)
+}
+
+func update(t *testing.T, agg *exponential.Aggregator, desc *sdkapi.Descriptor, values ...float64) {
+	for _, v := range values {
+		aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(v), desc)
+	}
+}
+
+type snapshot struct {
+	scale     int32
+	zeroCount uint64
+	positive  aggregation.ExponentialBuckets
+	negative  aggregation.ExponentialBuckets
+}
+
+func snapshotOf(t *testing.T, agg aggregation.ExponentialHistogram) snapshot {
+	var (
+		s   snapshot
+		err error
+	)
+	s.scale, err = agg.Scale()
+	require.NoError(t, err)
+	s.zeroCount, err = agg.ZeroCount()
+	require.NoError(t, err)
+	s.positive, err = agg.Positive()
+	require.NoError(t, err)
+	s.negative, err = agg.Negative()
+	require.NoError(t, err)
+	return s
+}
+
+func total(counts []uint64) uint64 {
+	var sum uint64
+	for _, c := range counts {
+		sum += c
+	}
+	return sum
+}
+
+func TestExponentialScaleReduction(t *testing.T) {
+	desc := floatDesc()
+	agg, ckpt := new2(desc, exponential.WithMaxSize(4))
+
+	// At scale 0 each power of two has its own bucket, which
+	// holds the first four values in four buckets.
+	update(t, agg, desc, 1, 2, 4, 8)
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+	require.Equal(t, snapshot{
+		scale:    0,
+		positive: aggregation.ExponentialBuckets{Offset: 0, Counts: []uint64{1, 1, 1, 1}},
+	}, snapshotOf(t, ckpt))
+
+	update(t, agg, desc, 1, 2, 4, 8, 16)
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+	require.Equal(t, snapshot{
+		scale:    -1,
+		positive: aggregation.ExponentialBuckets{Offset: 0, Counts: []uint64{2, 2, 1}},
+	}, snapshotOf(t, ckpt))
+
+	count, err := ckpt.Count()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), count)
+	sum, err := ckpt.Sum()
+	require.NoError(t, err)
+	require.Equal(t, 31.0, sum.AsFloat64())
+}
+
+func TestExponentialZeroAndNegative(t *testing.T) {
+	desc := floatDesc()
+	agg, ckpt := new2(desc)
+
+	update(t, agg, desc, 0, -1, 0, 1)
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+
+	require.Equal(t, snapshot{
+		scale:     exponential.MaxScale,
+		zeroCount: 2,
+		positive:  aggregation.ExponentialBuckets{Offset: 0, Counts: []uint64{1}},
+		negative:  aggregation.ExponentialBuckets{Offset: 0, Counts: []uint64{1}},
+	}, snapshotOf(t, ckpt))
+}
+
+func TestExponentialMaxSize(t *testing.T) {
+	aggregatortest.RunProfiles(t, func(t *testing.T, profile aggregatortest.Profile) {
+		desc := aggregatortest.NewAggregatorTest(sdkapi.HistogramInstrumentKind, profile.NumberKind)
+		agg, ckpt := new2(desc, exponential.WithMaxSize(20))
+
+		// Repeat to ensure the checkpointed state is reset
+		// before it is reused.
+		for repeat := 0; repeat < 3; repeat++ {
+			all := aggregatortest.NewNumbers(profile.NumberKind)
+			for i := 0; i < count; i++ {
+				sign := 1
+				if i%2 == 0 {
+					sign = -1
+				}
+				x := profile.Random(sign)
+				all.Append(x)
+				aggregatortest.CheckedUpdate(t, agg, x, desc)
+			}
+			require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+
+			c, err := ckpt.Count()
+			require.NoError(t, err)
+			require.Equal(t, all.Count(), c)
+
+			sum, err := ckpt.Sum()
+			require.NoError(t, err)
+			allSum := all.Sum()
+			require.InEpsilon(t, allSum.CoerceToFloat64(profile.NumberKind), sum.CoerceToFloat64(profile.NumberKind), 1e-9)
+
+			s := snapshotOf(t, ckpt)
+			require.LessOrEqual(t, len(s.positive.Counts), 20)
+			require.LessOrEqual(t, len(s.negative.Counts), 20)
+			require.Equal(t, c, s.zeroCount+total(s.positive.Counts)+total(s.negative.Counts))
+
+			require.Equal(t, snapshot{scale: exponential.MaxScale}, trim(snapshotOf(t, agg)))
+		}
+	})
+}
+
+func TestExponentialMerge(t *testing.T) {
+	desc := floatDesc()
+	rnd := rand.New(rand.NewSource(1))
+	random := func() float64 {
+		return math.Ldexp(rnd.Float64(), rnd.Intn(40)-20) * float64(rnd.Intn(3)-1)
+	}
+
+	for i := 0; i < 20; i++ {
+		agg1, ckpt1 := new2(desc, exponential.WithMaxSize(16))
+		agg2, ckpt2 := new2(desc, exponential.WithMaxSize(16))
+		all, allCkpt := new2(desc, exponential.WithMaxSize(16))
+
+		for j := 0; j < count; j++ {
+			v := random()
+			if j%3 == 0 {
+				update(t, agg1, desc, v)
+			} else {
+				update(t, agg2, desc, v*float64(j))
+			}
+		}
+		require.NoError(t, agg1.SynchronizedMove(ckpt1, desc))
+		require.NoError(t, agg2.SynchronizedMove(ckpt2, desc))
+
+		// The merged histogram equals the histogram of all the
+		// values, which do not depend on the order of updates.
+		for _, ckpt := range []*exponential.Aggregator{ckpt1, ckpt2} {
+			require.NoError(t, all.Merge(ckpt, desc))
+		}
+		aggregatortest.CheckedMerge(t, ckpt1, ckpt2, desc)
+
+		require.NoError(t, all.SynchronizedMove(allCkpt, desc))
+		require.Equal(t, trim(snapshotOf(t, allCkpt)), trim(snapshotOf(t, ckpt1)))
+
+		c, err := ckpt1.Count()
+		require.NoError(t, err)
+		require.Equal(t, uint64(count), c)
+	}
+}
+
+// trim removes the empty buckets at both ends of the ranges of s.
+func trim(s snapshot) snapshot {
+	for _, b := range []*aggregation.ExponentialBuckets{&s.positive, &s.negative} {
+		for len(b.Counts) > 0 && b.Counts[0] == 0 {
+			b.Counts = b.Counts[1:]
+			b.Offset++
+		}
+		for len(b.Counts) > 0 && b.Counts[len(b.Counts)-1] == 0 {
+			b.Counts = b.Counts[:len(b.Counts)-1]
+		}
+		if len(b.Counts) == 0 {
+			*b = aggregation.ExponentialBuckets{}
+		}
+	}
+	return s
+}
+
+func TestExponentialMergeEmpty(t *testing.T) {
+	desc := floatDesc()
+	agg, ckpt := new2(desc)
+	update(t, agg, desc, 3)
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+	want := snapshotOf(t, ckpt)
+
+	empty := &exponential.New(1, desc)[0]
+	aggregatortest.CheckedMerge(t, ckpt, empty, desc)
+	require.Equal(t, want, snapshotOf(t, ckpt))
+
+	aggregatortest.CheckedMerge(t, empty, ckpt, desc)
+	require.Equal(t, want, snapshotOf(t, empty))
+}
+
+func TestExponentialInfiniteInput(t *testing.T) {
+	desc := floatDesc()
+	agg := &exponential.New(1, desc)[0]
+	require.ErrorIs(t, agg.Update(context.Background(), number.NewFloat64Number(math.Inf(1)), desc), exponential.ErrInfiniteInput)
+	c, err := agg.Count()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), c)
+}
+
+func TestSynchronizedMoveReset(t *testing.T) {
+	aggregatortest.SynchronizedMoveResetTest(
+		t,
+		sdkapi.HistogramInstrumentKind,
+		func(desc *sdkapi.Descriptor) export.Aggregator {
+			return &exponential.New(1, desc)[0]
+		},
+	)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential // import "go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+
+import "math"
+
+const (
+	// MaxScale is the largest scale supported by the Aggregator.  At
+	// this scale the relative error of a bucket is below 1e-6.
+	MaxScale int32 = 20
+
+	// MinScale is the smallest scale supported by the Aggregator.  At
+	// this scale all finite float64 values map to the indexes -2, -1,
+	// and 0.
+	MinScale int32 = -10
+)
+
+// mapToIndex returns the index of the bucket counting value at scale.
+// The value must be finite and greater than zero.  The bucket with index
+// i counts the values in [base^i, base^(i+1)), where base = 2^(2^-scale).
+func mapToIndex(value float64, scale int32) int32 {
+	frac, exp := math.Frexp(value)
+	// value = frac * 2^exp with frac in [0.5, 1), hence the base-2
+	// logarithm of value is in [exp-1, exp).
+	if scale <= 0 {
+		return int32(exp-1) >> -scale
+	}
+	if frac == 0.5 {
+		// Exact powers of two are the lower boundaries of their
+		// buckets; avoid the rounding errors of the logarithm.
+		return int32(exp-1) << scale
+	}
+	index := int32(math.Floor(math.Log2(value) * math.Ldexp(1, int(scale))))
+	// Correct rounding errors of the logarithm that leave the index
+	// outside of the buckets of the exponent.
+	if low := int32(exp-1) << scale; index < low {
+		return low
+	}
+	if high := int32(exp)<<scale - 1; index > high {
+		return high
+	}
+	return index
+}
+
+// lowerBoundary returns the smallest value counted by the bucket with
+// index at scale.
+func lowerBoundary(index, scale int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
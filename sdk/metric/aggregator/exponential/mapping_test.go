@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToIndexPowersOfTwo(t *testing.T) {
+	for scale := MinScale; scale <= MaxScale; scale++ {
+		for exp := -1074; exp <= 1023; exp += 7 {
+			want := int32(exp)
+			if scale < 0 {
+				want >>= -scale
+			} else {
+				want <<= scale
+			}
+			assert.Equal(t, want, mapToIndex(math.Ldexp(1, exp), scale), "2^%d at scale %d", exp, scale)
+		}
+	}
+}
+
+func TestMapToIndexBoundaries(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for scale := MinScale; scale <= MaxScale; scale++ {
+		for i := 0; i < 1000; i++ {
+			value := math.Ldexp(1+rnd.Float64(), rnd.Intn(200)-100)
+			index := mapToIndex(value, scale)
+
+			// Allow for the rounding error of the logarithm at
+			// positive scales.
+			const tolerance = 1e-12
+			assert.LessOrEqual(t, lowerBoundary(index, scale), value*(1+tolerance), "%g at scale %d", value, scale)
+			assert.Greater(t, lowerBoundary(index+1, scale), value*(1-tolerance), "%g at scale %d", value, scale)
+		}
+	}
+}
+
+func TestMapToIndexExtremes(t *testing.T) {
+	assert.Equal(t, int32(-1074)<<MaxScale, mapToIndex(math.SmallestNonzeroFloat64, MaxScale))
+	assert.Equal(t, int32(1023)<<MaxScale|(1<<MaxScale-1), mapToIndex(math.MaxFloat64, MaxScale))
+
+	assert.Equal(t, int32(-2), mapToIndex(math.SmallestNonzeroFloat64, MinScale))
+	assert.Equal(t, int32(-1), mapToIndex(0.5, MinScale))
+	assert.Equal(t, int32(0), mapToIndex(1, MinScale))
+	assert.Equal(t, int32(0), mapToIndex(math.MaxFloat64, MinScale))
+}
+
+func TestBucketsDownscale(t *testing.T) {
+	b := buckets{offset: -3, counts: []uint64{1, 2, 3, 4, 5, 6}}
+	b.downscale(1)
+	// Indexes -3..2 map to -2, -1, -1, 0, 0, 1.
+	assert.Equal(t, int32(-2), b.offset)
+	assert.Equal(t, []uint64{1, 5, 9, 6}, b.counts)
+
+	b.downscale(2)
+	assert.Equal(t, int32(-1), b.offset)
+	assert.Equal(t, []uint64{6, 15}, b.counts)
+}
+
+func TestBucketsIncrement(t *testing.T) {
+	var b buckets
+	b.increment(5, 1)
+	b.increment(3, 2)
+	b.increment(8, 3)
+	assert.Equal(t, int32(3), b.offset)
+	assert.Equal(t, []uint64{2, 0, 1, 0, 0, 3}, b.counts)
+
+	b.clear()
+	assert.True(t, b.empty())
+	b.increment(-1, 1)
+	assert.Equal(t, int32(-1), b.offset)
+	assert.Equal(t, []uint64{1}, b.counts)
+}
+
+func TestScaleChange(t *testing.T) {
+	assert.Equal(t, int32(0), scaleChange(0, 3, 4))
+	assert.Equal(t, int32(1), scaleChange(0, 4, 4))
+	assert.Equal(t, int32(19), scaleChange(0, 1<<20, 4))
+	assert.Equal(t, int32(0), scaleChange(math.MaxInt32, math.MinInt32, 4))
+	assert.Equal(t, MaxScale-MinScale, scaleChange(-1074<<MaxScale, 1024<<MaxScale-1, 4))
+}
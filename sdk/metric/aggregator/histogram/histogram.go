@@ -24,6 +24,7 @@ import (
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 )
 
 // Note: This code uses a Mutex to govern access to the exclusive
@@ -61,6 +62,10 @@ type (
 		bucketCounts []uint64
 		sum          number.Number
 		count        uint64
+
+		// exemplars holds the last exemplar candidate of
+		// each bucket.
+		exemplars exemplar.Reservoir
 	}
 )
 
@@ -101,6 +106,7 @@ var _ export.Aggregator = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
 var _ aggregation.Count = &Aggregator{}
 var _ aggregation.Histogram = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new aggregator for computing Histograms.
 //
@@ -170,6 +176,12 @@ func (c *Aggregator) Histogram() (aggregation.Buckets, error) {
 	}, nil
 }
 
+// Exemplars returns the exemplars sampled in the checkpoint, at most one
+// per bucket.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	return c.state.exemplars.Exemplars(), nil
+}
+
 // SynchronizedMove saves the current state into oa and resets the current state to
 // the empty set.  Since no locks are taken, there is a chance that
 // the independent Sum, Count and Bucket Count are not consistent with each
@@ -217,10 +229,11 @@ func (c *Aggregator) clearState() {
 	}
 	c.state.sum = 0
 	c.state.count = 0
+	c.state.exemplars.Reset()
 }
 
 // Update adds the recorded measurement to the current data set.
-func (c *Aggregator) Update(_ context.Context, number number.Number, desc *sdkapi.Descriptor) error {
+func (c *Aggregator) Update(ctx context.Context, number number.Number, desc *sdkapi.Descriptor) error {
 	kind := desc.NumberKind()
 	asFloat := number.CoerceToFloat64(kind)
 
@@ -249,6 +262,7 @@ func (c *Aggregator) Update(_ context.Context, number number.Number, desc *sdkap
 	c.state.count++
 	c.state.sum.AddNumber(kind, number)
 	c.state.bucketCounts[bucketID]++
+	c.state.exemplars.OfferAt(ctx, bucketID, number)
 
 	return nil
 }
@@ -266,5 +280,6 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *sdkapi.Descriptor) error
 	for i := 0; i < len(c.state.bucketCounts); i++ {
 		c.state.bucketCounts[i] += o.state.bucketCounts[i]
 	}
+	c.state.exemplars.Merge(&o.state.exemplars)
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 )
 
 // Aggregator aggregates counter events.
@@ -29,10 +30,15 @@ type Aggregator struct {
 	// current holds current increments to this counter record
 	// current needs to be aligned for 64-bit atomic operations.
 	value number.Number
+
+	// exemplars samples the measurements that are exemplar
+	// candidates.
+	exemplars exemplar.Reservoir
 }
 
 var _ export.Aggregator = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new counter aggregator implemented by atomic
 // operations.  This aggregator implements the aggregation.Sum
@@ -57,11 +63,17 @@ func (c *Aggregator) Sum() (number.Number, error) {
 	return c.value, nil
 }
 
+// Exemplars returns the exemplars sampled in the last checkpoint.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	return c.exemplars.Exemplars(), nil
+}
+
 // SynchronizedMove atomically saves the current value into oa and resets the
 // current sum to zero.
 func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *sdkapi.Descriptor) error {
 	if oa == nil {
 		c.value.SetRawAtomic(0)
+		c.exemplars.Reset()
 		return nil
 	}
 	o, _ := oa.(*Aggregator)
@@ -69,12 +81,14 @@ func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *sdkapi.Descriptor
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	o.value = c.value.SwapNumberAtomic(number.Number(0))
+	c.exemplars.Move(&o.exemplars)
 	return nil
 }
 
 // Update atomically adds to the current value.
-func (c *Aggregator) Update(_ context.Context, num number.Number, desc *sdkapi.Descriptor) error {
+func (c *Aggregator) Update(ctx context.Context, num number.Number, desc *sdkapi.Descriptor) error {
 	c.value.AddNumberAtomic(desc.NumberKind(), num)
+	c.exemplars.Offer(ctx, num)
 	return nil
 }
 
@@ -85,5 +99,6 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *sdkapi.Descriptor) error
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	c.value.AddNumber(desc.NumberKind(), o.value)
+	c.exemplars.Merge(&o.exemplars)
 	return nil
 }
@@ -20,5 +20,6 @@ func AtomicFieldOffsets() map[string]uintptr {
 	return map[string]uintptr{
 		"record.refMapped.value": unsafe.Offsetof(record{}.refMapped.value),
 		"record.updateCount":     unsafe.Offsetof(record{}.updateCount),
+		"syncInstrument.series":  unsafe.Offsetof(syncInstrument{}.series),
 	}
 }
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+)
+
+func TestCardinalityLimit(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t, metricsdk.WithCardinalityLimit(2))
+
+	counter := Must(meter).NewInt64Counter("counter.sum")
+	counter.Add(ctx, 1, attribute.String("K", "a"))
+	counter.Add(ctx, 2, attribute.String("K", "b"))
+	require.NoError(t, testHandler.Flush())
+
+	counter.Add(ctx, 4, attribute.String("K", "c"))
+	counter.Add(ctx, 8, attribute.String("K", "d"))
+	counter.Add(ctx, 16, attribute.String("K", "a"))
+	require.True(t, errors.Is(testHandler.Flush(), metricsdk.ErrCardinalityLimit))
+
+	accum.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/K=a/":                       17,
+		"counter.sum/K=b/":                       2,
+		"counter.sum/otel.metric.overflow=true/": 12,
+	}, processor.Values())
+
+	// The overflow is only reported once.
+	counter.Add(ctx, 1, attribute.String("K", "e"))
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestCardinalityLimitView(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t,
+		metricsdk.WithCardinalityLimit(2),
+		metricsdk.WithViews(
+			view.New(view.MatchInstrumentName("limited.*"), view.WithCardinalityLimit(1)),
+		),
+	)
+
+	limited := Must(meter).NewInt64Counter("limited.sum")
+	other := Must(meter).NewInt64Counter("other.sum")
+	for i, v := range []string{"a", "b", "c"} {
+		kv := attribute.String("K", v)
+		meter.RecordBatch(ctx, []attribute.KeyValue{kv},
+			limited.Measurement(int64(i+1)),
+			other.Measurement(int64(i+1)),
+		)
+	}
+
+	accum.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"limited.sum/K=a/":                       1,
+		"limited.sum/otel.metric.overflow=true/": 5,
+		"other.sum/K=a/":                         1,
+		"other.sum/K=b/":                         2,
+		"other.sum/otel.metric.overflow=true/":   3,
+	}, processor.Values())
+	require.True(t, errors.Is(testHandler.Flush(), metricsdk.ErrCardinalityLimit))
+}
+
+func TestCardinalityLimitExpiredSeries(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t, metricsdk.WithCardinalityLimit(1))
+
+	counter := Must(meter).NewInt64Counter("counter.sum")
+	counter.Add(ctx, 1, attribute.String("K", "a"))
+	accum.Collect(ctx)
+
+	// The series of K=a is removed by the collection without
+	// updates, freeing its place for K=b.
+	accum.Collect(ctx)
+	processor.Reset()
+
+	counter.Add(ctx, 2, attribute.String("K", "b"))
+	accum.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/K=b/": 2,
+	}, processor.Values())
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestCardinalityLimitObserver(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t, metricsdk.WithCardinalityLimit(2))
+
+	_ = Must(meter).NewInt64GaugeObserver("observer.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1, attribute.String("K", "a"))
+		result.Observe(2, attribute.String("K", "b"))
+		result.Observe(3, attribute.String("K", "c"))
+		result.Observe(4, attribute.String("K", "d"))
+	})
+	_ = Must(meter).NewInt64CounterObserver("observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1, attribute.String("K", "a"))
+		result.Observe(2, attribute.String("K", "b"))
+		result.Observe(3, attribute.String("K", "c"))
+		result.Observe(4, attribute.String("K", "d"))
+	})
+
+	for i := 0; i < 2; i++ {
+		processor.Reset()
+		accum.Collect(ctx)
+		require.EqualValues(t, map[string]float64{
+			"observer.lastvalue/K=a/":                       1,
+			"observer.lastvalue/K=b/":                       2,
+			"observer.lastvalue/otel.metric.overflow=true/": 4,
+			"observer.sum/K=a/":                             1,
+			"observer.sum/K=b/":                             2,
+			"observer.sum/otel.metric.overflow=true/":       7,
+		}, processor.Values())
+	}
+	require.True(t, errors.Is(testHandler.Flush(), metricsdk.ErrCardinalityLimit))
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+)
+
+// config contains the options for configuring an Accumulator.
+type config struct {
+	// Library is the instrumentation library of the Meter the
+	// Accumulator implements. It is used to match Views.
+	Library instrumentation.Library
+
+	// Views are matched, in order, against each instrument
+	// created by the Accumulator.
+	Views []view.View
+
+	// ExemplarFilter decides which measurements are offered to
+	// the exemplar reservoirs of the aggregators.
+	ExemplarFilter exemplar.Filter
+
+	// CardinalityLimit is the maximum number of distinct
+	// attribute sets of each instrument not limited by a View.
+	// If 0, the number of attribute sets is not limited.
+	CardinalityLimit int
+}
+
+// Option configures an Accumulator.
+type Option interface {
+	apply(*config)
+}
+
+// WithInstrumentationLibrary sets the instrumentation library of the Meter
+// the Accumulator implements.
+func WithInstrumentationLibrary(library instrumentation.Library) Option {
+	return libraryOption(library)
+}
+
+type libraryOption instrumentation.Library
+
+func (o libraryOption) apply(cfg *config) {
+	cfg.Library = instrumentation.Library(o)
+}
+
+// WithViews adds views to the Accumulator. The first View that matches an
+// instrument determines how its data is exported; instruments not matched
+// by any View are exported unchanged.
+func WithViews(views ...view.View) Option {
+	return viewsOption(views)
+}
+
+type viewsOption []view.View
+
+func (o viewsOption) apply(cfg *config) {
+	cfg.Views = append(cfg.Views, o...)
+}
+
+// WithExemplarFilter sets the filter that decides which synchronous
+// measurements are exemplar candidates.  The default is
+// exemplar.TraceBasedFilter, which samples the measurements recorded in
+// the context of a sampled span.
+func WithExemplarFilter(filter exemplar.Filter) Option {
+	return exemplarFilterOption(filter)
+}
+
+type exemplarFilterOption exemplar.Filter
+
+func (o exemplarFilterOption) apply(cfg *config) {
+	cfg.ExemplarFilter = exemplar.Filter(o)
+}
+
+// WithCardinalityLimit limits the number of distinct attribute sets
+// recorded by each instrument to limit. The measurements of further
+// attribute sets are recorded in a single overflow series, with the
+// OverflowAttribute attribute, until the series not updated during a
+// collection interval are removed. The limit of the instruments matched by
+// a View with a cardinality limit is set by the View instead. The default
+// of 0 sets no limit.
+func WithCardinalityLimit(limit int) Option {
+	return cardinalityLimitOption(limit)
+}
+
+type cardinalityLimitOption int
+
+func (o cardinalityLimitOption) apply(cfg *config) {
+	cfg.CardinalityLimit = int(o)
+}
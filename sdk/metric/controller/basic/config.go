@@ -19,6 +19,8 @@ import (
 
 	"go.opentelemetry.io/otel"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/view"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -57,6 +59,28 @@ type config struct {
 	//
 	// Default value is 10s.  If zero, no Export timeout is applied.
 	PushTimeout time.Duration
+
+	// Views customize the data exported for the instruments of
+	// every Meter created by the Controller.
+	Views []view.View
+
+	// ExemplarFilter decides which measurements of every Meter
+	// created by the Controller are exemplar candidates.
+	//
+	// Default value is exemplar.TraceBasedFilter.
+	ExemplarFilter exemplar.Filter
+
+	// CardinalityLimit is the maximum number of label sets
+	// recorded by each instrument of every Meter created by the
+	// Controller, unless limited by a View.
+	//
+	// Default value is 0, which sets no limit.
+	CardinalityLimit int
+
+	// Readers are the Controllers that also record the
+	// measurements of the instruments of every Meter created by
+	// the Controller.
+	Readers []*Controller
 }
 
 // Option is the interface that applies the value to a configuration option.
@@ -124,3 +148,59 @@ type pushTimeoutOption time.Duration
 func (o pushTimeoutOption) apply(cfg *config) {
 	cfg.PushTimeout = time.Duration(o)
 }
+
+// WithViews adds views to the Views configuration option of a Config.
+// The first View that matches an instrument determines how its data is
+// exported.
+func WithViews(views ...view.View) Option {
+	return viewsOption(views)
+}
+
+type viewsOption []view.View
+
+func (o viewsOption) apply(cfg *config) {
+	cfg.Views = append(cfg.Views, o...)
+}
+
+// WithExemplarFilter sets the ExemplarFilter configuration option of a Config.
+func WithExemplarFilter(filter exemplar.Filter) Option {
+	return exemplarFilterOption(filter)
+}
+
+type exemplarFilterOption exemplar.Filter
+
+func (o exemplarFilterOption) apply(cfg *config) {
+	cfg.ExemplarFilter = exemplar.Filter(o)
+}
+
+// WithCardinalityLimit sets the CardinalityLimit configuration option of a Config.
+func WithCardinalityLimit(limit int) Option {
+	return cardinalityLimitOption(limit)
+}
+
+type cardinalityLimitOption int
+
+func (o cardinalityLimitOption) apply(cfg *config) {
+	cfg.CardinalityLimit = int(o)
+}
+
+// WithReader adds reader to the Readers configuration option of a
+// Config.  The instruments of the Meters created by the Controller
+// record their measurements with both the Controller and reader, so
+// that the same instruments are collected and exported by each one
+// with its own checkpointer, collection period, exporter, and Views.
+// This allows pushing the data of the instruments with an exporter
+// while a pull exporter reads it from reader, for example with a
+// different temporality.
+//
+// The Meters created by reader itself are not affected, and reader is
+// started and stopped independently of the Controller.
+func WithReader(reader *Controller) Option {
+	return readerOption{reader}
+}
+
+type readerOption struct{ reader *Controller }
+
+func (o readerOption) apply(cfg *config) {
+	cfg.Readers = append(cfg.Readers, o.reader)
+}
@@ -23,10 +23,13 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/internal/metric/registry"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/sdkapi"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
 	controllerTime "go.opentelemetry.io/otel/sdk/metric/controller/time"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/view"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -55,10 +58,15 @@ var ErrControllerStarted = fmt.Errorf("controller already started")
 // The controller supports mixing push and pull access to metric data
 // using the export.Reader RWLock interface.  Collection will
 // be blocked by a pull request in the basic controller.
+//
+// The instruments of a Controller configured WithReader record their
+// measurements with other Controllers too, each collecting and
+// exporting them independently.
 type Controller struct {
 	// lock synchronizes Start() and Stop().
 	lock                sync.Mutex
 	libraries           sync.Map
+	meters              sync.Map
 	checkpointerFactory export.CheckpointerFactory
 
 	resource *resource.Resource
@@ -72,6 +80,14 @@ type Controller struct {
 	collectTimeout time.Duration
 	pushTimeout    time.Duration
 
+	views            []view.View
+	exemplarFilter   exemplar.Filter
+	cardinalityLimit int
+
+	// readers are the Controllers also recording the
+	// measurements of the instruments of this Controller.
+	readers []*Controller
+
 	// collectedTime is used only in configurations with no
 	// exporter, when ticker != nil.
 	collectedTime time.Time
@@ -88,18 +104,45 @@ func (c *Controller) Meter(instrumentationName string, opts ...metric.MeterOptio
 		SchemaURL: cfg.SchemaURL(),
 	}
 
+	if len(c.readers) == 0 {
+		return metric.WrapMeterImpl(c.meterImpl(library))
+	}
+
+	m, ok := c.meters.Load(library)
+	if !ok {
+		impls := []sdkapi.MeterImpl{c.meterImpl(library)}
+		for _, reader := range c.readers {
+			impls = append(impls, reader.meterImpl(library))
+		}
+		m, _ = c.meters.LoadOrStore(
+			library,
+			registry.NewUniqueInstrumentMeterImpl(newTeeMeterImpl(impls)),
+		)
+	}
+	return metric.WrapMeterImpl(m.(*registry.UniqueInstrumentMeterImpl))
+}
+
+// meterImpl returns the MeterImpl of the Accumulator collected by the
+// Controller for library, creating it on first use.
+func (c *Controller) meterImpl(library instrumentation.Library) *registry.UniqueInstrumentMeterImpl {
 	m, ok := c.libraries.Load(library)
 	if !ok {
 		checkpointer := c.checkpointerFactory.NewCheckpointer()
 		m, _ = c.libraries.LoadOrStore(
 			library,
 			registry.NewUniqueInstrumentMeterImpl(&accumulatorCheckpointer{
-				Accumulator:  sdk.NewAccumulator(checkpointer),
+				Accumulator: sdk.NewAccumulator(
+					checkpointer,
+					sdk.WithInstrumentationLibrary(library),
+					sdk.WithViews(c.views...),
+					sdk.WithExemplarFilter(c.exemplarFilter),
+					sdk.WithCardinalityLimit(c.cardinalityLimit),
+				),
 				checkpointer: checkpointer,
 				library:      library,
 			}))
 	}
-	return metric.WrapMeterImpl(m.(*registry.UniqueInstrumentMeterImpl))
+	return m.(*registry.UniqueInstrumentMeterImpl)
 }
 
 type accumulatorCheckpointer struct {
@@ -139,6 +182,12 @@ func New(checkpointerFactory export.CheckpointerFactory, opts ...Option) *Contro
 		collectPeriod:  c.CollectPeriod,
 		collectTimeout: c.CollectTimeout,
 		pushTimeout:    c.PushTimeout,
+
+		views:            c.Views,
+		exemplarFilter:   c.ExemplarFilter,
+		cardinalityLimit: c.CardinalityLimit,
+
+		readers: c.Readers,
 	}
 }
 
@@ -30,10 +30,13 @@ import (
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/metric/view"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -473,3 +476,55 @@ func TestRegistryFunction(t *testing.T) {
 		"counter.sum//": 20,
 	}, exp.Values())
 }
+
+func TestControllerViews(t *testing.T) {
+	boundaries := []float64{1, 10, 100}
+	cont := controller.New(
+		processor.NewFactory(
+			simple.NewWithInexpensiveDistribution(),
+			aggregation.CumulativeTemporalitySelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithViews(
+			view.New(
+				view.MatchInstrumentationLibrary(instrumentation.Library{Name: "third-party"}),
+				view.MatchInstrumentName("latency"),
+				view.WithName("http.latency"),
+				view.WithAggregatorSelector(simple.NewWithHistogramDistribution(
+					histogram.WithExplicitBoundaries(boundaries),
+				)),
+			),
+			view.New(view.MatchInstrumentName("noise"), view.WithDrop()),
+		),
+	)
+
+	ctx := context.Background()
+	meter := cont.Meter("third-party")
+	latency := metric.Must(meter).NewFloat64Histogram("latency")
+	noise := metric.Must(meter).NewInt64Counter("noise")
+
+	for i := 0; i < 2; i++ {
+		latency.Record(ctx, 5)
+		noise.Add(ctx, 1)
+		require.NoError(t, cont.Collect(ctx))
+	}
+
+	var names []string
+	require.NoError(t, cont.ForEach(
+		func(_ instrumentation.Library, reader export.Reader) error {
+			return reader.ForEach(
+				aggregation.CumulativeTemporalitySelector(),
+				func(record export.Record) error {
+					names = append(names, record.Descriptor().Name())
+					buckets, err := record.Aggregation().(aggregation.Histogram).Histogram()
+					require.NoError(t, err)
+					require.Equal(t, boundaries, buckets.Boundaries)
+					require.Equal(t, []uint64{0, 2, 0, 0}, buckets.Counts)
+					return nil
+				},
+			)
+		}))
+	require.Equal(t, []string{"http.latency"}, names)
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func readAll(t *testing.T, cont *controller.Controller, sel aggregation.TemporalitySelector) map[string]float64 {
+	records := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, controllertest.ReadAll(cont, sel, records.AddInstrumentationLibraryRecord))
+	return records.Map()
+}
+
+func TestReaderTemporality(t *testing.T) {
+	reader := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	cont := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.DeltaTemporalitySelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithReader(reader),
+	)
+
+	ctx := context.Background()
+	meter := cont.Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+	_ = metric.Must(meter).NewInt64GaugeObserver("observer.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(5, attribute.String("A", "B"))
+	})
+	var batchObserver metric.Int64GaugeObserver
+	batchObserver = metric.Must(meter).NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		result.Observe([]attribute.KeyValue{attribute.String("A", "B")}, batchObserver.Observation(7))
+	}).NewInt64GaugeObserver("batch.lastvalue")
+
+	counter.Add(ctx, 10, attribute.String("A", "B"))
+	require.NoError(t, cont.Collect(ctx))
+	require.NoError(t, reader.Collect(ctx))
+
+	expect := map[string]float64{
+		"counter.sum/A=B/":        10,
+		"observer.lastvalue/A=B/": 5,
+		"batch.lastvalue/A=B/":    7,
+	}
+	require.EqualValues(t, expect, readAll(t, cont, aggregation.DeltaTemporalitySelector()))
+	require.EqualValues(t, expect, readAll(t, reader, aggregation.CumulativeTemporalitySelector()))
+
+	// The reader collects on its own schedule.
+	counter.Add(ctx, 10, attribute.String("A", "B"))
+	require.NoError(t, cont.Collect(ctx))
+	counter.Add(ctx, 10, attribute.String("A", "B"))
+	require.NoError(t, cont.Collect(ctx))
+	require.NoError(t, reader.Collect(ctx))
+
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=B/":        10,
+		"observer.lastvalue/A=B/": 5,
+		"batch.lastvalue/A=B/":    7,
+	}, readAll(t, cont, aggregation.DeltaTemporalitySelector()))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=B/":        30,
+		"observer.lastvalue/A=B/": 5,
+		"batch.lastvalue/A=B/":    7,
+	}, readAll(t, reader, aggregation.CumulativeTemporalitySelector()))
+}
+
+func TestReaderViews(t *testing.T) {
+	reader := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithViews(view.New(view.WithName("renamed.sum"))),
+	)
+	cont := controller.New(
+		processor.NewFactory(
+			processortest.AggregatorSelector(),
+			aggregation.CumulativeTemporalitySelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithReader(reader),
+	)
+
+	ctx := context.Background()
+	meter := cont.Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+	counter.Add(ctx, 1)
+	meter.RecordBatch(ctx, nil, counter.Measurement(2))
+
+	// Instruments are only registered once with each Controller.
+	_, err := meter.NewInt64Counter("counter.sum")
+	require.NoError(t, err)
+	_, err = meter.NewFloat64Counter("counter.sum")
+	require.Error(t, err)
+
+	require.NoError(t, cont.Collect(ctx))
+	require.NoError(t, reader.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//": 3,
+	}, readAll(t, cont, aggregation.CumulativeTemporalitySelector()))
+	require.EqualValues(t, map[string]float64{
+		"renamed.sum//": 3,
+	}, readAll(t, reader, aggregation.CumulativeTemporalitySelector()))
+}
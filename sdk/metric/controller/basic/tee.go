@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+)
+
+// teeMeterImpl is a MeterImpl recording the measurements of its
+// instruments with the MeterImpls of several Controllers.
+type teeMeterImpl struct {
+	impls []sdkapi.MeterImpl
+
+	// lock protects batchRunners.
+	lock sync.Mutex
+
+	// batchRunners maps the batch observers of the instruments
+	// to the runners registered with each MeterImpl.
+	batchRunners map[sdkapi.AsyncBatchRunner][]*teeBatchRunner
+}
+
+// teeSyncInstrument records with an instrument of each MeterImpl.
+type teeSyncInstrument struct {
+	descriptor sdkapi.Descriptor
+	impls      []sdkapi.SyncImpl
+}
+
+// teeAsyncInstrument is observed with an instrument of each MeterImpl.
+type teeAsyncInstrument struct {
+	descriptor sdkapi.Descriptor
+	impls      []sdkapi.AsyncImpl
+}
+
+// teeBatchRunner runs a batch observer for one MeterImpl, passing it
+// the observations of its own instruments.
+type teeBatchRunner struct {
+	runner sdkapi.AsyncBatchRunner
+
+	// lock protects instruments.
+	lock sync.Mutex
+
+	// instruments maps the instruments of the batch observer to
+	// those of the MeterImpl.
+	instruments map[sdkapi.AsyncImpl]sdkapi.AsyncImpl
+}
+
+var (
+	_ sdkapi.MeterImpl        = &teeMeterImpl{}
+	_ sdkapi.SyncImpl         = &teeSyncInstrument{}
+	_ sdkapi.AsyncImpl        = &teeAsyncInstrument{}
+	_ sdkapi.AsyncBatchRunner = &teeBatchRunner{}
+)
+
+func newTeeMeterImpl(impls []sdkapi.MeterImpl) *teeMeterImpl {
+	return &teeMeterImpl{
+		impls:        impls,
+		batchRunners: map[sdkapi.AsyncBatchRunner][]*teeBatchRunner{},
+	}
+}
+
+// RecordBatch implements sdkapi.MeterImpl.
+func (t *teeMeterImpl) RecordBatch(ctx context.Context, labels []attribute.KeyValue, measurements ...sdkapi.Measurement) {
+	for i, impl := range t.impls {
+		ms := make([]sdkapi.Measurement, 0, len(measurements))
+		for _, m := range measurements {
+			if inst, ok := m.SyncImpl().(*teeSyncInstrument); ok {
+				ms = append(ms, sdkapi.NewMeasurement(inst.impls[i], m.Number()))
+				continue
+			}
+			// Let the MeterImpl report foreign instruments.
+			ms = append(ms, m)
+		}
+		impl.RecordBatch(ctx, labels, ms...)
+	}
+}
+
+// NewSyncInstrument implements sdkapi.MeterImpl.
+func (t *teeMeterImpl) NewSyncInstrument(descriptor sdkapi.Descriptor) (sdkapi.SyncImpl, error) {
+	inst := &teeSyncInstrument{descriptor: descriptor}
+	for _, impl := range t.impls {
+		s, err := impl.NewSyncInstrument(descriptor)
+		if err != nil {
+			return nil, err
+		}
+		inst.impls = append(inst.impls, s)
+	}
+	return inst, nil
+}
+
+// NewAsyncInstrument implements sdkapi.MeterImpl.
+func (t *teeMeterImpl) NewAsyncInstrument(descriptor sdkapi.Descriptor, runner sdkapi.AsyncRunner) (sdkapi.AsyncImpl, error) {
+	inst := &teeAsyncInstrument{descriptor: descriptor}
+	batch, isBatch := runner.(sdkapi.AsyncBatchRunner)
+	runners := t.batchRunnersFor(batch, isBatch)
+	for i, impl := range t.impls {
+		r := runner
+		if isBatch {
+			// The observations of a batch observer refer to
+			// the tee instruments, which each MeterImpl
+			// needs to see as its own.
+			r = runners[i]
+		}
+		a, err := impl.NewAsyncInstrument(descriptor, r)
+		if err != nil {
+			return nil, err
+		}
+		inst.impls = append(inst.impls, a)
+		if isBatch {
+			runners[i].add(inst, a)
+		}
+	}
+	return inst, nil
+}
+
+// batchRunnersFor returns the runners of batch for each MeterImpl, or
+// nil when the instrument is not observed by a batch observer.
+func (t *teeMeterImpl) batchRunnersFor(batch sdkapi.AsyncBatchRunner, isBatch bool) []*teeBatchRunner {
+	if !isBatch {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	runners, ok := t.batchRunners[batch]
+	if !ok {
+		for range t.impls {
+			runners = append(runners, &teeBatchRunner{
+				runner:      batch,
+				instruments: map[sdkapi.AsyncImpl]sdkapi.AsyncImpl{},
+			})
+		}
+		t.batchRunners[batch] = runners
+	}
+	return runners
+}
+
+// Implementation implements sdkapi.InstrumentImpl.
+func (s *teeSyncInstrument) Implementation() interface{} {
+	return s
+}
+
+// Descriptor implements sdkapi.InstrumentImpl.
+func (s *teeSyncInstrument) Descriptor() sdkapi.Descriptor {
+	return s.descriptor
+}
+
+// RecordOne implements sdkapi.SyncImpl.
+func (s *teeSyncInstrument) RecordOne(ctx context.Context, number number.Number, labels []attribute.KeyValue) {
+	for _, impl := range s.impls {
+		impl.RecordOne(ctx, number, labels)
+	}
+}
+
+// Implementation implements sdkapi.InstrumentImpl.
+func (a *teeAsyncInstrument) Implementation() interface{} {
+	return a
+}
+
+// Descriptor implements sdkapi.InstrumentImpl.
+func (a *teeAsyncInstrument) Descriptor() sdkapi.Descriptor {
+	return a.descriptor
+}
+
+func (r *teeBatchRunner) add(inst *teeAsyncInstrument, impl sdkapi.AsyncImpl) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.instruments[inst] = impl
+}
+
+// AnyRunner implements sdkapi.AsyncRunner.
+func (*teeBatchRunner) AnyRunner() {}
+
+// Run implements sdkapi.AsyncBatchRunner.
+func (r *teeBatchRunner) Run(ctx context.Context, capture func([]attribute.KeyValue, ...sdkapi.Observation)) {
+	r.runner.Run(ctx, func(labels []attribute.KeyValue, obs ...sdkapi.Observation) {
+		translated := make([]sdkapi.Observation, len(obs))
+		r.lock.Lock()
+		for i, ob := range obs {
+			translated[i] = ob
+			if impl, ok := r.instruments[ob.AsyncImpl()]; ok {
+				translated[i] = sdkapi.NewObservation(impl, ob.Number())
+			}
+		}
+		r.lock.Unlock()
+		capture(labels, translated...)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exemplar provides the exemplar filters and reservoirs used by the
+// metric SDK to sample measurements along with the trace context they were
+// recorded in.
+//
+// The Accumulator decides with a Filter whether a synchronous measurement
+// is an exemplar candidate, and marks the context passed to the
+// Aggregator using NewCandidateContext.  Aggregators that support
+// exemplars offer their updates to a Reservoir, which keeps a bounded
+// sample of the candidates.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/exemplar"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Filter decides whether a measurement recorded with ctx is an exemplar
+// candidate.
+type Filter func(ctx context.Context) bool
+
+// AlwaysOnFilter makes every measurement an exemplar candidate.
+func AlwaysOnFilter(context.Context) bool {
+	return true
+}
+
+// AlwaysOffFilter disables exemplars.
+func AlwaysOffFilter(context.Context) bool {
+	return false
+}
+
+// TraceBasedFilter makes the measurements recorded in the context of a
+// sampled span exemplar candidates.
+func TraceBasedFilter(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsSampled()
+}
+
+type candidateKeyType int
+
+const candidateKey candidateKeyType = 0
+
+// candidate holds the information about a measurement that is not
+// passed to Aggregators explicitly.
+type candidate struct {
+	filtered []attribute.KeyValue
+}
+
+// NewCandidateContext returns a copy of parent that marks the measurements
+// recorded with it as exemplar candidates.  The filtered attributes are the
+// attributes of the measurement removed before aggregation.
+func NewCandidateContext(parent context.Context, filtered []attribute.KeyValue) context.Context {
+	return context.WithValue(parent, candidateKey, candidate{filtered: filtered})
+}
+
+// candidateFromContext returns the candidate information stored in ctx.
+func candidateFromContext(ctx context.Context) (candidate, bool) {
+	if ctx == nil {
+		return candidate{}, false
+	}
+	c, ok := ctx.Value(candidateKey).(candidate)
+	return c, ok
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/exemplar"
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultReservoirSize is the number of exemplars a Reservoir keeps when
+// sampling with Offer.
+const DefaultReservoirSize = 4
+
+// Reservoir stores the exemplars of an Aggregator.  Candidates are either
+// sampled uniformly with Offer, keeping at most DefaultReservoirSize
+// exemplars, or stored in a slot chosen by the Aggregator with OfferAt,
+// such as the bucket of a histogram.  An Aggregator uses only one of
+// these.
+//
+// The zero value is an empty Reservoir ready to use.  It is safe for
+// concurrent use.
+type Reservoir struct {
+	lock sync.Mutex
+
+	// offered is the number of candidates offered since the last
+	// reset, used for uniform sampling.
+	offered int64
+
+	// exemplars holds one exemplar per slot.  Empty slots have a
+	// zero Time.
+	exemplars []aggregation.Exemplar
+}
+
+// Offer samples the measurement num recorded with ctx, if ctx marks it as
+// an exemplar candidate.  Every candidate offered since the last reset has
+// an equal probability of being kept.
+func (r *Reservoir) Offer(ctx context.Context, num number.Number) {
+	c, ok := candidateFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.offered++
+	slot := int(r.offered - 1)
+	if r.offered > DefaultReservoirSize {
+		slot = int(rand.Int63n(r.offered))
+		if slot >= DefaultReservoirSize {
+			return
+		}
+	}
+	r.store(slot, newExemplar(ctx, c, num))
+}
+
+// OfferAt stores the measurement num recorded with ctx in slot, if ctx
+// marks it as an exemplar candidate, replacing the exemplar stored there.
+func (r *Reservoir) OfferAt(ctx context.Context, slot int, num number.Number) {
+	c, ok := candidateFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.store(slot, newExemplar(ctx, c, num))
+}
+
+// Exemplars returns the stored exemplars.
+func (r *Reservoir) Exemplars() []aggregation.Exemplar {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var out []aggregation.Exemplar
+	for _, e := range r.exemplars {
+		if !e.Time.IsZero() {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Move moves the exemplars of r into dest, leaving r empty.  If dest is
+// nil, the exemplars of r are discarded.
+//
+// The two locks are never held together, so that Move and Merge cannot
+// deadlock when called concurrently on the same pair of Reservoirs.
+func (r *Reservoir) Move(dest *Reservoir) {
+	if dest == nil {
+		r.Reset()
+		return
+	}
+
+	// Reuse the slots of dest for r.
+	dest.lock.Lock()
+	spare := dest.exemplars
+	dest.offered, dest.exemplars = 0, nil
+	dest.lock.Unlock()
+	for i := range spare {
+		spare[i] = aggregation.Exemplar{}
+	}
+
+	r.lock.Lock()
+	offered, exemplars := r.offered, r.exemplars
+	r.offered, r.exemplars = 0, spare
+	r.lock.Unlock()
+
+	dest.lock.Lock()
+	defer dest.lock.Unlock()
+	dest.offered, dest.exemplars = offered, exemplars
+}
+
+// Merge adds the exemplars of o to r.  Exemplars in the same slot are
+// replaced by the more recent one.  Exemplars of a uniformly sampled
+// Reservoir are placed in free slots first, and otherwise replace the
+// oldest exemplar if they are more recent.
+func (r *Reservoir) Merge(o *Reservoir) {
+	// Copy o before locking r, see Move.
+	o.lock.Lock()
+	offered := o.offered
+	exemplars := append([]aggregation.Exemplar(nil), o.exemplars...)
+	o.lock.Unlock()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	uniform := r.offered > 0 || offered > 0
+	for slot, e := range exemplars {
+		if e.Time.IsZero() {
+			continue
+		}
+		if uniform {
+			slot = r.oldestSlot()
+		}
+		if slot < len(r.exemplars) && !e.Time.After(r.exemplars[slot].Time) {
+			continue
+		}
+		r.store(slot, e)
+	}
+	r.offered += offered
+}
+
+// Reset removes all exemplars from r.
+func (r *Reservoir) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.offered = 0
+	for i := range r.exemplars {
+		r.exemplars[i] = aggregation.Exemplar{}
+	}
+}
+
+// oldestSlot returns the first free slot of a uniformly sampled
+// Reservoir, or the slot of its oldest exemplar if it is full.
+func (r *Reservoir) oldestSlot() int {
+	oldest := 0
+	for i := 0; i < DefaultReservoirSize; i++ {
+		if i >= len(r.exemplars) || r.exemplars[i].Time.IsZero() {
+			return i
+		}
+		if r.exemplars[i].Time.Before(r.exemplars[oldest].Time) {
+			oldest = i
+		}
+	}
+	return oldest
+}
+
+// store sets the exemplar in slot, growing the slots as needed.
+func (r *Reservoir) store(slot int, e aggregation.Exemplar) {
+	if slot >= len(r.exemplars) {
+		r.exemplars = append(r.exemplars, make([]aggregation.Exemplar, slot-len(r.exemplars)+1)...)
+	}
+	r.exemplars[slot] = e
+}
+
+func newExemplar(ctx context.Context, c candidate, num number.Number) aggregation.Exemplar {
+	e := aggregation.Exemplar{
+		Value: num,
+		Time:  time.Now(),
+	}
+	if len(c.filtered) > 0 {
+		// The filtered attributes may refer to the caller's
+		// memory, which is reused after the measurement.
+		e.FilteredAttributes = append([]attribute.KeyValue(nil), c.filtered...)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		e.TraceID = sc.TraceID()
+		e.SpanID = sc.SpanID()
+	}
+	return e
+}
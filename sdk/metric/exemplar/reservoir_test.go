@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID = trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	spanID  = trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+)
+
+func spanContext(flags trace.TraceFlags) context.Context {
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+	}))
+}
+
+func TestFilters(t *testing.T) {
+	sampled := spanContext(trace.FlagsSampled)
+	unsampled := spanContext(0)
+
+	assert.True(t, AlwaysOnFilter(context.Background()))
+	assert.False(t, AlwaysOffFilter(sampled))
+	assert.True(t, TraceBasedFilter(sampled))
+	assert.False(t, TraceBasedFilter(unsampled))
+	assert.False(t, TraceBasedFilter(context.Background()))
+}
+
+func TestOfferRequiresCandidate(t *testing.T) {
+	var r Reservoir
+	r.Offer(spanContext(trace.FlagsSampled), number.NewInt64Number(1))
+	r.OfferAt(context.Background(), 0, number.NewInt64Number(1))
+	assert.Empty(t, r.Exemplars())
+}
+
+func TestOffer(t *testing.T) {
+	var r Reservoir
+	filtered := []attribute.KeyValue{attribute.String("user", "alice")}
+	ctx := NewCandidateContext(spanContext(trace.FlagsSampled), filtered)
+	r.Offer(ctx, number.NewInt64Number(7))
+
+	// The stored attributes do not share the caller's memory.
+	filtered[0] = attribute.String("user", "bob")
+
+	got := r.Exemplars()
+	require.Len(t, got, 1)
+	assert.Equal(t, number.NewInt64Number(7), got[0].Value)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("user", "alice")}, got[0].FilteredAttributes)
+	assert.Equal(t, traceID, got[0].TraceID)
+	assert.Equal(t, spanID, got[0].SpanID)
+	assert.False(t, got[0].Time.IsZero())
+
+	r.Offer(NewCandidateContext(context.Background(), nil), number.NewInt64Number(8))
+	got = r.Exemplars()
+	require.Len(t, got, 2)
+	assert.False(t, got[1].TraceID.IsValid())
+	assert.Nil(t, got[1].FilteredAttributes)
+}
+
+func TestOfferUniformSize(t *testing.T) {
+	var r Reservoir
+	ctx := NewCandidateContext(context.Background(), nil)
+	for i := 0; i < 100; i++ {
+		r.Offer(ctx, number.NewInt64Number(int64(i)))
+	}
+	assert.Len(t, r.Exemplars(), DefaultReservoirSize)
+
+	r.Reset()
+	assert.Empty(t, r.Exemplars())
+}
+
+func TestOfferAt(t *testing.T) {
+	var r Reservoir
+	ctx := NewCandidateContext(context.Background(), nil)
+	r.OfferAt(ctx, 3, number.NewInt64Number(1))
+	r.OfferAt(ctx, 1, number.NewInt64Number(2))
+	r.OfferAt(ctx, 3, number.NewInt64Number(3))
+
+	got := r.Exemplars()
+	require.Len(t, got, 2)
+	assert.Equal(t, number.NewInt64Number(2), got[0].Value)
+	assert.Equal(t, number.NewInt64Number(3), got[1].Value)
+}
+
+func TestMove(t *testing.T) {
+	var r, dest Reservoir
+	ctx := NewCandidateContext(context.Background(), nil)
+	r.Offer(ctx, number.NewInt64Number(1))
+	dest.Offer(ctx, number.NewInt64Number(2))
+
+	r.Move(&dest)
+	assert.Empty(t, r.Exemplars())
+	got := dest.Exemplars()
+	require.Len(t, got, 1)
+	assert.Equal(t, number.NewInt64Number(1), got[0].Value)
+
+	dest.Move(nil)
+	assert.Empty(t, dest.Exemplars())
+}
+
+func exemplarAt(value int64, t time.Time) aggregation.Exemplar {
+	return aggregation.Exemplar{Value: number.NewInt64Number(value), Time: t}
+}
+
+func TestMergeAligned(t *testing.T) {
+	now := time.Now()
+	r := Reservoir{exemplars: []aggregation.Exemplar{exemplarAt(1, now), {}}}
+	o := Reservoir{exemplars: []aggregation.Exemplar{exemplarAt(2, now.Add(-time.Second)), exemplarAt(3, now), exemplarAt(4, now)}}
+
+	r.Merge(&o)
+	assert.Equal(t, []aggregation.Exemplar{
+		exemplarAt(1, now),
+		exemplarAt(3, now),
+		exemplarAt(4, now),
+	}, r.Exemplars())
+}
+
+func TestMergeUniform(t *testing.T) {
+	now := time.Now()
+	r := Reservoir{offered: 4}
+	for i := 0; i < DefaultReservoirSize; i++ {
+		r.store(i, exemplarAt(int64(i), now.Add(time.Duration(i)*time.Second)))
+	}
+	o := Reservoir{offered: 1, exemplars: []aggregation.Exemplar{exemplarAt(10, now.Add(time.Minute))}}
+
+	r.Merge(&o)
+	got := r.Exemplars()
+	require.Len(t, got, DefaultReservoirSize)
+	// The oldest exemplar was replaced.
+	assert.Equal(t, number.NewInt64Number(10), got[0].Value)
+	assert.Equal(t, int64(5), r.offered)
+}
+
+func TestMoveMergeConcurrent(t *testing.T) {
+	var a, b Reservoir
+	ctx := NewCandidateContext(context.Background(), nil)
+	a.Offer(ctx, number.NewInt64Number(1))
+	b.Offer(ctx, number.NewInt64Number(2))
+
+	// Move and Merge run on the same pair in opposite directions must
+	// not deadlock.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			a.Move(&b)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			a.Merge(&b)
+		}
+	}()
+	wg.Wait()
+}
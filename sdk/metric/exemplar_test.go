@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	testTraceID = trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	testSpanID  = trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+)
+
+func spanContext(flags trace.TraceFlags) context.Context {
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    testTraceID,
+		SpanID:     testSpanID,
+		TraceFlags: flags,
+	}))
+}
+
+func collectExemplars(t *testing.T, opts ...metricsdk.Option) func(ctx context.Context) map[string][]aggregation.Exemplar {
+	recorder := &accumulationRecorder{AggregatorSelector: simple.NewWithHistogramDistribution()}
+	accum := metricsdk.NewAccumulator(recorder, opts...)
+	meter := metric.WrapMeterImpl(accum)
+	counter := Must(meter).NewInt64Counter("counter")
+	histogram := Must(meter).NewFloat64Histogram("histogram")
+
+	return func(ctx context.Context) map[string][]aggregation.Exemplar {
+		recorder.accumulations = nil
+		labels := []attribute.KeyValue{attribute.String("A", "a"), attribute.String("B", "b")}
+		counter.Add(ctx, 1, labels...)
+		accum.RecordBatch(ctx, labels, histogram.Measurement(2))
+		accum.Collect(ctx)
+
+		out := map[string][]aggregation.Exemplar{}
+		for _, a := range recorder.accumulations {
+			exemplars, err := a.Aggregator().(aggregation.Exemplars).Exemplars()
+			require.NoError(t, err)
+			out[a.Descriptor().Name()] = exemplars
+		}
+		return out
+	}
+}
+
+func TestExemplarsTraceBased(t *testing.T) {
+	record := collectExemplars(t, metricsdk.WithViews(view.New(view.WithAttributeKeys("A"))))
+
+	got := record(spanContext(trace.FlagsSampled))
+	require.Len(t, got, 2)
+	for name, exemplars := range got {
+		require.Len(t, exemplars, 1, name)
+		require.Equal(t, testTraceID, exemplars[0].TraceID, name)
+		require.Equal(t, testSpanID, exemplars[0].SpanID, name)
+		require.Equal(t, []attribute.KeyValue{attribute.String("B", "b")}, exemplars[0].FilteredAttributes, name)
+	}
+	require.Equal(t, int64(1), got["counter"][0].Value.AsInt64())
+	require.Equal(t, 2.0, got["histogram"][0].Value.AsFloat64())
+
+	got = record(spanContext(0))
+	require.Len(t, got, 2)
+	for name, exemplars := range got {
+		require.Empty(t, exemplars, name)
+	}
+}
+
+func TestExemplarsAlwaysOn(t *testing.T) {
+	record := collectExemplars(t, metricsdk.WithExemplarFilter(exemplar.AlwaysOnFilter))
+	for name, exemplars := range record(context.Background()) {
+		require.Len(t, exemplars, 1, name)
+		require.False(t, exemplars[0].TraceID.IsValid(), name)
+		require.Empty(t, exemplars[0].FilteredAttributes, name)
+	}
+}
+
+func TestExemplarsAlwaysOff(t *testing.T) {
+	record := collectExemplars(t, metricsdk.WithExemplarFilter(exemplar.AlwaysOffFilter))
+	for name, exemplars := range record(spanContext(trace.FlagsSampled)) {
+		require.Empty(t, exemplars, name)
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
 	"go.opentelemetry.io/otel/metric/sdkapi"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 )
 
 type (
@@ -34,26 +36,31 @@ type (
 		state
 	}
 
+	// stateKey identifies an instrument by its name, kinds and
+	// instrumentation library rather than by its descriptor, so
+	// that the data of the identical instruments allocated by
+	// multiple accumulators is combined.
 	stateKey struct {
-		// TODO: This code is organized to support multiple
-		// accumulators which could theoretically produce the
-		// data for the same instrument, and this code has
-		// logic to combine data properly from multiple
-		// accumulators.  However, the use of
-		// *sdkapi.Descriptor in the stateKey makes such
-		// combination impossible, because each accumulator
-		// allocates its own instruments.  This can be fixed
-		// by using the instrument name and kind instead of
-		// the descriptor pointer.  See
-		// https://github.com/open-telemetry/opentelemetry-go/issues/862.
-		descriptor *sdkapi.Descriptor
-		distinct   attribute.Distinct
+		name           string
+		instrumentKind sdkapi.InstrumentKind
+		numberKind     number.Kind
+		library        instrumentation.Library
+		distinct       attribute.Distinct
 	}
 
 	stateValue struct {
+		// descriptor is the descriptor of the first
+		// Accumulation processed for the stateKey, which its
+		// data is exported with.
+		descriptor *sdkapi.Descriptor
+
 		// labels corresponds to the stateKey.distinct field.
 		labels *attribute.Set
 
+		// start is the start time of the cumulative
+		// aggregation.
+		start time.Time
+
 		// updated indicates the last sequence number when this value had
 		// Process() called by an accumulator.
 		updated int64
@@ -89,6 +96,11 @@ type (
 		sync.RWMutex
 		values map[stateKey]*stateValue
 
+		// stale holds the values removed by the last
+		// collection after Config.IdleSeriesTTL, exported as
+		// staleness markers.
+		stale []*stateValue
+
 		processStart  time.Time
 		intervalStart time.Time
 		intervalEnd   time.Time
@@ -165,21 +177,38 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	}
 	desc := accum.Descriptor()
 	key := stateKey{
-		descriptor: desc,
-		distinct:   accum.Labels().Equivalent(),
+		name:           desc.Name(),
+		instrumentKind: desc.InstrumentKind(),
+		numberKind:     desc.NumberKind(),
+		library:        accum.Library(),
+		distinct:       accum.Labels().Equivalent(),
 	}
 	agg := accum.Aggregator()
 
+	// Allocate aggregators with the selector chosen by a View, if any.
+	selector := accum.AggregatorSelector()
+	if selector == nil {
+		selector = b.AggregatorSelector
+	}
+
 	// Check if there is an existing value.
 	value, ok := b.state.values[key]
 	if !ok {
 		stateful := b.TemporalityFor(desc, agg.Aggregation().Kind()).MemoryRequired(desc.InstrumentKind())
 
 		newValue := &stateValue{
-			labels:   accum.Labels(),
-			updated:  b.state.finishedCollection,
-			stateful: stateful,
-			current:  agg,
+			descriptor: desc,
+			labels:     accum.Labels(),
+			start:      b.processStart,
+			updated:    b.state.finishedCollection,
+			stateful:   stateful,
+			current:    agg,
+		}
+		if b.config.IdleSeriesTTL > 0 {
+			// The label set may have been removed
+			// before, restarting its cumulative
+			// aggregation.
+			newValue.start = b.intervalStart
 		}
 		if stateful {
 			if desc.InstrumentKind().PrecomputedSum() {
@@ -193,7 +222,7 @@ func (b *Processor) Process(accum export.Accumulation) error {
 			}
 			// In this case allocate one aggregator to
 			// save the current state.
-			b.AggregatorFor(desc, &newValue.cumulative)
+			selector.AggregatorFor(desc, &newValue.cumulative)
 		}
 		b.state.values[key] = newValue
 		return nil
@@ -249,7 +278,7 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	// before merging below.
 	if !value.currentOwned {
 		tmp := value.current
-		b.AggregatorSelector.AggregatorFor(desc, &value.current)
+		selector.AggregatorFor(desc, &value.current)
 		value.currentOwned = true
 		if err := tmp.SynchronizedMove(value.current, desc); err != nil {
 			return err
@@ -287,11 +316,22 @@ func (b *Processor) FinishCollection() error {
 	}
 	defer func() { b.finishedCollection++ }()
 
+	b.stale = b.stale[:0]
 	for key, value := range b.values {
-		mkind := key.descriptor.InstrumentKind()
+		mkind := value.descriptor.InstrumentKind()
 		stale := value.updated != b.finishedCollection
 		stateless := !value.stateful
 
+		// Remove the values not updated during the last
+		// IdleSeriesTTL collections.
+		if ttl := int64(b.config.IdleSeriesTTL); ttl > 0 && b.finishedCollection-value.updated >= ttl {
+			if b.config.StalenessMarkers {
+				b.stale = append(b.stale, value)
+			}
+			delete(b.values, key)
+			continue
+		}
+
 		// The following branch updates stateful aggregators.  Skip
 		// these updates if the aggregator is not stateful or if the
 		// aggregator is stale.
@@ -312,7 +352,7 @@ func (b *Processor) FinishCollection() error {
 		if !mkind.PrecomputedSum() {
 			// This line is equivalent to:
 			// value.cumulative = value.cumulative + value.current
-			if err := value.cumulative.Merge(value.current, key.descriptor); err != nil {
+			if err := value.cumulative.Merge(value.current, value.descriptor); err != nil {
 				return err
 			}
 		}
@@ -327,13 +367,13 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 	if b.startedCollection != b.finishedCollection {
 		return ErrInconsistentState
 	}
-	for key, value := range b.values {
-		mkind := key.descriptor.InstrumentKind()
+	for _, value := range b.values {
+		mkind := value.descriptor.InstrumentKind()
 
 		var agg aggregation.Aggregation
 		var start time.Time
 
-		aggTemp := exporter.TemporalityFor(key.descriptor, value.current.Aggregation().Kind())
+		aggTemp := exporter.TemporalityFor(value.descriptor, value.current.Aggregation().Kind())
 
 		switch aggTemp {
 		case aggregation.CumulativeTemporality:
@@ -345,7 +385,7 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 			} else {
 				agg = value.current.Aggregation()
 			}
-			start = b.processStart
+			start = value.start
 
 		case aggregation.DeltaTemporality:
 			// Precomputed sums are a special case.
@@ -368,7 +408,7 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 		}
 
 		if err := f(export.NewRecord(
-			key.descriptor,
+			value.descriptor,
 			value.labels,
 			agg,
 			start,
@@ -377,5 +417,29 @@ func (b *state) ForEach(exporter aggregation.TemporalitySelector, f func(export.
 			return err
 		}
 	}
+	return b.forEachStale(exporter, f)
+}
+
+// forEachStale passes the staleness markers of the values removed by
+// the last collection to f, for the cumulative exports.
+func (b *state) forEachStale(exporter aggregation.TemporalitySelector, f func(export.Record) error) error {
+	for _, value := range b.stale {
+		if exporter.TemporalityFor(value.descriptor, value.current.Aggregation().Kind()) != aggregation.CumulativeTemporality {
+			continue
+		}
+		agg := value.current.Aggregation()
+		if value.stateful {
+			agg = value.cumulative.Aggregation()
+		}
+		if err := f(export.NewStaleRecord(
+			value.descriptor,
+			value.labels,
+			agg,
+			value.start,
+			b.intervalEnd,
+		)); err != nil && !errors.Is(err, aggregation.ErrNoData) {
+			return err
+		}
+	}
 	return nil
 }
@@ -510,3 +510,145 @@ func TestCounterObserverEndToEnd(t *testing.T) {
 	requireNotAfter(t, endTime[0], endTime[1])
 	requireNotAfter(t, endTime[1], endTime[2])
 }
+
+func TestMultiAccumulator(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		aggregation.TemporalitySelector
+		expect [2]map[string]int64
+	}{
+		{
+			name:                "cumulative",
+			TemporalitySelector: aggregation.CumulativeTemporalitySelector(),
+			expect: [2]map[string]int64{
+				{"a/counter.sum": 3, "b/counter.sum": 10},
+				{"a/counter.sum": 6, "b/counter.sum": 20},
+			},
+		},
+		{
+			name:                "delta",
+			TemporalitySelector: aggregation.DeltaTemporalitySelector(),
+			expect: [2]map[string]int64{
+				{"a/counter.sum": 3, "b/counter.sum": 10},
+				{"a/counter.sum": 3, "b/counter.sum": 10},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			proc := basic.New(processorTest.AggregatorSelector(), test.TemporalitySelector)
+
+			// The instruments of the first two Accumulators are
+			// identical, the third one belongs to another
+			// library.  The description of the instruments
+			// tells the libraries apart in the exported records.
+			var accums []*sdk.Accumulator
+			var counters []metric.Int64Counter
+			for _, library := range []string{"a", "a", "b"} {
+				accum := sdk.NewAccumulator(proc, sdk.WithInstrumentationLibrary(instrumentation.Library{Name: library}))
+				meter := metric.WrapMeterImpl(accum)
+				accums = append(accums, accum)
+				counters = append(counters, metric.Must(meter).NewInt64Counter("counter.sum", metric.WithDescription(library)))
+			}
+
+			for _, expect := range test.expect {
+				counters[0].Add(ctx, 1)
+				counters[1].Add(ctx, 2)
+				counters[2].Add(ctx, 10)
+
+				proc.StartCollection()
+				for _, accum := range accums {
+					accum.Collect(ctx)
+				}
+				require.NoError(t, proc.FinishCollection())
+
+				sums := map[string]int64{}
+				require.NoError(t, proc.Reader().ForEach(test.TemporalitySelector, func(r export.Record) error {
+					key := r.Descriptor().Description() + "/" + r.Descriptor().Name()
+					require.NotContains(t, sums, key)
+					sum, err := r.Aggregation().(aggregation.Sum).Sum()
+					require.NoError(t, err)
+					sums[key] = sum.AsInt64()
+					return nil
+				}))
+				require.Equal(t, expect, sums)
+			}
+		})
+	}
+}
+
+func TestIdleSeriesTTL(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		aggregation.TemporalitySelector
+		expect [4]map[string]int64
+	}{
+		{
+			name:                "cumulative",
+			TemporalitySelector: aggregation.CumulativeTemporalitySelector(),
+			expect: [4]map[string]int64{
+				{"counter.sum/A=a/": 1, "counter.sum/A=b/": 2},
+				{"counter.sum/A=a/": 2},
+				{"counter.sum/A=a/": 3, "stale/counter.sum/A=b/": 2},
+				{"counter.sum/A=b/": 5},
+			},
+		},
+		{
+			name:                "delta",
+			TemporalitySelector: aggregation.DeltaTemporalitySelector(),
+			expect: [4]map[string]int64{
+				{"counter.sum/A=a/": 1, "counter.sum/A=b/": 2},
+				{"counter.sum/A=a/": 1},
+				{"counter.sum/A=a/": 1},
+				{"counter.sum/A=b/": 5},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			desc := metrictest.NewDescriptor("counter.sum", sdkapi.CounterInstrumentKind, number.Int64Kind)
+			selector := processorTest.AggregatorSelector()
+			processor := basic.New(selector, test.TemporalitySelector,
+				basic.WithIdleSeriesTTL(2),
+				basic.WithStalenessMarkers(true),
+			)
+
+			updates := [4][]export.Accumulation{
+				{
+					updateFor(t, &desc, selector, 1, attribute.String("A", "a")),
+					updateFor(t, &desc, selector, 2, attribute.String("A", "b")),
+				},
+				{updateFor(t, &desc, selector, 1, attribute.String("A", "a"))},
+				{updateFor(t, &desc, selector, 1, attribute.String("A", "a"))},
+				// The series of A=b restarts after its removal.
+				{updateFor(t, &desc, selector, 5, attribute.String("A", "b"))},
+			}
+			var firstEnd time.Time
+			for i, expect := range test.expect {
+				processor.StartCollection()
+				for _, accum := range updates[i] {
+					require.NoError(t, processor.Process(accum))
+				}
+				require.NoError(t, processor.FinishCollection())
+
+				values := map[string]int64{}
+				require.NoError(t, processor.Reader().ForEach(test.TemporalitySelector, func(r export.Record) error {
+					key := fmt.Sprint(r.Descriptor().Name(), "/", r.Labels().Encoded(attribute.DefaultEncoder()), "/")
+					if r.Stale() {
+						key = "stale/" + key
+					}
+					sum, err := r.Aggregation().(aggregation.Sum).Sum()
+					require.NoError(t, err)
+					values[key] = sum.AsInt64()
+
+					if i == 0 {
+						firstEnd = r.EndTime()
+					} else if i == 3 && test.name == "cumulative" {
+						requireNotAfter(t, firstEnd, r.StartTime())
+					}
+					return nil
+				}))
+				require.Equal(t, expect, values, "collection %d", i)
+			}
+		})
+	}
+}
@@ -21,6 +21,17 @@ type config struct {
 	// When Memory is true, Reader.ForEach() will visit
 	// metrics that were not updated in the most recent interval.
 	Memory bool
+
+	// IdleSeriesTTL is the number of collections without
+	// updates after which the state of a label set is removed,
+	// ending its export.  If 0, the state of stateful and
+	// remembered label sets is kept forever.
+	IdleSeriesTTL int
+
+	// StalenessMarkers controls whether the label sets removed
+	// after IdleSeriesTTL are exported once more, in a stale
+	// Record marking the end of their series.
+	StalenessMarkers bool
 }
 
 type Option interface {
@@ -40,3 +51,36 @@ type memoryOption bool
 func (m memoryOption) applyProcessor(cfg *config) {
 	cfg.Memory = bool(m)
 }
+
+// WithIdleSeriesTTL removes the state of the label sets not updated
+// during the last intervals collections, which are then no longer
+// exported, until updated again.  This bounds the memory used for
+// cumulative temporality or with WithMemory when label values churn.
+// As a removed label set restarts its cumulative aggregation when
+// updated again, the cumulative aggregations of the label sets start
+// with the collection they were first updated in, rather than when the
+// Processor was created.  The default of 0 never removes them.
+func WithIdleSeriesTTL(intervals int) Option {
+	return idleSeriesTTLOption(intervals)
+}
+
+type idleSeriesTTLOption int
+
+func (o idleSeriesTTLOption) applyProcessor(cfg *config) {
+	cfg.IdleSeriesTTL = int(o)
+}
+
+// WithStalenessMarkers sets whether the label sets removed by
+// WithIdleSeriesTTL are exported one last time after their removal,
+// in a Record whose Stale method returns true.  Staleness markers are
+// only exported with cumulative temporality, as the absence of delta
+// data already means no update.
+func WithStalenessMarkers(markers bool) Option {
+	return stalenessMarkersOption(markers)
+}
+
+type stalenessMarkersOption bool
+
+func (o stalenessMarkersOption) applyProcessor(cfg *config) {
+	cfg.StalenessMarkers = bool(o)
+}
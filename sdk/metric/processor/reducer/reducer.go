@@ -61,6 +61,8 @@ func (p *Processor) Process(accum export.Accumulation) error {
 			accum.Descriptor(),
 			&reduced,
 			accum.Aggregator(),
+			export.WithAggregatorSelector(accum.AggregatorSelector()),
+			export.WithInstrumentationLibrary(accum.Library()),
 		),
 	)
 }
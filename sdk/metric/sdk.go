@@ -27,7 +27,10 @@ import (
 	"go.opentelemetry.io/otel/metric/number"
 	"go.opentelemetry.io/otel/metric/sdkapi"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/view"
 )
 
 type (
@@ -56,6 +59,19 @@ type (
 		// processor is the configured processor+configuration.
 		processor export.Processor
 
+		// library and views configure the exported data of
+		// each instrument, see the view package.
+		library instrumentation.Library
+		views   []view.View
+
+		// exemplarFilter decides which synchronous
+		// measurements are exemplar candidates.
+		exemplarFilter exemplar.Filter
+
+		// cardinalityLimit is the maximum number of label sets
+		// of the instruments not limited by a View, if positive.
+		cardinalityLimit int
+
 		// collectLock prevents simultaneous calls to Collect().
 		collectLock sync.Mutex
 
@@ -66,6 +82,12 @@ type (
 	}
 
 	syncInstrument struct {
+		// series is the number of records of the instrument
+		// mapped in Accumulator.current, not counting its
+		// overflow record.  It is the first field for 64-bit
+		// alignment.
+		series int64
+
 		instrument
 	}
 
@@ -111,6 +133,10 @@ type (
 		// inst is a pointer to the corresponding instrument.
 		inst *syncInstrument
 
+		// counted is true if the record is included in the
+		// series count of inst.
+		counted bool
+
 		// current implements the actual RecordOne() API,
 		// depending on the type of aggregation.  If nil, the
 		// metric was disabled by the exporter.
@@ -121,6 +147,33 @@ type (
 	instrument struct {
 		meter      *Accumulator
 		descriptor sdkapi.Descriptor
+
+		// exported is the descriptor the instrument's data is
+		// exported with, when a View renames or describes the
+		// instrument.  If nil, descriptor is used.
+		exported *sdkapi.Descriptor
+
+		// filter removes the labels not kept by a View.  If
+		// nil, all labels are kept.
+		filter attribute.Filter
+
+		// selector chooses the aggregators of the instrument
+		// when configured by a View.  If nil, the processor
+		// chooses them.
+		selector export.AggregatorSelector
+
+		// dropped is true when a View drops the instrument.
+		dropped bool
+
+		// limit is the maximum number of label sets recorded
+		// by the instrument, beyond which measurements are
+		// recorded with the overflow label set.  If 0, the
+		// number of label sets is not limited.
+		limit int
+
+		// overflowed is set to 1 by the first measurement
+		// beyond limit, which is reported to the error handler.
+		overflowed uint32
 	}
 
 	asyncInstrument struct {
@@ -144,12 +197,63 @@ var (
 
 	// ErrUninitializedInstrument is returned when an instrument is used when uninitialized.
 	ErrUninitializedInstrument = fmt.Errorf("use of an uninitialized instrument")
+
+	// ErrCardinalityLimit is reported when an instrument first
+	// records more label sets than its cardinality limit.
+	ErrCardinalityLimit = fmt.Errorf("cardinality limit exceeded")
+
+	// OverflowAttribute is the label of the series recording the
+	// measurements of an instrument beyond its cardinality limit.
+	OverflowAttribute = attribute.Bool("otel.metric.overflow", true)
+
+	overflowLabels = attribute.NewSet(OverflowAttribute)
 )
 
 func (inst *instrument) Descriptor() sdkapi.Descriptor {
 	return inst.descriptor
 }
 
+// exportDescriptor returns the descriptor the instrument's data is
+// exported with.
+func (inst *instrument) exportDescriptor() *sdkapi.Descriptor {
+	if inst.exported != nil {
+		return inst.exported
+	}
+	return &inst.descriptor
+}
+
+// aggregatorFor allocates the aggregators of the instrument.  They
+// are left nil, disabling the instrument, if it is dropped by a View.
+func (inst *instrument) aggregatorFor(aggs ...*export.Aggregator) {
+	switch {
+	case inst.dropped:
+	case inst.selector != nil:
+		inst.selector.AggregatorFor(inst.exportDescriptor(), aggs...)
+	default:
+		inst.meter.processor.AggregatorFor(inst.exportDescriptor(), aggs...)
+	}
+}
+
+// overflow reports the first measurement of the instrument beyond its
+// cardinality limit.
+func (inst *instrument) overflow() {
+	if atomic.CompareAndSwapUint32(&inst.overflowed, 0, 1) {
+		otel.Handle(fmt.Errorf("%w: instrument %q is limited to %d label sets, recording further measurements with %s",
+			ErrCardinalityLimit, inst.descriptor.Name(), inst.limit, OverflowAttribute.Key))
+	}
+}
+
+// accumulation returns the Accumulation of agg for labels.
+func (inst *instrument) accumulation(labels *attribute.Set, agg export.Aggregator) export.Accumulation {
+	return export.NewAccumulation(
+		inst.exportDescriptor(),
+		labels,
+		agg,
+		export.WithAggregatorSelector(inst.selector),
+		export.WithInstrumentationLibrary(inst.meter.library),
+	)
+}
+
 func (a *asyncInstrument) Implementation() interface{} {
 	return a
 }
@@ -159,33 +263,51 @@ func (s *syncInstrument) Implementation() interface{} {
 }
 
 func (a *asyncInstrument) observe(num number.Number, labels *attribute.Set) {
+	if a.dropped {
+		return
+	}
 	if err := aggregator.RangeTest(num, &a.descriptor); err != nil {
 		otel.Handle(err)
 		return
 	}
+	if a.filter != nil {
+		filtered, _ := labels.Filter(a.filter)
+		labels = &filtered
+	}
 	recorder := a.getRecorder(labels)
 	if recorder == nil {
 		// The instrument is disabled according to the
 		// AggregatorSelector.
 		return
 	}
-	if err := recorder.Update(context.Background(), num, &a.descriptor); err != nil {
+	if err := recorder.Update(context.Background(), num, a.exportDescriptor()); err != nil {
 		otel.Handle(err)
 		return
 	}
 }
 
 func (a *asyncInstrument) getRecorder(labels *attribute.Set) export.Aggregator {
+	overflow := labels.Equivalent() == overflowLabels.Equivalent()
 	lrec, ok := a.recorders[labels.Equivalent()]
 	if ok {
-		// Note: SynchronizedMove(nil) can't return an error
-		_ = lrec.observed.SynchronizedMove(nil, &a.descriptor)
+		// When a View filters labels, or the instrument
+		// overflows, several observations in the same
+		// collection may share the label set; these are
+		// combined instead of reset.
+		if (a.filter == nil && !overflow) || lrec.observedEpoch != a.meter.currentEpoch {
+			// Note: SynchronizedMove(nil) can't return an error
+			_ = lrec.observed.SynchronizedMove(nil, a.exportDescriptor())
+		}
 		lrec.observedEpoch = a.meter.currentEpoch
 		a.recorders[labels.Equivalent()] = lrec
 		return lrec.observed
 	}
+	if a.limit > 0 && !overflow && a.series() >= a.limit {
+		a.overflow()
+		return a.getRecorder(&overflowLabels)
+	}
 	var rec export.Aggregator
-	a.meter.processor.AggregatorFor(&a.descriptor, &rec)
+	a.aggregatorFor(&rec)
 	if a.recorders == nil {
 		a.recorders = make(map[attribute.Distinct]*labeledRecorder)
 	}
@@ -200,27 +322,59 @@ func (a *asyncInstrument) getRecorder(labels *attribute.Set) export.Aggregator {
 	return rec
 }
 
+// series returns the number of label sets recorded by the instrument,
+// not counting the overflow label set.
+func (a *asyncInstrument) series() int {
+	n := len(a.recorders)
+	if _, ok := a.recorders[overflowLabels.Equivalent()]; ok {
+		n--
+	}
+	return n
+}
+
 // acquireHandle gets or creates a `*record` corresponding to `kvs`,
 // the input labels.  The second argument `labels` is passed in to
 // support re-use of the orderedLabels computed by a previous
 // measurement in the same batch.   This performs two allocations
-// in the common case.
-func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attribute.Set) *record {
+// in the common case.  The labels removed by a View are returned
+// along with the record.
+func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attribute.Set) (*record, []attribute.KeyValue) {
 	var rec *record
 	var equiv attribute.Distinct
+	var filtered []attribute.KeyValue
 
-	if labelPtr == nil {
+	switch {
+	case labelPtr == nil:
 		// This memory allocation may not be used, but it's
 		// needed for the `sortSlice` field, to avoid an
 		// allocation while sorting.
 		rec = &record{}
-		rec.storage = attribute.NewSetWithSortable(kvs, &rec.sortSlice)
+		if s.filter != nil {
+			rec.storage, filtered = attribute.NewSetWithSortableFiltered(kvs, &rec.sortSlice, s.filter)
+		} else {
+			rec.storage = attribute.NewSetWithSortable(kvs, &rec.sortSlice)
+		}
+		rec.labels = &rec.storage
+		equiv = rec.storage.Equivalent()
+	case s.filter != nil:
+		// The labels shared by a batch are filtered for this
+		// instrument.
+		rec = &record{}
+		rec.storage, filtered = labelPtr.Filter(s.filter)
 		rec.labels = &rec.storage
 		equiv = rec.storage.Equivalent()
-	} else {
+	default:
 		equiv = labelPtr.Equivalent()
 	}
 
+	return s.acquireRecord(equiv, rec, labelPtr, false), filtered
+}
+
+// acquireRecord gets the mapped `*record` for the label set `equiv`,
+// or maps `rec`, allocated with the labels when not shared, for it.
+// Once the instrument records as many label sets as its cardinality
+// limit, the record of the overflow label set is returned instead.
+func (s *syncInstrument) acquireRecord(equiv attribute.Distinct, rec *record, labelPtr *attribute.Set, overflow bool) *record {
 	// Create lookup key for sync.Map (one allocation, as this
 	// passes through an interface{})
 	mk := mapkey{
@@ -239,14 +393,24 @@ func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attri
 		// This entry is no longer mapped, try to add a new entry.
 	}
 
+	// Reserve a series of the instrument for the new entry,
+	// recording with the overflow label set beyond the limit.
+	counted := s.limit > 0 && !overflow
+	if counted && atomic.AddInt64(&s.series, 1) > int64(s.limit) {
+		atomic.AddInt64(&s.series, -1)
+		s.overflow()
+		return s.acquireRecord(overflowLabels.Equivalent(), nil, &overflowLabels, true)
+	}
+
 	if rec == nil {
 		rec = &record{}
 		rec.labels = labelPtr
 	}
 	rec.refMapped = refcountMapped{value: 2}
 	rec.inst = s
+	rec.counted = counted
 
-	s.meter.processor.AggregatorFor(&s.descriptor, &rec.current, &rec.checkpoint)
+	s.aggregatorFor(&rec.current, &rec.checkpoint)
 
 	for {
 		// Load/Store: there's a memory allocation to place `mk` into
@@ -258,6 +422,9 @@ func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attri
 			if oldRec.refMapped.ref() {
 				// At this moment it is guaranteed that the entry is in
 				// the map and will not be removed.
+				if counted {
+					atomic.AddInt64(&s.series, -1)
+				}
 				return oldRec
 			}
 			// This loaded entry is marked as unmapped (so Collect will remove
@@ -280,9 +447,12 @@ func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attri
 
 // The order of the input array `kvs` may be sorted after the function is called.
 func (s *syncInstrument) RecordOne(ctx context.Context, num number.Number, kvs []attribute.KeyValue) {
-	h := s.acquireHandle(kvs, nil)
+	if s.dropped {
+		return
+	}
+	h, filtered := s.acquireHandle(kvs, nil)
 	defer h.unbind()
-	h.RecordOne(ctx, num)
+	h.RecordOne(s.meter.exemplarContext(ctx, filtered), num)
 }
 
 // NewAccumulator constructs a new Accumulator for the given
@@ -294,30 +464,70 @@ func (s *syncInstrument) RecordOne(ctx context.Context, num number.Number, kvs [
 // processor will call Collect() when it receives a request to scrape
 // current metric values.  A push-based processor should configure its
 // own periodic collection.
-func NewAccumulator(processor export.Processor) *Accumulator {
+//
+// Views configured with WithViews change the data exported for the
+// instruments they match.  WithCardinalityLimit bounds the number of
+// label sets recorded by each instrument.
+func NewAccumulator(processor export.Processor, opts ...Option) *Accumulator {
+	var cfg config
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.ExemplarFilter == nil {
+		cfg.ExemplarFilter = exemplar.TraceBasedFilter
+	}
 	return &Accumulator{
 		processor:        processor,
 		asyncInstruments: internal.NewAsyncInstrumentState(),
+		library:          cfg.Library,
+		views:            cfg.Views,
+		exemplarFilter:   cfg.ExemplarFilter,
+		cardinalityLimit: cfg.CardinalityLimit,
+	}
+}
+
+// exemplarContext returns the context passed to the aggregators of a
+// synchronous measurement recorded with ctx, marking the measurement as
+// an exemplar candidate if the exemplar filter accepts it.
+func (m *Accumulator) exemplarContext(ctx context.Context, filtered []attribute.KeyValue) context.Context {
+	if !m.exemplarFilter(ctx) {
+		return ctx
 	}
+	return exemplar.NewCandidateContext(ctx, filtered)
+}
+
+// newInstrument returns the instrument for descriptor, configured by
+// the first View that matches it.
+func (m *Accumulator) newInstrument(descriptor sdkapi.Descriptor) instrument {
+	inst := instrument{
+		descriptor: descriptor,
+		meter:      m,
+		limit:      m.cardinalityLimit,
+	}
+	if v, ok := view.Find(m.views, m.library, descriptor); ok {
+		exported := v.Descriptor(descriptor)
+		inst.exported = &exported
+		inst.filter = v.AttributeFilter()
+		inst.selector = v.AggregatorSelector()
+		inst.dropped = v.Drop()
+		if limit := v.CardinalityLimit(); limit > 0 {
+			inst.limit = limit
+		}
+	}
+	return inst
 }
 
 // NewSyncInstrument implements sdkapi.MetricImpl.
 func (m *Accumulator) NewSyncInstrument(descriptor sdkapi.Descriptor) (sdkapi.SyncImpl, error) {
 	return &syncInstrument{
-		instrument: instrument{
-			descriptor: descriptor,
-			meter:      m,
-		},
+		instrument: m.newInstrument(descriptor),
 	}, nil
 }
 
 // NewAsyncInstrument implements sdkapi.MetricImpl.
 func (m *Accumulator) NewAsyncInstrument(descriptor sdkapi.Descriptor, runner sdkapi.AsyncRunner) (sdkapi.AsyncImpl, error) {
 	a := &asyncInstrument{
-		instrument: instrument{
-			descriptor: descriptor,
-			meter:      m,
-		},
+		instrument: m.newInstrument(descriptor),
 	}
 	m.asyncLock.Lock()
 	defer m.asyncLock.Unlock()
@@ -373,6 +583,9 @@ func (m *Accumulator) collectSyncInstruments() int {
 		// entry in the map, they are busy calling Gosched() awaiting
 		// this deletion:
 		m.current.Delete(inuse.mapkey())
+		if inuse.counted {
+			atomic.AddInt64(&inuse.inst.series, -1)
+		}
 
 		// There's a potential race between `LoadInt64` and
 		// `tryUnmap` in this function.  Since this is the
@@ -420,13 +633,13 @@ func (m *Accumulator) checkpointRecord(r *record) int {
 	if r.current == nil {
 		return 0
 	}
-	err := r.current.SynchronizedMove(r.checkpoint, &r.inst.descriptor)
+	err := r.current.SynchronizedMove(r.checkpoint, r.inst.exportDescriptor())
 	if err != nil {
 		otel.Handle(err)
 		return 0
 	}
 
-	a := export.NewAccumulation(&r.inst.descriptor, r.labels, r.checkpoint)
+	a := r.inst.accumulation(r.labels, r.checkpoint)
 	err = m.processor.Process(a)
 	if err != nil {
 		otel.Handle(err)
@@ -444,8 +657,7 @@ func (m *Accumulator) checkpointAsync(a *asyncInstrument) int {
 		epochDiff := m.currentEpoch - lrec.observedEpoch
 		if epochDiff == 0 {
 			if lrec.observed != nil {
-				a := export.NewAccumulation(&a.descriptor, lrec.labels, lrec.observed)
-				err := m.processor.Process(a)
+				err := m.processor.Process(a.accumulation(lrec.labels, lrec.observed))
 				if err != nil {
 					otel.Handle(err)
 				}
@@ -472,20 +684,27 @@ func (m *Accumulator) RecordBatch(ctx context.Context, kvs []attribute.KeyValue,
 	// previously computed value instead of recomputing the
 	// ordered labels.
 	var labelsPtr *attribute.Set
-	for i, meas := range measurements {
+	for _, meas := range measurements {
 		s := m.fromSync(meas.SyncImpl())
-		if s == nil {
+		if s == nil || s.dropped {
 			continue
 		}
-		h := s.acquireHandle(kvs, labelsPtr)
+		if labelsPtr == nil && s.filter != nil {
+			// Instruments filtered by a View derive their
+			// labels from the complete label set.
+			labels := attribute.NewSet(kvs...)
+			labelsPtr = &labels
+		}
+		h, filtered := s.acquireHandle(kvs, labelsPtr)
 
-		// Re-use labels for the next measurement.
-		if i == 0 {
+		// Re-use labels for the next measurement, unless
+		// replaced by the overflow label set.
+		if labelsPtr == nil && h.labels != &overflowLabels {
 			labelsPtr = h.labels
 		}
 
 		defer h.unbind()
-		h.RecordOne(ctx, meas.Number())
+		h.RecordOne(m.exemplarContext(ctx, filtered), meas.Number())
 	}
 }
 
@@ -499,7 +718,7 @@ func (r *record) RecordOne(ctx context.Context, num number.Number) {
 		otel.Handle(err)
 		return
 	}
-	if err := r.current.Update(ctx, num, &r.inst.descriptor); err != nil {
+	if err := r.current.Update(ctx, num, r.inst.exportDescriptor()); err != nil {
 		otel.Handle(err)
 		return
 	}
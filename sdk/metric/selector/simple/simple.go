@@ -17,6 +17,7 @@ package simple // import "go.opentelemetry.io/otel/sdk/metric/selector/simple"
 import (
 	"go.opentelemetry.io/otel/metric/sdkapi"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/minmaxsumcount"
@@ -28,11 +29,15 @@ type (
 	selectorHistogram   struct {
 		options []histogram.Option
 	}
+	selectorExponential struct {
+		options []exponential.Option
+	}
 )
 
 var (
 	_ export.AggregatorSelector = selectorInexpensive{}
 	_ export.AggregatorSelector = selectorHistogram{}
+	_ export.AggregatorSelector = selectorExponential{}
 )
 
 // NewWithInexpensiveDistribution returns a simple aggregator selector
@@ -51,6 +56,14 @@ func NewWithHistogramDistribution(options ...histogram.Option) export.Aggregator
 	return selectorHistogram{options: options}
 }
 
+// NewWithExponentialHistogramDistribution returns a simple aggregator
+// selector that uses exponential histogram aggregators for `Histogram`
+// instruments.  Unlike explicit-boundary histograms, these adapt their
+// bucket resolution to the range of the recorded values.
+func NewWithExponentialHistogramDistribution(options ...exponential.Option) export.AggregatorSelector {
+	return selectorExponential{options: options}
+}
+
 func sumAggs(aggPtrs []*export.Aggregator) {
 	aggs := sum.New(len(aggPtrs))
 	for i := range aggPtrs {
@@ -92,3 +105,17 @@ func (s selectorHistogram) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs
 		sumAggs(aggPtrs)
 	}
 }
+
+func (s selectorExponential) AggregatorFor(descriptor *sdkapi.Descriptor, aggPtrs ...*export.Aggregator) {
+	switch descriptor.InstrumentKind() {
+	case sdkapi.GaugeObserverInstrumentKind:
+		lastValueAggs(aggPtrs)
+	case sdkapi.HistogramInstrumentKind:
+		aggs := exponential.New(len(aggPtrs), descriptor, s.options...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	default:
+		sumAggs(aggPtrs)
+	}
+}
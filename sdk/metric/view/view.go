@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package view provides Views, a mechanism to customize the metric data
+// produced by an Accumulator for the instruments it creates.
+//
+// A View selects instruments by name pattern, instrument kind, or
+// instrumentation library, and describes how the matched instruments are
+// exported: under a different name or description, with a restricted set
+// of attribute keys, using a different aggregation, with a limited number
+// of attribute sets, or not at all.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package view // import "go.opentelemetry.io/otel/sdk/metric/view"
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// View describes how the data of a selected set of instruments is
+// exported. The zero value matches every instrument and does not modify
+// its data.
+type View struct {
+	// Instrument selection criteria.
+	namePattern *regexp.Regexp
+	kind        *sdkapi.InstrumentKind
+	library     *instrumentation.Library
+
+	// Stream configuration.
+	name        string
+	description string
+	keys        map[attribute.Key]struct{}
+	selector    export.AggregatorSelector
+	drop        bool
+	limit       int
+}
+
+// Option applies a configuration option to a View.
+type Option interface {
+	apply(*View)
+}
+
+type optionFunc func(*View)
+
+func (fn optionFunc) apply(v *View) {
+	fn(v)
+}
+
+// New returns a View configured with opts. A View created without any
+// Match option applies to all instruments.
+func New(opts ...Option) View {
+	var v View
+	for _, opt := range opts {
+		opt.apply(&v)
+	}
+	return v
+}
+
+// MatchInstrumentName selects instruments whose name matches pattern. The
+// pattern must match the whole name; "*" matches any sequence of
+// characters and "?" matches exactly one character.
+func MatchInstrumentName(pattern string) Option {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	re := regexp.MustCompile("^" + expr + "$")
+	return optionFunc(func(v *View) {
+		v.namePattern = re
+	})
+}
+
+// MatchInstrumentKind selects instruments of kind.
+func MatchInstrumentKind(kind sdkapi.InstrumentKind) Option {
+	return optionFunc(func(v *View) {
+		v.kind = &kind
+	})
+}
+
+// MatchInstrumentationLibrary selects instruments created by the Meter of
+// lib. The library name must be equal, its version and schema URL are only
+// compared when they are not empty.
+func MatchInstrumentationLibrary(lib instrumentation.Library) Option {
+	return optionFunc(func(v *View) {
+		v.library = &lib
+	})
+}
+
+// WithName exports the matched instruments under name.
+func WithName(name string) Option {
+	return optionFunc(func(v *View) {
+		v.name = name
+	})
+}
+
+// WithDescription exports the matched instruments with description.
+func WithDescription(description string) Option {
+	return optionFunc(func(v *View) {
+		v.description = description
+	})
+}
+
+// WithAttributeKeys restricts the attributes exported for the matched
+// instruments to keys. All other attributes are removed before
+// aggregation, and measurements that become identical are combined.
+func WithAttributeKeys(keys ...attribute.Key) Option {
+	return optionFunc(func(v *View) {
+		v.keys = make(map[attribute.Key]struct{}, len(keys))
+		for _, k := range keys {
+			v.keys[k] = struct{}{}
+		}
+	})
+}
+
+// WithAggregatorSelector aggregates the matched instruments with the
+// Aggregators chosen by selector instead of the ones chosen by the
+// Processor. For example, to use custom histogram boundaries for a single
+// instrument:
+//
+//	view.New(
+//		view.MatchInstrumentName("http.server.duration"),
+//		view.WithAggregatorSelector(simple.NewWithHistogramDistribution(
+//			histogram.WithExplicitBoundaries([]float64{0.1, 0.5, 1, 5}),
+//		)),
+//	)
+func WithAggregatorSelector(selector export.AggregatorSelector) Option {
+	return optionFunc(func(v *View) {
+		v.selector = selector
+	})
+}
+
+// WithCardinalityLimit limits the number of distinct attribute sets the
+// matched instruments export to limit. The measurements of further
+// attribute sets are combined into a single overflow series, until the
+// series of the instruments expire. A limit of 0 or less sets no limit
+// for the matched instruments, but the limit of their Accumulator.
+func WithCardinalityLimit(limit int) Option {
+	return optionFunc(func(v *View) {
+		v.limit = limit
+	})
+}
+
+// WithDrop drops all measurements of the matched instruments.
+func WithDrop() Option {
+	return optionFunc(func(v *View) {
+		v.drop = true
+	})
+}
+
+// Matches returns whether the instrument described by desc and created by
+// the Meter of lib is selected by v.
+func (v View) Matches(lib instrumentation.Library, desc sdkapi.Descriptor) bool {
+	if v.namePattern != nil && !v.namePattern.MatchString(desc.Name()) {
+		return false
+	}
+	if v.kind != nil && *v.kind != desc.InstrumentKind() {
+		return false
+	}
+	if v.library != nil {
+		if v.library.Name != lib.Name {
+			return false
+		}
+		if v.library.Version != "" && v.library.Version != lib.Version {
+			return false
+		}
+		if v.library.SchemaURL != "" && v.library.SchemaURL != lib.SchemaURL {
+			return false
+		}
+	}
+	return true
+}
+
+// Descriptor returns the descriptor the data of an instrument described by
+// desc is exported with.
+func (v View) Descriptor(desc sdkapi.Descriptor) sdkapi.Descriptor {
+	name, description := desc.Name(), desc.Description()
+	if v.name != "" {
+		name = v.name
+	}
+	if v.description != "" {
+		description = v.description
+	}
+	return sdkapi.NewDescriptor(name, desc.InstrumentKind(), desc.NumberKind(), description, desc.Unit())
+}
+
+// AttributeFilter returns the filter applied to the attributes of
+// measurements, or nil if all attributes are kept.
+func (v View) AttributeFilter() attribute.Filter {
+	if v.keys == nil {
+		return nil
+	}
+	keys := v.keys
+	return func(kv attribute.KeyValue) bool {
+		_, ok := keys[kv.Key]
+		return ok
+	}
+}
+
+// AggregatorSelector returns the AggregatorSelector configured for v, or
+// nil if the Processor chooses the Aggregators.
+func (v View) AggregatorSelector() export.AggregatorSelector {
+	return v.selector
+}
+
+// CardinalityLimit returns the maximum number of distinct attribute sets
+// exported by the matched instruments, or 0 if v sets no limit.
+func (v View) CardinalityLimit() int {
+	if v.limit < 0 {
+		return 0
+	}
+	return v.limit
+}
+
+// Drop returns whether measurements of the matched instruments are dropped.
+func (v View) Drop() bool {
+	return v.drop
+}
+
+// Find returns the first of views that matches the instrument described by
+// desc and created by the Meter of lib.
+func Find(views []View, lib instrumentation.Library, desc sdkapi.Descriptor) (View, bool) {
+	for _, v := range views {
+		if v.Matches(lib, desc) {
+			return v, true
+		}
+	}
+	return View{}, false
+}
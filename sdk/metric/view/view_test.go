@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+)
+
+var (
+	testLib  = instrumentation.Library{Name: "lib", Version: "v0.1.0"}
+	testDesc = sdkapi.NewDescriptor("http.server.duration", sdkapi.HistogramInstrumentKind, number.Float64Kind, "desc", unit.Milliseconds)
+)
+
+func TestMatchInstrumentName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"http.server.duration", true},
+		{"http.server.*", true},
+		{"*", true},
+		{"http.?erver.duration", true},
+		{"http.server", false},
+		{"http.client.*", false},
+		{"http?server?duration", true},
+		{"http.server.duration.*", false},
+		{"HTTP.server.duration", false},
+	}
+	for _, test := range tests {
+		v := New(MatchInstrumentName(test.pattern))
+		assert.Equal(t, test.want, v.Matches(testLib, testDesc), test.pattern)
+	}
+}
+
+func TestMatchInstrumentKind(t *testing.T) {
+	assert.True(t, New(MatchInstrumentKind(sdkapi.HistogramInstrumentKind)).Matches(testLib, testDesc))
+	assert.False(t, New(MatchInstrumentKind(sdkapi.CounterInstrumentKind)).Matches(testLib, testDesc))
+}
+
+func TestMatchInstrumentationLibrary(t *testing.T) {
+	tests := []struct {
+		lib  instrumentation.Library
+		want bool
+	}{
+		{instrumentation.Library{Name: "lib"}, true},
+		{instrumentation.Library{Name: "lib", Version: "v0.1.0"}, true},
+		{instrumentation.Library{Name: "lib", Version: "v0.2.0"}, false},
+		{instrumentation.Library{Name: "lib", SchemaURL: "https://opentelemetry.io/schemas/1.7.0"}, false},
+		{instrumentation.Library{Name: "other"}, false},
+	}
+	for _, test := range tests {
+		v := New(MatchInstrumentationLibrary(test.lib))
+		assert.Equal(t, test.want, v.Matches(testLib, testDesc), test.lib)
+	}
+}
+
+func TestMatchAllCriteria(t *testing.T) {
+	v := New(
+		MatchInstrumentName("http.*"),
+		MatchInstrumentKind(sdkapi.CounterInstrumentKind),
+	)
+	assert.False(t, v.Matches(testLib, testDesc))
+
+	assert.True(t, New().Matches(testLib, testDesc))
+}
+
+func TestDescriptor(t *testing.T) {
+	assert.Equal(t, testDesc, New().Descriptor(testDesc))
+
+	got := New(WithName("latency"), WithDescription("request latency")).Descriptor(testDesc)
+	assert.Equal(t, "latency", got.Name())
+	assert.Equal(t, "request latency", got.Description())
+	assert.Equal(t, testDesc.InstrumentKind(), got.InstrumentKind())
+	assert.Equal(t, testDesc.NumberKind(), got.NumberKind())
+	assert.Equal(t, testDesc.Unit(), got.Unit())
+}
+
+func TestAttributeFilter(t *testing.T) {
+	assert.Nil(t, New().AttributeFilter())
+
+	filter := New(WithAttributeKeys("A", "B")).AttributeFilter()
+	assert.True(t, filter(attribute.String("A", "a")))
+	assert.True(t, filter(attribute.String("B", "b")))
+	assert.False(t, filter(attribute.String("C", "c")))
+
+	filter = New(WithAttributeKeys()).AttributeFilter()
+	assert.False(t, filter(attribute.String("A", "a")))
+}
+
+func TestStreamOptions(t *testing.T) {
+	v := New()
+	assert.Nil(t, v.AggregatorSelector())
+	assert.False(t, v.Drop())
+
+	selector := simple.NewWithInexpensiveDistribution()
+	v = New(WithAggregatorSelector(selector), WithDrop())
+	assert.Equal(t, selector, v.AggregatorSelector())
+	assert.True(t, v.Drop())
+	assert.Equal(t, 0, v.CardinalityLimit())
+
+	assert.Equal(t, 100, New(WithCardinalityLimit(100)).CardinalityLimit())
+	assert.Equal(t, 0, New(WithCardinalityLimit(-1)).CardinalityLimit())
+}
+
+func TestFind(t *testing.T) {
+	views := []View{
+		New(MatchInstrumentName("http.client.*"), WithName("client")),
+		New(MatchInstrumentName("http.*"), WithName("first")),
+		New(MatchInstrumentName("http.server.*"), WithName("second")),
+	}
+	v, ok := Find(views, testLib, testDesc)
+	assert.True(t, ok)
+	assert.Equal(t, "first", v.Descriptor(testDesc).Name())
+
+	_, ok = Find(views[:1], testLib, testDesc)
+	assert.False(t, ok)
+}
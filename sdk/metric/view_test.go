@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+)
+
+// accumulationRecorder is a Processor that keeps every Accumulation it
+// processes.
+type accumulationRecorder struct {
+	export.AggregatorSelector
+	accumulations []export.Accumulation
+}
+
+func (r *accumulationRecorder) Process(accum export.Accumulation) error {
+	r.accumulations = append(r.accumulations, accum)
+	return nil
+}
+
+func newViewSDK(t *testing.T, opts ...metricsdk.Option) (metric.Meter, *metricsdk.Accumulator, *processortest.Processor) {
+	testHandler.Reset()
+	processor := processortest.NewProcessor(
+		processortest.AggregatorSelector(),
+		attribute.DefaultEncoder(),
+	)
+	accum := metricsdk.NewAccumulator(processor, opts...)
+	return metric.WrapMeterImpl(accum), accum, processor
+}
+
+func TestViewRename(t *testing.T) {
+	ctx := context.Background()
+	recorder := &accumulationRecorder{AggregatorSelector: processortest.AggregatorSelector()}
+	accum := metricsdk.NewAccumulator(recorder, metricsdk.WithViews(
+		view.New(
+			view.MatchInstrumentName("old.*"),
+			view.WithName("new.sum"),
+			view.WithDescription("renamed counter"),
+		),
+	))
+	meter := metric.WrapMeterImpl(accum)
+
+	counter := Must(meter).NewInt64Counter("old.sum", metric.WithDescription("original"))
+	other := Must(meter).NewInt64Counter("other.sum")
+	counter.Add(ctx, 1)
+	other.Add(ctx, 1)
+
+	require.Equal(t, "old.sum", counter.SyncImpl().Descriptor().Name())
+	require.Equal(t, 2, accum.Collect(ctx))
+
+	names := map[string]string{}
+	for _, a := range recorder.accumulations {
+		names[a.Descriptor().Name()] = a.Descriptor().Description()
+	}
+	require.Equal(t, map[string]string{
+		"new.sum":   "renamed counter",
+		"other.sum": "",
+	}, names)
+}
+
+func TestViewAttributeKeys(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t, metricsdk.WithViews(
+		view.New(view.WithAttributeKeys("A")),
+	))
+
+	counter := Must(meter).NewInt64Counter("counter.sum")
+	counter.Add(ctx, 1, attribute.String("A", "a"), attribute.String("B", "b1"))
+	counter.Add(ctx, 2, attribute.String("A", "a"), attribute.String("B", "b2"))
+	counter.Add(ctx, 4, attribute.String("B", "b3"))
+
+	_ = Must(meter).NewInt64CounterObserver("observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(10, attribute.String("A", "a"), attribute.String("B", "b1"))
+		result.Observe(20, attribute.String("A", "a"), attribute.String("B", "b2"))
+	})
+
+	accum.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=a/":  3,
+		"counter.sum//":     4,
+		"observer.sum/A=a/": 30,
+	}, processor.Values())
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestViewAttributeKeysRecordBatch(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t, metricsdk.WithViews(
+		view.New(view.MatchInstrumentName("filtered.*"), view.WithAttributeKeys("A")),
+	))
+
+	filtered := Must(meter).NewInt64Counter("filtered.sum")
+	unfiltered := Must(meter).NewInt64Counter("unfiltered.sum")
+
+	labels := []attribute.KeyValue{
+		attribute.String("A", "a"),
+		attribute.String("B", "b"),
+	}
+	accum.RecordBatch(ctx, labels, filtered.Measurement(1), unfiltered.Measurement(2))
+	accum.RecordBatch(ctx, labels, unfiltered.Measurement(4), filtered.Measurement(8))
+
+	accum.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"filtered.sum/A=a/":       9,
+		"unfiltered.sum/A=a,B=b/": 6,
+	}, processor.Values())
+}
+
+func TestViewAggregatorSelector(t *testing.T) {
+	ctx := context.Background()
+	boundaries := []float64{1, 10, 100}
+	selector := simple.NewWithHistogramDistribution(histogram.WithExplicitBoundaries(boundaries))
+	recorder := &accumulationRecorder{AggregatorSelector: simple.NewWithInexpensiveDistribution()}
+	accum := metricsdk.NewAccumulator(recorder, metricsdk.WithViews(
+		view.New(
+			view.MatchInstrumentName("latency"),
+			view.WithAggregatorSelector(selector),
+		),
+	))
+	meter := metric.WrapMeterImpl(accum)
+
+	Must(meter).NewFloat64Histogram("latency").Record(ctx, 5)
+	Must(meter).NewFloat64Histogram("size").Record(ctx, 5)
+
+	require.Equal(t, 2, accum.Collect(ctx))
+	require.Len(t, recorder.accumulations, 2)
+	for _, a := range recorder.accumulations {
+		switch a.Descriptor().Name() {
+		case "latency":
+			require.Equal(t, aggregation.HistogramKind, a.Aggregator().Aggregation().Kind())
+			buckets, err := a.Aggregator().(aggregation.Histogram).Histogram()
+			require.NoError(t, err)
+			require.Equal(t, boundaries, buckets.Boundaries)
+			require.Equal(t, []uint64{0, 1, 0, 0}, buckets.Counts)
+			require.Equal(t, selector, a.AggregatorSelector())
+		case "size":
+			require.Equal(t, aggregation.MinMaxSumCountKind, a.Aggregator().Aggregation().Kind())
+			require.Nil(t, a.AggregatorSelector())
+		}
+	}
+}
+
+func TestViewDrop(t *testing.T) {
+	ctx := context.Background()
+	meter, accum, processor := newViewSDK(t, metricsdk.WithViews(
+		view.New(view.MatchInstrumentKind(sdkapi.CounterInstrumentKind), view.WithDrop()),
+	))
+
+	counter := Must(meter).NewInt64Counter("counter.sum")
+	_ = Must(meter).NewInt64GaugeObserver("gauge.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+	counter.Add(ctx, 1)
+	accum.RecordBatch(ctx, nil, counter.Measurement(1))
+
+	require.Equal(t, 1, accum.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"gauge.lastvalue//": 1,
+	}, processor.Values())
+}
+
+func TestViewInstrumentationLibrary(t *testing.T) {
+	ctx := context.Background()
+	views := metricsdk.WithViews(
+		view.New(
+			view.MatchInstrumentationLibrary(instrumentation.Library{Name: "third-party"}),
+			view.WithDrop(),
+		),
+	)
+
+	meter, accum, processor := newViewSDK(t, views, metricsdk.WithInstrumentationLibrary(instrumentation.Library{Name: "first-party"}))
+	Must(meter).NewInt64Counter("counter.sum").Add(ctx, 1)
+	require.Equal(t, 1, accum.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//": 1,
+	}, processor.Values())
+
+	meter, accum, _ = newViewSDK(t, views, metricsdk.WithInstrumentationLibrary(instrumentation.Library{Name: "third-party"}))
+	Must(meter).NewInt64Counter("counter.sum").Add(ctx, 1)
+	require.Equal(t, 0, accum.Collect(ctx))
+}
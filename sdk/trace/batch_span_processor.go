@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/internal/env"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -62,6 +63,28 @@ type BatchSpanProcessorOptions struct {
 	// Blocking option should be used carefully as it can severely affect the performance of an
 	// application.
 	BlockOnQueueFull bool
+
+	// Hooks are notified of the activity of the processor, so it can be
+	// monitored.
+	Hooks BatchSpanProcessorHooks
+}
+
+// BatchSpanProcessorHooks are notified of the activity of a batch span
+// processor. Nil hooks are ignored. They are called synchronously by the
+// processor and must not block.
+type BatchSpanProcessorHooks struct {
+	// RegisterQueue is called once, when the processor is created, with a
+	// function returning the number of spans in its queue and with the
+	// capacity of the queue.
+	RegisterQueue func(length func() int, capacity int)
+
+	// OnDrop is called each time a span is dropped because the queue is
+	// full.
+	OnDrop func()
+
+	// OnExport is called after each export with the number of spans
+	// exported, the duration of the export, and the error it returned.
+	OnExport func(ctx context.Context, spans int, duration time.Duration, err error)
 }
 
 // batchSpanProcessor is a SpanProcessor that batches asynchronously-received
@@ -87,12 +110,28 @@ var _ SpanProcessor = (*batchSpanProcessor)(nil)
 // span batches to the exporter with the supplied options.
 //
 // If the exporter is nil, the span processor will preform no action.
+//
+// The OTEL_BSP_SCHEDULE_DELAY, OTEL_BSP_EXPORT_TIMEOUT,
+// OTEL_BSP_MAX_QUEUE_SIZE, and OTEL_BSP_MAX_EXPORT_BATCH_SIZE environment
+// variables are used to override the default values of the processor. The
+// passed options take precedence over these environment variables.
 func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorOption) SpanProcessor {
+	maxQueueSize := env.BatchSpanProcessorMaxQueueSize(DefaultMaxQueueSize)
+	maxExportBatchSize := env.BatchSpanProcessorMaxExportBatchSize(DefaultMaxExportBatchSize)
+
+	if maxExportBatchSize > maxQueueSize {
+		if DefaultMaxExportBatchSize > maxQueueSize {
+			maxExportBatchSize = maxQueueSize
+		} else {
+			maxExportBatchSize = DefaultMaxExportBatchSize
+		}
+	}
+
 	o := BatchSpanProcessorOptions{
-		BatchTimeout:       DefaultBatchTimeout,
-		ExportTimeout:      DefaultExportTimeout,
-		MaxQueueSize:       DefaultMaxQueueSize,
-		MaxExportBatchSize: DefaultMaxExportBatchSize,
+		BatchTimeout:       env.BatchSpanProcessorScheduleDelay(DefaultBatchTimeout),
+		ExportTimeout:      env.BatchSpanProcessorExportTimeout(DefaultExportTimeout),
+		MaxQueueSize:       maxQueueSize,
+		MaxExportBatchSize: maxExportBatchSize,
 	}
 	for _, opt := range options {
 		opt(&o)
@@ -105,6 +144,9 @@ func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorO
 		queue:  make(chan ReadOnlySpan, o.MaxQueueSize),
 		stopCh: make(chan struct{}),
 	}
+	if o.Hooks.RegisterQueue != nil {
+		o.Hooks.RegisterQueue(func() int { return len(bsp.queue) }, cap(bsp.queue))
+	}
 
 	bsp.stopWait.Add(1)
 	go func() {
@@ -222,6 +264,14 @@ func WithBlocking() BatchSpanProcessorOption {
 	}
 }
 
+// WithBatchSpanProcessorHooks sets the hooks notified of the activity of the
+// processor.
+func WithBatchSpanProcessorHooks(hooks BatchSpanProcessorHooks) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.Hooks = hooks
+	}
+}
+
 // exportSpans is a subroutine of processing and draining the queue.
 func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 	bsp.timer.Reset(bsp.o.BatchTimeout)
@@ -236,7 +286,11 @@ func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 	}
 
 	if l := len(bsp.batch); l > 0 {
+		start := time.Now()
 		err := bsp.e.ExportSpans(ctx, bsp.batch)
+		if bsp.o.Hooks.OnExport != nil {
+			bsp.o.Hooks.OnExport(ctx, l, time.Since(start), err)
+		}
 
 		// A new batch is always created after exporting, even if the batch failed to be exported.
 		//
@@ -363,6 +417,9 @@ func (bsp *batchSpanProcessor) enqueueBlockOnQueueFull(ctx context.Context, sd R
 		return true
 	default:
 		atomic.AddUint32(&bsp.dropped, 1)
+		if bsp.o.Hooks.OnDrop != nil {
+			bsp.o.Hooks.OnDrop()
+		}
 	}
 	return false
 }
@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -26,6 +27,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/internal/env"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -109,6 +112,7 @@ func TestNewBatchSpanProcessorWithNilExporter(t *testing.T) {
 type testOption struct {
 	name           string
 	o              []sdktrace.BatchSpanProcessorOption
+	envs           map[string]string
 	wantNumSpans   int
 	wantBatchCount int
 	genNumSpans    int
@@ -219,6 +223,94 @@ func TestNewBatchSpanProcessorWithOptions(t *testing.T) {
 	}
 }
 
+func TestNewBatchSpanProcessorWithEnvOptions(t *testing.T) {
+	options := []testOption{
+		{
+			name:           "BatchSpanProcessorEnvOptions - Basic",
+			wantNumSpans:   2053,
+			wantBatchCount: 1,
+			genNumSpans:    2053,
+			envs: map[string]string{
+				env.BatchSpanProcessorMaxQueueSizeKey:       "5000",
+				env.BatchSpanProcessorMaxExportBatchSizeKey: "5000",
+			},
+		},
+		{
+			name:           "BatchSpanProcessorEnvOptions - A larger max export batch size than queue size",
+			wantNumSpans:   2053,
+			wantBatchCount: 4,
+			genNumSpans:    2053,
+			envs: map[string]string{
+				env.BatchSpanProcessorMaxQueueSizeKey:       "5000",
+				env.BatchSpanProcessorMaxExportBatchSizeKey: "10000",
+			},
+		},
+		{
+			name:           "BatchSpanProcessorEnvOptions - A large max export batch size with a small queue size",
+			wantNumSpans:   2053,
+			wantBatchCount: 42,
+			genNumSpans:    2053,
+			envs: map[string]string{
+				env.BatchSpanProcessorMaxQueueSizeKey:       "50",
+				env.BatchSpanProcessorMaxExportBatchSizeKey: "10000",
+			},
+		},
+		{
+			name: "BatchSpanProcessorEnvOptions - Explicit options take precedence",
+			o: []sdktrace.BatchSpanProcessorOption{
+				sdktrace.WithMaxQueueSize(200),
+				sdktrace.WithMaxExportBatchSize(20),
+			},
+			wantNumSpans:   205,
+			wantBatchCount: 11,
+			genNumSpans:    205,
+			envs: map[string]string{
+				env.BatchSpanProcessorMaxQueueSizeKey:       "5000",
+				env.BatchSpanProcessorMaxExportBatchSizeKey: "5000",
+			},
+		},
+	}
+
+	envStore := ottest.NewEnvStore()
+	envStore.Record(env.BatchSpanProcessorMaxQueueSizeKey)
+	envStore.Record(env.BatchSpanProcessorMaxExportBatchSizeKey)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	for _, option := range options {
+		t.Run(option.name, func(t *testing.T) {
+			for k, v := range option.envs {
+				require.NoError(t, os.Setenv(k, v))
+			}
+
+			te := testBatchExporter{}
+			tp := basicTracerProvider(t)
+			ssp := createAndRegisterBatchSP(option, &te)
+			if ssp == nil {
+				t.Fatalf("%s: Error creating new instance of BatchSpanProcessor\n", option.name)
+			}
+			tp.RegisterSpanProcessor(ssp)
+			tr := tp.Tracer("BatchSpanProcessorWithEnvOptions")
+
+			generateSpan(t, option.parallel, tr, option)
+
+			tp.UnregisterSpanProcessor(ssp)
+
+			gotNumOfSpans := te.len()
+			if option.wantNumSpans > 0 && option.wantNumSpans != gotNumOfSpans {
+				t.Errorf("number of exported span: got %+v, want %+v\n",
+					gotNumOfSpans, option.wantNumSpans)
+			}
+
+			gotBatchCount := te.getBatchCount()
+			if option.wantBatchCount > 0 && gotBatchCount < option.wantBatchCount {
+				t.Errorf("number batches: got %+v, want >= %+v\n",
+					gotBatchCount, option.wantBatchCount)
+				t.Errorf("Batches %v\n", te.sizes)
+			}
+		})
+	}
+}
+
 type stuckExporter struct {
 	testBatchExporter
 }
@@ -480,3 +572,84 @@ func TestBatchSpanProcessorForceFlushQueuedSpans(t *testing.T) {
 		assert.Len(t, exp.GetSpans(), i+1)
 	}
 }
+
+// blockingExporter blocks exports until release is closed. The first export
+// fails with err.
+type blockingExporter struct {
+	started chan struct{}
+	release chan struct{}
+	err     error
+	calls   int
+}
+
+func (e *blockingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	select {
+	case e.started <- struct{}{}:
+	default:
+	}
+	<-e.release
+	e.calls++
+	if e.calls == 1 {
+		return e.err
+	}
+	return nil
+}
+
+func (e *blockingExporter) Shutdown(context.Context) error { return nil }
+
+func TestBatchSpanProcessorHooks(t *testing.T) {
+	var (
+		queueLength   func() int
+		queueCapacity int
+		dropped       int
+		exported      []int
+		errs          []error
+	)
+	hooks := sdktrace.BatchSpanProcessorHooks{
+		RegisterQueue: func(length func() int, capacity int) {
+			queueLength, queueCapacity = length, capacity
+		},
+		OnDrop: func() { dropped++ },
+		OnExport: func(_ context.Context, spans int, _ time.Duration, err error) {
+			exported = append(exported, spans)
+			errs = append(errs, err)
+		},
+	}
+	exp := &blockingExporter{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+		err:     context.DeadlineExceeded,
+	}
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxQueueSize(2),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithBatchSpanProcessorHooks(hooks),
+	)
+	require.NotNil(t, queueLength)
+	assert.Equal(t, 2, queueCapacity)
+
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorHooks")
+
+	// The first span is dequeued and blocks in the exporter.
+	_, span := tr.Start(context.Background(), "first")
+	span.End()
+	<-exp.started
+
+	// Two spans fill the queue and two are dropped.
+	for i := 0; i < 4; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+	assert.Equal(t, 2, queueLength())
+	assert.Equal(t, 2, dropped)
+
+	close(exp.release)
+	require.NoError(t, bsp.ForceFlush(context.Background()))
+	require.NoError(t, bsp.Shutdown(context.Background()))
+
+	assert.Equal(t, []int{1, 1, 1}, exported)
+	assert.Equal(t, []error{context.DeadlineExceeded, nil, nil}, errs)
+}
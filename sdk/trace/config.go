@@ -14,8 +14,24 @@
 
 package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
+import "go.opentelemetry.io/otel/sdk/internal/env"
+
 // SpanLimits represents the limits of a span.
+//
+// Any limit that is not set to a positive value is replaced by the value of
+// the corresponding OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT,
+// OTEL_SPAN_EVENT_COUNT_LIMIT, OTEL_SPAN_LINK_COUNT_LIMIT,
+// OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT, OTEL_LINK_ATTRIBUTE_COUNT_LIMIT, or
+// OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT (falling back to
+// OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT) environment variable if set, and the
+// package default otherwise.
 type SpanLimits struct {
+	// AttributeValueLengthLimit is the maximum allowed attribute value
+	// length, in characters, of span, event, and link attributes. Longer
+	// STRING values, and elements of STRINGSLICE values, are truncated. By
+	// default, attribute values are not truncated.
+	AttributeValueLengthLimit int
+
 	// AttributeCountLimit is the maximum allowed span attribute count.
 	AttributeCountLimit int
 
@@ -33,24 +49,40 @@ type SpanLimits struct {
 }
 
 func (sl *SpanLimits) ensureDefault() {
+	if sl.AttributeValueLengthLimit <= 0 {
+		sl.AttributeValueLengthLimit = env.SpanAttributeValueLength(DefaultAttributeValueLengthLimit)
+	}
 	if sl.EventCountLimit <= 0 {
-		sl.EventCountLimit = DefaultEventCountLimit
+		sl.EventCountLimit = positiveOr(env.SpanEventCount(DefaultEventCountLimit), DefaultEventCountLimit)
 	}
 	if sl.AttributeCountLimit <= 0 {
-		sl.AttributeCountLimit = DefaultAttributeCountLimit
+		sl.AttributeCountLimit = positiveOr(env.SpanAttributeCount(DefaultAttributeCountLimit), DefaultAttributeCountLimit)
 	}
 	if sl.LinkCountLimit <= 0 {
-		sl.LinkCountLimit = DefaultLinkCountLimit
+		sl.LinkCountLimit = positiveOr(env.SpanLinkCount(DefaultLinkCountLimit), DefaultLinkCountLimit)
 	}
 	if sl.AttributePerEventCountLimit <= 0 {
-		sl.AttributePerEventCountLimit = DefaultAttributePerEventCountLimit
+		sl.AttributePerEventCountLimit = positiveOr(env.SpanEventAttributeCount(DefaultAttributePerEventCountLimit), DefaultAttributePerEventCountLimit)
 	}
 	if sl.AttributePerLinkCountLimit <= 0 {
-		sl.AttributePerLinkCountLimit = DefaultAttributePerLinkCountLimit
+		sl.AttributePerLinkCountLimit = positiveOr(env.SpanLinkAttributeCount(DefaultAttributePerLinkCountLimit), DefaultAttributePerLinkCountLimit)
 	}
 }
 
+// positiveOr returns v if it is positive, otherwise defaultValue.
+func positiveOr(v, defaultValue int) int {
+	if v <= 0 {
+		return defaultValue
+	}
+	return v
+}
+
 const (
+	// DefaultAttributeValueLengthLimit is the default maximum allowed
+	// attribute value length. It is not positive, meaning values are not
+	// truncated.
+	DefaultAttributeValueLengthLimit = -1
+
 	// DefaultAttributeCountLimit is the default maximum allowed span attribute count.
 	DefaultAttributeCountLimit = 128
 
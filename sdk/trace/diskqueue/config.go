@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue // import "go.opentelemetry.io/otel/sdk/trace/diskqueue"
+
+import "time"
+
+// Defaults for the Exporter configuration.
+const (
+	// DefaultMaxBytes is the maximum size of the queue on disk.
+	DefaultMaxBytes = 128 << 20
+	// DefaultMaxSegmentBytes is the size at which segment files are rotated.
+	DefaultMaxSegmentBytes = 8 << 20
+	// DefaultRetryInterval is the time waited before retrying a failed
+	// export.
+	DefaultRetryInterval = 5 * time.Second
+	// DefaultExportTimeout is the maximum duration of an export.
+	DefaultExportTimeout = 30 * time.Second
+)
+
+// config contains configuration for an Exporter.
+type config struct {
+	maxBytes        int64
+	maxSegmentBytes int64
+	retryInterval   time.Duration
+	exportTimeout   time.Duration
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		maxBytes:        DefaultMaxBytes,
+		maxSegmentBytes: DefaultMaxSegmentBytes,
+		retryInterval:   DefaultRetryInterval,
+		exportTimeout:   DefaultExportTimeout,
+	}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	if c.maxSegmentBytes > c.maxBytes {
+		c.maxSegmentBytes = c.maxBytes
+	}
+	return c
+}
+
+// Option applies a configuration option to an Exporter.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithMaxBytes sets the maximum size, in bytes, of the queue on disk. When
+// it is exceeded, the oldest segments of the queue are dropped. Non-positive
+// values are ignored. By default, DefaultMaxBytes is used.
+func WithMaxBytes(n int64) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxBytes = n
+		}
+	})
+}
+
+// WithMaxSegmentBytes sets the size, in bytes, at which segment files are
+// rotated. Non-positive values are ignored. By default,
+// DefaultMaxSegmentBytes is used.
+func WithMaxSegmentBytes(n int64) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxSegmentBytes = n
+		}
+	})
+}
+
+// WithRetryInterval sets the time waited before retrying a failed export.
+// Non-positive values are ignored. By default, DefaultRetryInterval is used.
+func WithRetryInterval(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.retryInterval = d
+		}
+	})
+}
+
+// WithExportTimeout sets the maximum duration of an export. Non-positive
+// values are ignored. By default, DefaultExportTimeout is used.
+func WithExportTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.exportTimeout = d
+		}
+	})
+}
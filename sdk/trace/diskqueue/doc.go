@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diskqueue provides a SpanExporter that persists the batches it is
+// passed in a write-ahead queue on disk before exporting them with another
+// SpanExporter.
+//
+// Batches are exported in the order they were queued. A batch is removed
+// from the queue only once it has been exported successfully, failed exports
+// being retried until then. Batches that could not be exported before the
+// Exporter was shut down, or before the process stopped, are exported when a
+// new Exporter is created with the same directory.
+//
+// The queue is stored in segment files that are rotated when they reach a
+// configurable size. When the total size of the queue exceeds its limit, the
+// oldest segments are dropped. Records that are found to be corrupted while
+// the queue is read are skipped along with the rest of their segment.
+//
+// The Exporter is meant to be used with a BatchSpanProcessor:
+//
+//	exp, err := diskqueue.New(otlpExporter, "/var/lib/app/spans")
+//	if err != nil {
+//		// ...
+//	}
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp))
+package diskqueue // import "go.opentelemetry.io/otel/sdk/trace/diskqueue"
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue // import "go.opentelemetry.io/otel/sdk/trace/diskqueue"
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanRecord is the persisted form of a ReadOnlySpan.
+type spanRecord struct {
+	Name                   string
+	SpanContext            spanContextRecord
+	Parent                 spanContextRecord
+	SpanKind               trace.SpanKind
+	StartTime              time.Time
+	EndTime                time.Time
+	Attributes             []attributeRecord
+	Events                 []eventRecord
+	Links                  []linkRecord
+	StatusCode             codes.Code
+	StatusDescription      string
+	DroppedAttributes      int
+	DroppedEvents          int
+	DroppedLinks           int
+	TruncatedAttributes    int
+	ChildSpanCount         int
+	Resource               *resourceRecord
+	InstrumentationLibrary instrumentation.Library
+}
+
+type spanContextRecord struct {
+	TraceID    trace.TraceID
+	SpanID     trace.SpanID
+	TraceFlags trace.TraceFlags
+	TraceState string
+	Remote     bool
+}
+
+type attributeRecord struct {
+	Key      attribute.Key
+	Type     attribute.Type
+	Bool     bool
+	Int64    int64
+	Float64  float64
+	String   string
+	Bools    []bool
+	Int64s   []int64
+	Float64s []float64
+	Strings  []string
+}
+
+type eventRecord struct {
+	Name                  string
+	Attributes            []attributeRecord
+	DroppedAttributeCount int
+	Time                  time.Time
+}
+
+type linkRecord struct {
+	SpanContext           spanContextRecord
+	Attributes            []attributeRecord
+	DroppedAttributeCount int
+}
+
+type resourceRecord struct {
+	SchemaURL  string
+	Attributes []attributeRecord
+}
+
+// encodeSpans returns the persisted form of spans.
+func encodeSpans(spans []sdktrace.ReadOnlySpan) ([]byte, error) {
+	records := make([]spanRecord, len(spans))
+	for i, s := range spans {
+		records[i] = spanRecord{
+			Name:                   s.Name(),
+			SpanContext:            toSpanContextRecord(s.SpanContext()),
+			Parent:                 toSpanContextRecord(s.Parent()),
+			SpanKind:               s.SpanKind(),
+			StartTime:              s.StartTime(),
+			EndTime:                s.EndTime(),
+			Attributes:             toAttributeRecords(s.Attributes()),
+			StatusCode:             s.Status().Code,
+			StatusDescription:      s.Status().Description,
+			DroppedAttributes:      s.DroppedAttributes(),
+			DroppedEvents:          s.DroppedEvents(),
+			DroppedLinks:           s.DroppedLinks(),
+			TruncatedAttributes:    s.TruncatedAttributes(),
+			ChildSpanCount:         s.ChildSpanCount(),
+			InstrumentationLibrary: s.InstrumentationLibrary(),
+		}
+		for _, e := range s.Events() {
+			records[i].Events = append(records[i].Events, eventRecord{
+				Name:                  e.Name,
+				Attributes:            toAttributeRecords(e.Attributes),
+				DroppedAttributeCount: e.DroppedAttributeCount,
+				Time:                  e.Time,
+			})
+		}
+		for _, l := range s.Links() {
+			records[i].Links = append(records[i].Links, linkRecord{
+				SpanContext:           toSpanContextRecord(l.SpanContext),
+				Attributes:            toAttributeRecords(l.Attributes),
+				DroppedAttributeCount: l.DroppedAttributeCount,
+			})
+		}
+		if r := s.Resource(); r != nil {
+			records[i].Resource = &resourceRecord{
+				SchemaURL:  r.SchemaURL(),
+				Attributes: toAttributeRecords(r.Attributes()),
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(records); err != nil {
+		return nil, fmt.Errorf("failed to encode spans: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSpans returns the spans persisted in p by encodeSpans.
+func decodeSpans(p []byte) ([]sdktrace.ReadOnlySpan, error) {
+	var records []spanRecord
+	if err := gob.NewDecoder(bytes.NewReader(p)).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed to decode spans: %w", err)
+	}
+
+	stubs := make(tracetest.SpanStubs, len(records))
+	for i, r := range records {
+		stubs[i] = tracetest.SpanStub{
+			Name:        r.Name,
+			SpanContext: fromSpanContextRecord(r.SpanContext),
+			Parent:      fromSpanContextRecord(r.Parent),
+			SpanKind:    r.SpanKind,
+			StartTime:   r.StartTime,
+			EndTime:     r.EndTime,
+			Attributes:  fromAttributeRecords(r.Attributes),
+			Status: sdktrace.Status{
+				Code:        r.StatusCode,
+				Description: r.StatusDescription,
+			},
+			DroppedAttributes:      r.DroppedAttributes,
+			DroppedEvents:          r.DroppedEvents,
+			DroppedLinks:           r.DroppedLinks,
+			TruncatedAttributes:    r.TruncatedAttributes,
+			ChildSpanCount:         r.ChildSpanCount,
+			InstrumentationLibrary: r.InstrumentationLibrary,
+		}
+		for _, e := range r.Events {
+			stubs[i].Events = append(stubs[i].Events, sdktrace.Event{
+				Name:                  e.Name,
+				Attributes:            fromAttributeRecords(e.Attributes),
+				DroppedAttributeCount: e.DroppedAttributeCount,
+				Time:                  e.Time,
+			})
+		}
+		for _, l := range r.Links {
+			stubs[i].Links = append(stubs[i].Links, sdktrace.Link{
+				SpanContext:           fromSpanContextRecord(l.SpanContext),
+				Attributes:            fromAttributeRecords(l.Attributes),
+				DroppedAttributeCount: l.DroppedAttributeCount,
+			})
+		}
+		if r.Resource != nil {
+			stubs[i].Resource = resource.NewWithAttributes(
+				r.Resource.SchemaURL,
+				fromAttributeRecords(r.Resource.Attributes)...,
+			)
+		}
+	}
+	return stubs.Snapshots(), nil
+}
+
+func toSpanContextRecord(sc trace.SpanContext) spanContextRecord {
+	return spanContextRecord{
+		TraceID:    sc.TraceID(),
+		SpanID:     sc.SpanID(),
+		TraceFlags: sc.TraceFlags(),
+		TraceState: sc.TraceState().String(),
+		Remote:     sc.IsRemote(),
+	}
+}
+
+func fromSpanContextRecord(r spanContextRecord) trace.SpanContext {
+	// The trace state was valid when it was persisted.
+	ts, _ := trace.ParseTraceState(r.TraceState)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    r.TraceID,
+		SpanID:     r.SpanID,
+		TraceFlags: r.TraceFlags,
+		TraceState: ts,
+		Remote:     r.Remote,
+	})
+}
+
+func toAttributeRecords(attrs []attribute.KeyValue) []attributeRecord {
+	if len(attrs) == 0 {
+		return nil
+	}
+	records := make([]attributeRecord, len(attrs))
+	for i, a := range attrs {
+		r := attributeRecord{Key: a.Key, Type: a.Value.Type()}
+		switch r.Type {
+		case attribute.BOOL:
+			r.Bool = a.Value.AsBool()
+		case attribute.INT64:
+			r.Int64 = a.Value.AsInt64()
+		case attribute.FLOAT64:
+			r.Float64 = a.Value.AsFloat64()
+		case attribute.STRING:
+			r.String = a.Value.AsString()
+		case attribute.BOOLSLICE:
+			r.Bools = a.Value.AsBoolSlice()
+		case attribute.INT64SLICE:
+			r.Int64s = a.Value.AsInt64Slice()
+		case attribute.FLOAT64SLICE:
+			r.Float64s = a.Value.AsFloat64Slice()
+		case attribute.STRINGSLICE:
+			r.Strings = a.Value.AsStringSlice()
+		}
+		records[i] = r
+	}
+	return records
+}
+
+func fromAttributeRecords(records []attributeRecord) []attribute.KeyValue {
+	if len(records) == 0 {
+		return nil
+	}
+	attrs := make([]attribute.KeyValue, 0, len(records))
+	for _, r := range records {
+		switch r.Type {
+		case attribute.BOOL:
+			attrs = append(attrs, r.Key.Bool(r.Bool))
+		case attribute.INT64:
+			attrs = append(attrs, r.Key.Int64(r.Int64))
+		case attribute.FLOAT64:
+			attrs = append(attrs, r.Key.Float64(r.Float64))
+		case attribute.STRING:
+			attrs = append(attrs, r.Key.String(r.String))
+		case attribute.BOOLSLICE:
+			attrs = append(attrs, r.Key.BoolSlice(r.Bools))
+		case attribute.INT64SLICE:
+			attrs = append(attrs, r.Key.Int64Slice(r.Int64s))
+		case attribute.FLOAT64SLICE:
+			attrs = append(attrs, r.Key.Float64Slice(r.Float64s))
+		case attribute.STRINGSLICE:
+			attrs = append(attrs, r.Key.StringSlice(r.Strings))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue // import "go.opentelemetry.io/otel/sdk/trace/diskqueue"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Exporter is a SpanExporter that persists the batches it is passed in a
+// queue on disk and exports them in the background with another
+// SpanExporter.
+type Exporter struct {
+	exporter sdktrace.SpanExporter
+	cfg      config
+	queue    *queue
+
+	// notifyCh is signaled when a batch is queued.
+	notifyCh chan struct{}
+	// stopCh is closed to stop the export loop once the queue is drained.
+	stopCh chan struct{}
+	// doneCh is closed when the export loop returns.
+	doneCh chan struct{}
+	// ctx is canceled to abort the export loop.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	stopOnce sync.Once
+}
+
+var _ sdktrace.SpanExporter = (*Exporter)(nil)
+
+// New returns an Exporter that persists batches in dir, creating it if
+// needed, and exports them with exporter. Batches persisted in dir by a
+// previous Exporter are exported first.
+func New(exporter sdktrace.SpanExporter, dir string, opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts...)
+	q, err := openQueue(dir, cfg.maxBytes, cfg.maxSegmentBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &Exporter{
+		exporter: exporter,
+		cfg:      cfg,
+		queue:    q,
+		notifyCh: make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	go e.run()
+	return e, nil
+}
+
+// ExportSpans persists spans in the queue. They are exported in the
+// background. An error is returned if the Exporter was shut down.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	select {
+	case <-e.stopCh:
+		return errClosed
+	default:
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	p, err := encodeSpans(spans)
+	if err != nil {
+		return err
+	}
+	if err := e.queue.append(p); err != nil {
+		return err
+	}
+
+	select {
+	case e.notifyCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Shutdown exports the queued batches until the queue is empty, an export
+// fails, or ctx is done, and then shuts down the underlying exporter. The
+// batches that were not exported remain persisted.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	var err error
+	e.stopOnce.Do(func() {
+		close(e.stopCh)
+		select {
+		case <-e.doneCh:
+		case <-ctx.Done():
+			e.cancel()
+			<-e.doneCh
+			err = ctx.Err()
+		}
+		e.cancel()
+
+		if qerr := e.queue.close(); err == nil {
+			err = qerr
+		}
+		if xerr := e.exporter.Shutdown(ctx); err == nil {
+			err = xerr
+		}
+	})
+	return err
+}
+
+// run exports the queued batches in order until the Exporter is stopped.
+func (e *Exporter) run() {
+	defer close(e.doneCh)
+	for e.ctx.Err() == nil {
+		p, pos, ok := e.queue.next()
+		if !ok {
+			select {
+			case <-e.notifyCh:
+				continue
+			case <-e.stopCh:
+				return
+			case <-e.ctx.Done():
+				return
+			}
+		}
+
+		spans, err := decodeSpans(p)
+		if err != nil {
+			otel.Handle(err)
+		} else if !e.export(spans) {
+			return
+		}
+		if err := e.queue.ack(pos); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
+
+// export exports spans, retrying failed exports until it succeeds. It
+// returns false if the Exporter was stopped before the spans were exported.
+func (e *Exporter) export(spans []sdktrace.ReadOnlySpan) bool {
+	for {
+		ctx, cancel := context.WithTimeout(e.ctx, e.cfg.exportTimeout)
+		err := e.exporter.ExportSpans(ctx, spans)
+		cancel()
+		if err == nil {
+			return true
+		}
+		otel.Handle(err)
+
+		timer := time.NewTimer(e.cfg.retryInterval)
+		select {
+		case <-timer.C:
+		case <-e.stopCh:
+			timer.Stop()
+			return false
+		case <-e.ctx.Done():
+			timer.Stop()
+			return false
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/diskqueue"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingExporter records the spans it exports. Exports fail while its
+// failures count is positive.
+type recordingExporter struct {
+	mu       sync.Mutex
+	failures int
+	spans    tracetest.SpanStubs
+}
+
+func (e *recordingExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.failures != 0 {
+		e.failures--
+		return errors.New("export failed")
+	}
+	e.spans = append(e.spans, tracetest.SpanStubsFromReadOnlySpans(spans)...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error { return nil }
+
+func (e *recordingExporter) names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var names []string
+	for _, s := range e.spans {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func spans(names ...string) []sdktrace.ReadOnlySpan {
+	stubs := make(tracetest.SpanStubs, len(names))
+	for i, n := range names {
+		stubs[i].Name = n
+	}
+	return stubs.Snapshots()
+}
+
+func eventuallyExported(t *testing.T, exp *recordingExporter, names ...string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return len(exp.names()) >= len(names)
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, names, exp.names())
+}
+
+func TestExporterPersistsSpans(t *testing.T) {
+	ts, err := trace.ParseTraceState("key=value")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+	parent := sc.WithSpanID(trace.SpanID{0x02}).WithRemote(true)
+	start := time.Unix(1600000000, 0).UTC()
+	attrs := []attribute.KeyValue{
+		attribute.Bool("bool", true),
+		attribute.Int64("int64", 1),
+		attribute.Float64("float64", math.Inf(1)),
+		attribute.String("string", "value"),
+		attribute.BoolSlice("bools", []bool{true, false}),
+		attribute.Int64Slice("int64s", []int64{1, 2}),
+		attribute.Float64Slice("float64s", []float64{1.5, 2.5}),
+		attribute.StringSlice("strings", []string{"a", "b"}),
+	}
+	want := tracetest.SpanStubs{{
+		Name:        "span",
+		SpanContext: sc,
+		Parent:      parent,
+		SpanKind:    trace.SpanKindServer,
+		StartTime:   start,
+		EndTime:     start.Add(time.Second),
+		Attributes:  attrs,
+		Events: []sdktrace.Event{{
+			Name:                  "event",
+			Attributes:            attrs[:1],
+			DroppedAttributeCount: 1,
+			Time:                  start.Add(time.Millisecond),
+		}},
+		Links: []sdktrace.Link{{
+			SpanContext:           parent,
+			Attributes:            attrs[1:2],
+			DroppedAttributeCount: 2,
+		}},
+		Status:                 sdktrace.Status{Code: codes.Error, Description: "failure"},
+		DroppedAttributes:      1,
+		DroppedEvents:          2,
+		DroppedLinks:           3,
+		TruncatedAttributes:    4,
+		ChildSpanCount:         5,
+		Resource:               resource.NewWithAttributes("https://example.com/schema", attribute.String("service.name", "test")),
+		InstrumentationLibrary: instrumentation.Library{Name: "lib", Version: "v1", SchemaURL: "https://example.com/schema"},
+	}}
+
+	exp := &recordingExporter{}
+	dq, err := diskqueue.New(exp, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dq.ExportSpans(context.Background(), want.Snapshots()))
+	require.NoError(t, dq.Shutdown(context.Background()))
+
+	assert.Equal(t, want, exp.spans)
+}
+
+func TestExporterRetriesFailedExports(t *testing.T) {
+	exp := &recordingExporter{failures: 2}
+	dq, err := diskqueue.New(exp, t.TempDir(), diskqueue.WithRetryInterval(time.Millisecond))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dq.Shutdown(context.Background())) }()
+
+	ctx := context.Background()
+	require.NoError(t, dq.ExportSpans(ctx, spans("a", "b")))
+	require.NoError(t, dq.ExportSpans(ctx, spans("c")))
+	eventuallyExported(t, exp, "a", "b", "c")
+}
+
+func TestExporterExportAfterShutdown(t *testing.T) {
+	exp := &recordingExporter{}
+	dq, err := diskqueue.New(exp, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dq.Shutdown(context.Background()))
+
+	assert.Error(t, dq.ExportSpans(context.Background(), spans("a")))
+	assert.Error(t, dq.ExportSpans(context.Background(), nil))
+	assert.Empty(t, exp.names())
+}
+
+func TestExporterResumesAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// The collector is unavailable until the process restarts.
+	down := &recordingExporter{failures: -1}
+	dq, err := diskqueue.New(down, dir, diskqueue.WithRetryInterval(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, dq.ExportSpans(ctx, spans("a")))
+	require.NoError(t, dq.ExportSpans(ctx, spans("b", "c")))
+	require.NoError(t, dq.Shutdown(ctx))
+	assert.Empty(t, down.names())
+
+	up := &recordingExporter{}
+	dq, err = diskqueue.New(up, dir)
+	require.NoError(t, err)
+	require.NoError(t, dq.ExportSpans(ctx, spans("d")))
+	eventuallyExported(t, up, "a", "b", "c", "d")
+	require.NoError(t, dq.Shutdown(ctx))
+
+	// Exported batches are not exported again.
+	again := &recordingExporter{}
+	dq, err = diskqueue.New(again, dir)
+	require.NoError(t, err)
+	require.NoError(t, dq.Shutdown(ctx))
+	assert.Empty(t, again.names())
+}
+
+// blockingExporter blocks exports until their context is done.
+type blockingExporter struct{}
+
+func (blockingExporter) ExportSpans(ctx context.Context, _ []sdktrace.ReadOnlySpan) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (blockingExporter) Shutdown(context.Context) error { return nil }
+
+func TestExporterShutdownHonorsContext(t *testing.T) {
+	dq, err := diskqueue.New(blockingExporter{}, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dq.ExportSpans(context.Background(), spans("a")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, dq.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Error(t, dq.ExportSpans(context.Background(), spans("b")), "export after shutdown")
+}
+
+func TestExporterWithBatchSpanProcessor(t *testing.T) {
+	exp := &recordingExporter{}
+	dq, err := diskqueue.New(exp, t.TempDir())
+	require.NoError(t, err)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(dq))
+
+	_, span := tp.Tracer("diskqueue").Start(context.Background(), "span")
+	span.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"span"}, exp.names())
+}
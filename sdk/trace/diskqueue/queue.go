@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue // import "go.opentelemetry.io/otel/sdk/trace/diskqueue"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// segmentExt is the file extension of segment files. Segment files are
+	// named after their sequence number.
+	segmentExt = ".seg"
+	// cursorName is the name of the file storing the read position.
+	cursorName = "cursor"
+	// headerSize is the size of the header of a record: the length and the
+	// CRC-32C checksum of its payload.
+	headerSize = 8
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errClosed    = errors.New("queue is closed")
+	errTruncated = errors.New("truncated record")
+	errChecksum  = errors.New("record checksum mismatch")
+)
+
+// position is a position in the queue.
+type position struct {
+	segment uint64
+	offset  int64
+}
+
+// segment is a segment file of the queue.
+type segment struct {
+	id   uint64
+	size int64
+}
+
+// queue is a write-ahead queue of records stored in segment files in a
+// directory. Records are appended to the last, active, segment which is
+// rotated when it would exceed its maximum size. Records are read from the
+// position of the cursor, which is persisted when records are acknowledged.
+type queue struct {
+	dir             string
+	maxBytes        int64
+	maxSegmentBytes int64
+
+	mu       sync.Mutex
+	closed   bool
+	segments []*segment
+	size     int64
+	active   *os.File
+	reader   *os.File
+	readerID uint64
+	cursor   position
+}
+
+// openQueue opens the queue stored in dir, creating dir if it does not
+// exist. Records are appended to a new segment, leaving the segments written
+// by a previous queue to be read as they were left.
+func openQueue(dir string, maxBytes, maxSegmentBytes int64) (*queue, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	q := &queue{
+		dir:             dir,
+		maxBytes:        maxBytes,
+		maxSegmentBytes: maxSegmentBytes,
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		q.segments = append(q.segments, &segment{id: id, size: info.Size()})
+	}
+	sort.Slice(q.segments, func(i, j int) bool {
+		return q.segments[i].id < q.segments[j].id
+	})
+
+	if err := q.loadCursor(); err != nil {
+		return nil, err
+	}
+	// Segments before the cursor have been read entirely.
+	for len(q.segments) > 0 && q.segments[0].id < q.cursor.segment {
+		if err := q.removeOldest(); err != nil {
+			return nil, err
+		}
+	}
+
+	var id uint64 = 1
+	if n := len(q.segments); n > 0 {
+		id = q.segments[n-1].id + 1
+	} else if q.cursor.segment > 0 {
+		id = q.cursor.segment + 1
+	}
+	if err := q.createSegment(id); err != nil {
+		return nil, err
+	}
+	if q.segments[0].id != q.cursor.segment {
+		q.cursor = position{segment: q.segments[0].id}
+	}
+	for _, s := range q.segments {
+		q.size += s.size
+	}
+	return q, nil
+}
+
+// append appends a record with payload p to the queue.
+func (q *queue) append(p []byte) error {
+	rec := make([]byte, headerSize+len(p))
+	binary.LittleEndian.PutUint32(rec, uint32(len(p)))
+	binary.LittleEndian.PutUint32(rec[4:], crc32.Checksum(p, crcTable))
+	copy(rec[headerSize:], p)
+	n := int64(len(rec))
+	if n > q.maxSegmentBytes {
+		return fmt.Errorf("record of %d bytes exceeds the segment size limit of %d bytes", n, q.maxSegmentBytes)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return errClosed
+	}
+
+	if active := q.segments[len(q.segments)-1]; active.size > 0 && active.size+n > q.maxSegmentBytes {
+		if err := q.rotate(); err != nil {
+			return err
+		}
+	}
+	for q.size+n > q.maxBytes && len(q.segments) > 1 {
+		s := q.segments[0]
+		if err := q.removeOldest(); err != nil {
+			return err
+		}
+		otel.Handle(fmt.Errorf("diskqueue: dropped segment %d of %d bytes: queue size limit exceeded", s.id, s.size))
+	}
+
+	active := q.segments[len(q.segments)-1]
+	if _, err := q.active.Write(rec); err != nil {
+		// Remove any partial write so the next records remain readable.
+		_ = q.active.Truncate(active.size)
+		return err
+	}
+	if err := q.active.Sync(); err != nil {
+		return err
+	}
+	active.size += n
+	q.size += n
+	return nil
+}
+
+// next returns the payload of the record at the cursor and the position
+// following it. It returns false if there is no record to read.
+//
+// Segments read entirely are removed. Corrupted records are skipped along
+// with the rest of their segment.
+func (q *queue) next() ([]byte, position, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.closed && len(q.segments) > 0 {
+		s := q.segments[0]
+		if q.cursor.segment != s.id {
+			q.cursor = position{segment: s.id}
+		}
+		if q.cursor.offset >= s.size {
+			if len(q.segments) == 1 {
+				// Nothing more to read from the active segment.
+				break
+			}
+			if err := q.removeOldest(); err != nil {
+				otel.Handle(err)
+				break
+			}
+			continue
+		}
+
+		p, err := q.read(s, q.cursor.offset)
+		if err != nil {
+			otel.Handle(fmt.Errorf("diskqueue: skipping the rest of segment %d from offset %d: %w", s.id, q.cursor.offset, err))
+			q.cursor.offset = s.size
+			continue
+		}
+		return p, position{segment: s.id, offset: q.cursor.offset + headerSize + int64(len(p))}, true
+	}
+	return nil, position{}, false
+}
+
+// ack moves the cursor to pos, acknowledging the records before it have
+// been processed, and persists the cursor.
+func (q *queue) ack(pos position) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return errClosed
+	}
+	if pos.segment < q.cursor.segment || (pos.segment == q.cursor.segment && pos.offset <= q.cursor.offset) {
+		// The records were dropped in the meantime.
+		return nil
+	}
+	q.cursor = pos
+	return q.saveCursor()
+}
+
+// close closes the files of the queue and persists the cursor.
+func (q *queue) close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+
+	err := q.active.Close()
+	if q.reader != nil {
+		_ = q.reader.Close()
+	}
+	if cerr := q.saveCursor(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// read reads the record of s at offset off and returns its payload.
+func (q *queue) read(s *segment, off int64) ([]byte, error) {
+	if q.reader == nil || q.readerID != s.id {
+		if q.reader != nil {
+			_ = q.reader.Close()
+		}
+		f, err := os.Open(q.segmentPath(s.id))
+		if err != nil {
+			q.reader = nil
+			return nil, err
+		}
+		q.reader, q.readerID = f, s.id
+	}
+
+	if s.size-off < headerSize {
+		return nil, errTruncated
+	}
+	var hdr [headerSize]byte
+	if _, err := q.reader.ReadAt(hdr[:], off); err != nil {
+		return nil, err
+	}
+	n := int64(binary.LittleEndian.Uint32(hdr[:]))
+	if n > s.size-off-headerSize {
+		return nil, errTruncated
+	}
+	p := make([]byte, n)
+	if _, err := q.reader.ReadAt(p, off+headerSize); err != nil {
+		return nil, err
+	}
+	if crc32.Checksum(p, crcTable) != binary.LittleEndian.Uint32(hdr[4:]) {
+		return nil, errChecksum
+	}
+	return p, nil
+}
+
+// rotate closes the active segment and creates a new one.
+func (q *queue) rotate() error {
+	if err := q.active.Close(); err != nil {
+		return err
+	}
+	return q.createSegment(q.segments[len(q.segments)-1].id + 1)
+}
+
+// createSegment creates the segment id and makes it the active segment.
+func (q *queue) createSegment(id uint64) error {
+	f, err := os.OpenFile(q.segmentPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	q.active = f
+	q.segments = append(q.segments, &segment{id: id})
+	return nil
+}
+
+// removeOldest removes the oldest segment, moving the cursor to the next
+// segment if it was in the removed one.
+func (q *queue) removeOldest() error {
+	s := q.segments[0]
+	if q.reader != nil && q.readerID == s.id {
+		_ = q.reader.Close()
+		q.reader = nil
+	}
+	if err := os.Remove(q.segmentPath(s.id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	q.segments = q.segments[1:]
+	q.size -= s.size
+	if len(q.segments) > 0 && q.cursor.segment <= s.id {
+		q.cursor = position{segment: q.segments[0].id}
+		return q.saveCursor()
+	}
+	return nil
+}
+
+func (q *queue) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, segmentExt))
+}
+
+// loadCursor reads the persisted cursor, if any.
+func (q *queue) loadCursor() error {
+	b, err := ioutil.ReadFile(filepath.Join(q.dir, cursorName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var pos position
+	if _, err := fmt.Sscan(string(b), &pos.segment, &pos.offset); err != nil {
+		// Read the queue from its start rather than losing records.
+		otel.Handle(fmt.Errorf("diskqueue: invalid cursor: %w", err))
+		return nil
+	}
+	q.cursor = pos
+	return nil
+}
+
+// saveCursor atomically persists the cursor.
+func (q *queue) saveCursor() error {
+	tmp := filepath.Join(q.dir, cursorName+".tmp")
+	b := []byte(fmt.Sprintf("%d %d\n", q.cursor.segment, q.cursor.offset))
+	if err := ioutil.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(q.dir, cursorName))
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendRecords(t *testing.T, q *queue, payloads ...string) {
+	t.Helper()
+	for _, p := range payloads {
+		require.NoError(t, q.append([]byte(p)))
+	}
+}
+
+// readAll reads and acknowledges all the records of q.
+func readAll(t *testing.T, q *queue) []string {
+	t.Helper()
+	var got []string
+	for {
+		p, pos, ok := q.next()
+		if !ok {
+			return got
+		}
+		got = append(got, string(p))
+		require.NoError(t, q.ack(pos))
+	}
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	require.NoError(t, err)
+	return files
+}
+
+func TestQueueReadsInOrder(t *testing.T) {
+	q, err := openQueue(t.TempDir(), 1<<20, 1<<10)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+
+	appendRecords(t, q, "a", "b")
+	assert.Equal(t, []string{"a", "b"}, readAll(t, q))
+	appendRecords(t, q, "c")
+	assert.Equal(t, []string{"c"}, readAll(t, q))
+}
+
+func TestQueueNextWithoutAckRereads(t *testing.T) {
+	q, err := openQueue(t.TempDir(), 1<<20, 1<<10)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+
+	appendRecords(t, q, "a")
+	p, _, ok := q.next()
+	require.True(t, ok)
+	assert.Equal(t, "a", string(p))
+	p, _, ok = q.next()
+	require.True(t, ok)
+	assert.Equal(t, "a", string(p))
+}
+
+func TestQueueRotatesSegments(t *testing.T) {
+	dir := t.TempDir()
+	// Segments hold two records with a 1 byte payload.
+	q, err := openQueue(dir, 1<<20, 2*(headerSize+1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+
+	appendRecords(t, q, "a", "b", "c", "d", "e")
+	assert.Len(t, segmentFiles(t, dir), 3)
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, readAll(t, q))
+	assert.Len(t, segmentFiles(t, dir), 1, "segments read entirely are not removed")
+}
+
+func TestQueueDropsOldestSegments(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 4*(headerSize+1), 2*(headerSize+1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+
+	appendRecords(t, q, "a", "b", "c", "d", "e", "f")
+	assert.Equal(t, []string{"c", "d", "e", "f"}, readAll(t, q))
+}
+
+func TestQueueRejectsOversizedRecords(t *testing.T) {
+	q, err := openQueue(t.TempDir(), 1<<20, 2*(headerSize+1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+
+	assert.Error(t, q.append([]byte("abcdefghijk")))
+}
+
+func TestQueueResumesFromCursor(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 1<<20, 1<<10)
+	require.NoError(t, err)
+	appendRecords(t, q, "a", "b")
+	_, pos, ok := q.next()
+	require.True(t, ok)
+	require.NoError(t, q.ack(pos))
+	require.NoError(t, q.close())
+
+	q, err = openQueue(dir, 1<<20, 1<<10)
+	require.NoError(t, err)
+	appendRecords(t, q, "c")
+	assert.Equal(t, []string{"b", "c"}, readAll(t, q))
+	require.NoError(t, q.close())
+
+	q, err = openQueue(dir, 1<<20, 1<<10)
+	require.NoError(t, err)
+	assert.Empty(t, readAll(t, q))
+	require.NoError(t, q.close())
+	assert.Len(t, segmentFiles(t, dir), 1)
+}
+
+func TestQueueSkipsCorruptedRecords(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 1<<20, 3*(headerSize+1))
+	require.NoError(t, err)
+	appendRecords(t, q, "a", "b", "c", "d", "e", "f")
+	require.NoError(t, q.close())
+
+	// Corrupt the payload of "b" in the first segment.
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 2)
+	b, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	b[2*headerSize+1] = 'x'
+	require.NoError(t, ioutil.WriteFile(files[0], b, 0o600))
+
+	// Truncate "f" in the second segment.
+	require.NoError(t, os.Truncate(files[1], 2*(headerSize+1)+headerSize))
+
+	q, err = openQueue(dir, 1<<20, 3*(headerSize+1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+	appendRecords(t, q, "g")
+	assert.Equal(t, []string{"a", "d", "e", "g"}, readAll(t, q))
+}
+
+func TestQueueIgnoresInvalidCursor(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 1<<20, 1<<10)
+	require.NoError(t, err)
+	appendRecords(t, q, "a")
+	require.NoError(t, q.close())
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, cursorName), []byte("invalid"), 0o600))
+
+	q, err = openQueue(dir, 1<<20, 1<<10)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, q.close()) }()
+	assert.Equal(t, []string{"a"}, readAll(t, q))
+}
+
+func TestQueueClosed(t *testing.T) {
+	q, err := openQueue(t.TempDir(), 1<<20, 1<<10)
+	require.NoError(t, err)
+	require.NoError(t, q.close())
+
+	assert.ErrorIs(t, q.append([]byte("a")), errClosed)
+	_, _, ok := q.next()
+	assert.False(t, ok)
+	assert.NoError(t, q.close())
+}
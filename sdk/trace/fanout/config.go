@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout // import "go.opentelemetry.io/otel/sdk/trace/fanout"
+
+import (
+	"fmt"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// DefaultTimeout is the default maximum duration of the exports of a
+// wrapped exporter.
+const DefaultTimeout = 30 * time.Second
+
+// config contains configuration for an Exporter.
+type config struct {
+	targets []targetConfig
+}
+
+// Option applies a configuration option to an Exporter.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithExporter adds exporter to the exporters a batch is exported with,
+// configured with opts. This option can be used multiple times to add more
+// exporters.
+func WithExporter(exporter sdktrace.SpanExporter, opts ...ExporterOption) Option {
+	return optionFunc(func(c *config) {
+		tc := targetConfig{
+			name:     fmt.Sprintf("%T", exporter),
+			exporter: exporter,
+			timeout:  DefaultTimeout,
+		}
+		for _, o := range opts {
+			o.apply(&tc)
+		}
+		c.targets = append(c.targets, tc)
+	})
+}
+
+// targetConfig contains configuration for a wrapped exporter.
+type targetConfig struct {
+	name     string
+	exporter sdktrace.SpanExporter
+	timeout  time.Duration
+}
+
+// ExporterOption applies a configuration option to a wrapped exporter.
+type ExporterOption interface {
+	apply(*targetConfig)
+}
+
+type exporterOptionFunc func(*targetConfig)
+
+func (fn exporterOptionFunc) apply(c *targetConfig) {
+	fn(c)
+}
+
+// WithName sets the name of the exporter used in the errors it reports. By
+// default, the type of the exporter is used.
+func WithName(name string) ExporterOption {
+	return exporterOptionFunc(func(c *targetConfig) {
+		c.name = name
+	})
+}
+
+// WithTimeout sets the maximum duration of the exports of the exporter. The
+// exports are also bounded by the context passed to ExportSpans.
+// Non-positive values are ignored. By default, DefaultTimeout is used.
+func WithTimeout(d time.Duration) ExporterOption {
+	return exporterOptionFunc(func(c *targetConfig) {
+		if d > 0 {
+			c.timeout = d
+		}
+	})
+}
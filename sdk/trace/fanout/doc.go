@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fanout provides a SpanExporter that exports each batch of spans
+// with multiple SpanExporters.
+//
+// The wrapped exporters export each batch concurrently, each with its own
+// timeout, and the export completes once all of them have returned. A
+// failing exporter therefore does not prevent the others from exporting,
+// and a slow exporter delays the export by at most its timeout, provided it
+// honors the context of its exports. The errors of all the failed exports
+// are returned.
+//
+// A single BatchSpanProcessor can then export to multiple backends:
+//
+//	exp := fanout.New(
+//		fanout.WithExporter(otlpExporter, fanout.WithName("vendor")),
+//		fanout.WithExporter(jaegerExporter, fanout.WithName("jaeger"), fanout.WithTimeout(time.Second)),
+//	)
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp))
+package fanout // import "go.opentelemetry.io/otel/sdk/trace/fanout"
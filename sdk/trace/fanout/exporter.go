@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout // import "go.opentelemetry.io/otel/sdk/trace/fanout"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var errShutdown = errors.New("fanout: exporter is shut down")
+
+// Exporter is a SpanExporter that exports each batch of spans with multiple
+// SpanExporters concurrently.
+type Exporter struct {
+	targets []targetConfig
+
+	mu       sync.RWMutex
+	stopped  bool
+	stopOnce sync.Once
+}
+
+var _ sdktrace.SpanExporter = (*Exporter)(nil)
+
+// New returns an Exporter exporting with the exporters added with the
+// WithExporter option.
+func New(opts ...Option) *Exporter {
+	var cfg config
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+	return &Exporter{targets: cfg.targets}
+}
+
+// ExportSpans exports spans with all the wrapped exporters concurrently and
+// waits for all the exports to complete. Each export is bounded by ctx and
+// by the timeout of its exporter. The errors of all the failed exports are
+// returned.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.RLock()
+	stopped := e.stopped
+	e.mu.RUnlock()
+	if stopped {
+		return errShutdown
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	return e.each(func(t targetConfig) error {
+		ctx, cancel := context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+		return t.exporter.ExportSpans(ctx, spans)
+	}, "multiple errors exporting spans")
+}
+
+// Shutdown shuts down all the wrapped exporters concurrently. The errors of
+// all the exporters are returned.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	var err error
+	e.stopOnce.Do(func() {
+		e.mu.Lock()
+		e.stopped = true
+		e.mu.Unlock()
+
+		err = e.each(func(t targetConfig) error {
+			return t.exporter.Shutdown(ctx)
+		}, "multiple errors shutting down exporters")
+	})
+	return err
+}
+
+// each calls f concurrently for all the targets of e, and returns their
+// errors joined with msg.
+func (e *Exporter) each(f func(targetConfig) error, msg string) error {
+	errs := make([]error, len(e.targets))
+	var wg sync.WaitGroup
+	for i, t := range e.targets {
+		wg.Add(1)
+		go func(i int, t targetConfig) {
+			defer wg.Done()
+			if err := f(t); err != nil {
+				errs[i] = fmt.Errorf("exporter %q: %w", t.name, err)
+			}
+		}(i, t)
+	}
+	wg.Wait()
+	return joinErrors(msg, errs)
+}
+
+// joinErrors returns an error holding msg and the messages of the non-nil
+// errs. A single error is returned as is.
+func joinErrors(msg string, errs []error) error {
+	var msgs []string
+	var last error
+	for _, err := range errs {
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			last = err
+		}
+	}
+	switch len(msgs) {
+	case 0:
+		return nil
+	case 1:
+		return last
+	}
+	return fmt.Errorf("%s: %s", msg, strings.Join(msgs, "; "))
+}
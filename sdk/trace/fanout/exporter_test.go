@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/fanout"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordingExporter records the names of the spans it exports. Exports
+// signal its started channel, block while its release channel is open, and
+// fail with its err.
+type recordingExporter struct {
+	started     chan struct{}
+	release     chan struct{}
+	err         error
+	shutdownErr error
+
+	mu    sync.Mutex
+	names []string
+}
+
+func (e *recordingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if e.started != nil {
+		e.started <- struct{}{}
+	}
+	if e.release != nil {
+		select {
+		case <-e.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if e.err != nil {
+		return e.err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		e.names = append(e.names, s.Name())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error { return e.shutdownErr }
+
+func (e *recordingExporter) exported() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.names...)
+}
+
+func spans(names ...string) []sdktrace.ReadOnlySpan {
+	stubs := make(tracetest.SpanStubs, len(names))
+	for i, n := range names {
+		stubs[i].Name = n
+	}
+	return stubs.Snapshots()
+}
+
+func TestExporterFansOut(t *testing.T) {
+	a, b := &recordingExporter{}, &recordingExporter{}
+	exp := fanout.New(fanout.WithExporter(a), fanout.WithExporter(b))
+
+	ctx := context.Background()
+	require.NoError(t, exp.ExportSpans(ctx, spans("1", "2")))
+	require.NoError(t, exp.ExportSpans(ctx, spans("3")))
+	assert.Equal(t, []string{"1", "2", "3"}, a.exported())
+	assert.Equal(t, []string{"1", "2", "3"}, b.exported())
+
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Error(t, exp.ExportSpans(ctx, spans("4")), "export after shutdown")
+}
+
+func TestExporterExportsConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	a := &recordingExporter{started: make(chan struct{}, 1), release: release}
+	b := &recordingExporter{started: make(chan struct{}, 1), release: release}
+	exp := fanout.New(fanout.WithExporter(a), fanout.WithExporter(b))
+
+	done := make(chan error, 1)
+	go func() { done <- exp.ExportSpans(context.Background(), spans("1")) }()
+
+	// Both exports are started before either of them completes.
+	<-a.started
+	<-b.started
+	select {
+	case <-done:
+		t.Fatal("ExportSpans returned before the exports completed")
+	default:
+	}
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"1"}, a.exported())
+	assert.Equal(t, []string{"1"}, b.exported())
+}
+
+func TestExporterReturnsErrors(t *testing.T) {
+	a := &recordingExporter{err: errors.New("unavailable")}
+	ok := &recordingExporter{}
+	c := &recordingExporter{err: errors.New("rejected")}
+	exp := fanout.New(
+		fanout.WithExporter(a, fanout.WithName("a")),
+		fanout.WithExporter(ok),
+		fanout.WithExporter(c, fanout.WithName("c")),
+	)
+
+	err := exp.ExportSpans(context.Background(), spans("1"))
+	assert.EqualError(t, err, `multiple errors exporting spans: exporter "a": unavailable; exporter "c": rejected`)
+	// A failing exporter does not prevent the others from exporting.
+	assert.Equal(t, []string{"1"}, ok.exported())
+}
+
+func TestExporterTimeout(t *testing.T) {
+	stuck := &recordingExporter{release: make(chan struct{})}
+	ok := &recordingExporter{}
+	exp := fanout.New(
+		fanout.WithExporter(stuck, fanout.WithName("stuck"), fanout.WithTimeout(time.Millisecond)),
+		fanout.WithExporter(ok),
+	)
+
+	err := exp.ExportSpans(context.Background(), spans("1"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, `exporter "stuck": context deadline exceeded`)
+	assert.Equal(t, []string{"1"}, ok.exported())
+}
+
+func TestExporterHonorsContext(t *testing.T) {
+	stuck := &recordingExporter{release: make(chan struct{})}
+	exp := fanout.New(fanout.WithExporter(stuck, fanout.WithName("stuck")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, exp.ExportSpans(ctx, spans("1")), context.DeadlineExceeded)
+}
+
+func TestExporterShutdownAggregatesErrors(t *testing.T) {
+	a := &recordingExporter{shutdownErr: errors.New("a failed")}
+	b := &recordingExporter{}
+	c := &recordingExporter{shutdownErr: errors.New("c failed")}
+	exp := fanout.New(
+		fanout.WithExporter(a, fanout.WithName("a")),
+		fanout.WithExporter(b, fanout.WithName("b")),
+		fanout.WithExporter(c, fanout.WithName("c")),
+	)
+
+	err := exp.Shutdown(context.Background())
+	assert.EqualError(t, err, `multiple errors shutting down exporters: exporter "a": a failed; exporter "c": c failed`)
+	assert.NoError(t, exp.Shutdown(context.Background()), "second shutdown")
+}
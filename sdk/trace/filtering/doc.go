@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filtering provides a SpanProcessor that drops the ended spans
+// matching a Predicate instead of passing them to another SpanProcessor.
+//
+// Predicates match spans on their name, kind, instrumentation library,
+// status code, duration, or attributes, and are combined with And, Or, and
+// Not. For example, the following drops the server spans of health checks:
+//
+//	drop := filtering.And(
+//		filtering.Kind(trace.SpanKindServer),
+//		filtering.Name("GET /healthz", "GET /readyz"),
+//	)
+//	sp := filtering.NewSpanProcessor(sdktrace.NewBatchSpanProcessor(exp), drop)
+package filtering // import "go.opentelemetry.io/otel/sdk/trace/filtering"
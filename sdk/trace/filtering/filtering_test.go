@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/filtering"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPredicates(t *testing.T) {
+	start := time.Now()
+	s := tracetest.SpanStub{
+		Name:      "GET /healthz",
+		SpanKind:  trace.SpanKindServer,
+		StartTime: start,
+		EndTime:   start.Add(10 * time.Millisecond),
+		Attributes: []attribute.KeyValue{
+			attribute.String("http.target", "/healthz"),
+			attribute.StringSlice("tags", []string{"a", "b"}),
+		},
+		Status:                 sdktrace.Status{Code: codes.Ok},
+		InstrumentationLibrary: instrumentation.Library{Name: "net/http"},
+	}.Snapshot()
+
+	tests := []struct {
+		name      string
+		predicate filtering.Predicate
+		want      bool
+	}{
+		{"Name", filtering.Name("GET /readyz", "GET /healthz"), true},
+		{"NameMismatch", filtering.Name("GET /readyz"), false},
+		{"NameMatches", filtering.NameMatches(regexp.MustCompile(`/(healthz|readyz)$`)), true},
+		{"NameMatchesMismatch", filtering.NameMatches(regexp.MustCompile(`^POST`)), false},
+		{"Kind", filtering.Kind(trace.SpanKindClient, trace.SpanKindServer), true},
+		{"KindMismatch", filtering.Kind(trace.SpanKindInternal), false},
+		{"InstrumentationLibrary", filtering.InstrumentationLibrary("net/http"), true},
+		{"InstrumentationLibraryMismatch", filtering.InstrumentationLibrary("grpc"), false},
+		{"StatusCode", filtering.StatusCode(codes.Unset, codes.Ok), true},
+		{"StatusCodeMismatch", filtering.StatusCode(codes.Error), false},
+		{"Duration", filtering.Duration(0, 100*time.Millisecond), true},
+		{"DurationUnbounded", filtering.Duration(10*time.Millisecond, 0), true},
+		{"DurationTooLong", filtering.Duration(0, 10*time.Millisecond), false},
+		{"DurationTooShort", filtering.Duration(time.Second, 0), false},
+		{"AttributeKey", filtering.Attribute("http.target"), true},
+		{"AttributeValue", filtering.Attribute("http.target", attribute.StringValue("/readyz"), attribute.StringValue("/healthz")), true},
+		{"AttributeSliceValue", filtering.Attribute("tags", attribute.StringSliceValue([]string{"a", "b"})), true},
+		{"AttributeValueMismatch", filtering.Attribute("http.target", attribute.StringValue("/")), false},
+		{"AttributeKeyMismatch", filtering.Attribute("http.method"), false},
+		{"And", filtering.And(filtering.Kind(trace.SpanKindServer), filtering.Name("GET /healthz")), true},
+		{"AndMismatch", filtering.And(filtering.Kind(trace.SpanKindServer), filtering.Name("GET /")), false},
+		{"Or", filtering.Or(filtering.Name("GET /"), filtering.Kind(trace.SpanKindServer)), true},
+		{"OrMismatch", filtering.Or(filtering.Name("GET /"), filtering.Kind(trace.SpanKindClient)), false},
+		{"Not", filtering.Not(filtering.Name("GET /")), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.predicate(s))
+		})
+	}
+}
+
+func TestSpanProcessor(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	var onDrop []string
+	sp := filtering.NewSpanProcessor(
+		sdktrace.NewSimpleSpanProcessor(exp),
+		filtering.And(filtering.Kind(trace.SpanKindServer), filtering.Name("GET /healthz")),
+		filtering.WithOnDrop(func(s sdktrace.ReadOnlySpan) { onDrop = append(onDrop, s.Name()) }),
+	)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp))
+	tracer := tp.Tracer("filtering")
+
+	ctx := context.Background()
+	for _, name := range []string{"GET /healthz", "GET /users", "GET /healthz"} {
+		_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+		span.End()
+	}
+	_, span := tracer.Start(ctx, "GET /healthz", trace.WithSpanKind(trace.SpanKindClient))
+	span.End()
+
+	var names []string
+	for _, s := range exp.GetSpans() {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"GET /users", "GET /healthz"}, names)
+	assert.Equal(t, uint64(2), sp.Dropped())
+
+	assert.Equal(t, []string{"GET /healthz", "GET /healthz"}, onDrop)
+
+	require.NoError(t, tp.Shutdown(ctx))
+}
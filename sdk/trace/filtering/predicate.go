@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering // import "go.opentelemetry.io/otel/sdk/trace/filtering"
+
+import (
+	"reflect"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Predicate reports whether an ended span matches.
+type Predicate func(sdktrace.ReadOnlySpan) bool
+
+// Name matches spans named one of names.
+func Name(names ...string) Predicate {
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		set[n] = struct{}{}
+	}
+	return func(s sdktrace.ReadOnlySpan) bool {
+		_, ok := set[s.Name()]
+		return ok
+	}
+}
+
+// NameMatches matches spans whose name matches re.
+func NameMatches(re *regexp.Regexp) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return re.MatchString(s.Name())
+	}
+}
+
+// Kind matches spans of one of kinds.
+func Kind(kinds ...trace.SpanKind) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		for _, k := range kinds {
+			if s.SpanKind() == k {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// InstrumentationLibrary matches spans created by a Tracer of one of the
+// named instrumentation libraries.
+func InstrumentationLibrary(names ...string) Predicate {
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		set[n] = struct{}{}
+	}
+	return func(s sdktrace.ReadOnlySpan) bool {
+		_, ok := set[s.InstrumentationLibrary().Name]
+		return ok
+	}
+}
+
+// StatusCode matches spans with one of the status codes cs.
+func StatusCode(cs ...codes.Code) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		for _, c := range cs {
+			if s.Status().Code == c {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Duration matches spans lasting at least min and less than max. A
+// non-positive max sets no upper bound.
+func Duration(min, max time.Duration) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		d := s.EndTime().Sub(s.StartTime())
+		return d >= min && (max <= 0 || d < max)
+	}
+}
+
+// Attribute matches spans having an attribute with key and one of values,
+// or with key and any value if no values are passed.
+func Attribute(key attribute.Key, values ...attribute.Value) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		for _, kv := range s.Attributes() {
+			if kv.Key != key {
+				continue
+			}
+			if len(values) == 0 {
+				return true
+			}
+			for _, v := range values {
+				if equal(kv.Value, v) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// equal reports whether a and b hold the same value. Slice values are
+// compared element-wise.
+func equal(a, b attribute.Value) bool {
+	return a.Type() == b.Type() && reflect.DeepEqual(a.AsInterface(), b.AsInterface())
+}
+
+// And matches spans matched by all of predicates.
+func And(predicates ...Predicate) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		for _, p := range predicates {
+			if !p(s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches spans matched by any of predicates.
+func Or(predicates ...Predicate) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		for _, p := range predicates {
+			if p(s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches spans not matched by p.
+func Not(p Predicate) Predicate {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return !p(s)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering // import "go.opentelemetry.io/otel/sdk/trace/filtering"
+
+import (
+	"context"
+	"sync/atomic"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// config contains configuration for a SpanProcessor.
+type config struct {
+	onDrop func(sdktrace.ReadOnlySpan)
+}
+
+// Option applies a configuration option to a SpanProcessor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithOnDrop sets a function called with each span dropped by the
+// SpanProcessor. It is called synchronously and must not block.
+func WithOnDrop(f func(sdktrace.ReadOnlySpan)) Option {
+	return optionFunc(func(c *config) {
+		c.onDrop = f
+	})
+}
+
+// SpanProcessor is a SpanProcessor that drops the ended spans matching a
+// Predicate and passes the others to another SpanProcessor.
+type SpanProcessor struct {
+	// dropped is the number of dropped spans. It is accessed atomically
+	// and is kept first to be 64-bit aligned.
+	dropped uint64
+
+	next   sdktrace.SpanProcessor
+	drop   Predicate
+	onDrop func(sdktrace.ReadOnlySpan)
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor that drops the ended spans
+// matching drop and passes the others to next.
+func NewSpanProcessor(next sdktrace.SpanProcessor, drop Predicate, opts ...Option) *SpanProcessor {
+	var cfg config
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+	return &SpanProcessor{
+		next:   next,
+		drop:   drop,
+		onDrop: cfg.onDrop,
+	}
+}
+
+// OnStart passes s to the wrapped SpanProcessor. Spans are only filtered
+// once they have ended.
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd drops s if it matches the Predicate of p, otherwise it passes s to
+// the wrapped SpanProcessor.
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if p.drop(s) {
+		atomic.AddUint64(&p.dropped, 1)
+		if p.onDrop != nil {
+			p.onDrop(s)
+		}
+		return
+	}
+	p.next.OnEnd(s)
+}
+
+// Shutdown shuts down the wrapped SpanProcessor.
+func (p *SpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped SpanProcessor.
+func (p *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// Dropped returns the number of spans dropped by p.
+func (p *SpanProcessor) Dropped() uint64 {
+	return atomic.LoadUint64(&p.dropped)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
+
+import (
+	"net/http"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Defaults for the Sampler configuration.
+const (
+	// DefaultSamplingServerURL is the sampling endpoint of a local Jaeger
+	// agent.
+	DefaultSamplingServerURL = "http://localhost:5778/sampling"
+	// DefaultSamplingRefreshInterval is the period between two fetches of
+	// the sampling strategy.
+	DefaultSamplingRefreshInterval = time.Minute
+	// DefaultMaxOperations is the maximum number of operations tracked by a
+	// per-operation strategy.
+	DefaultMaxOperations = 2000
+	// DefaultInitialSamplingRate is the sampling rate of the default
+	// initial sampler.
+	DefaultInitialSamplingRate = 0.001
+)
+
+// config contains configuration for a Sampler.
+type config struct {
+	serverURL       string
+	refreshInterval time.Duration
+	initialSampler  sdktrace.Sampler
+	maxOperations   int
+	client          *http.Client
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		serverURL:       DefaultSamplingServerURL,
+		refreshInterval: DefaultSamplingRefreshInterval,
+		initialSampler:  sdktrace.TraceIDRatioBased(DefaultInitialSamplingRate),
+		maxOperations:   DefaultMaxOperations,
+		client:          http.DefaultClient,
+	}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	return c
+}
+
+// Option applies a configuration option to a Sampler.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithSamplingServerURL sets the URL of the endpoint serving sampling
+// strategies. The service name is passed to it with the "service" query
+// parameter. By default, DefaultSamplingServerURL is used.
+func WithSamplingServerURL(url string) Option {
+	return optionFunc(func(c *config) {
+		c.serverURL = url
+	})
+}
+
+// WithSamplingRefreshInterval sets the period between two fetches of the
+// sampling strategy. Non-positive values are ignored. By default,
+// DefaultSamplingRefreshInterval is used.
+func WithSamplingRefreshInterval(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.refreshInterval = d
+		}
+	})
+}
+
+// WithInitialSampler sets the sampler used until a sampling strategy has been
+// fetched. By default, a TraceIDRatioBased sampler with a rate of
+// DefaultInitialSamplingRate is used.
+func WithInitialSampler(s sdktrace.Sampler) Option {
+	return optionFunc(func(c *config) {
+		if s != nil {
+			c.initialSampler = s
+		}
+	})
+}
+
+// WithMaxOperations sets the maximum number of operations a per-operation
+// strategy tracks. Spans of operations beyond this limit are sampled with
+// the default sampling probability of the strategy. By default,
+// DefaultMaxOperations is used.
+func WithMaxOperations(n int) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxOperations = n
+		}
+	})
+}
+
+// WithHTTPClient sets the HTTP client used to fetch sampling strategies. By
+// default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		if client != nil {
+			c.client = client
+		}
+	})
+}
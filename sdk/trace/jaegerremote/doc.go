@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jaegerremote provides a Sampler that periodically fetches its
+// sampling strategy from a Jaeger agent or collector.
+//
+// The sampling strategies managed by the remote endpoint are applied as
+// follows:
+//
+//   - probabilistic strategies sample a fixed fraction of traces based on
+//     their trace ID.
+//   - rate-limiting strategies sample at most a fixed number of traces per
+//     second.
+//   - per-operation strategies sample each span name with its own
+//     probability while guaranteeing a lower-bound rate of sampled traces
+//     per operation.
+//
+// Until a strategy has been fetched successfully, the initial sampler is
+// used. If later fetches fail, the last fetched strategy remains in effect.
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
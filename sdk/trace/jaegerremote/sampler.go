@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Sampler is a Sampler that delegates its decisions to the sampling strategy
+// periodically fetched from a Jaeger sampling endpoint.
+type Sampler struct {
+	lock    sync.RWMutex
+	sampler sdktrace.Sampler
+
+	fetcher         fetcher
+	refreshInterval time.Duration
+	maxOperations   int
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ sdktrace.Sampler = (*Sampler)(nil)
+
+// New returns a Sampler for the service serviceName. It immediately starts
+// fetching the sampling strategy of the service in the background, and
+// refreshes it periodically until Close is called.
+func New(serviceName string, opts ...Option) *Sampler {
+	cfg := newConfig(opts...)
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Sampler{
+		sampler: cfg.initialSampler,
+		fetcher: fetcher{
+			client:      cfg.client,
+			url:         cfg.serverURL,
+			serviceName: serviceName,
+		},
+		refreshInterval: cfg.refreshInterval,
+		maxOperations:   cfg.maxOperations,
+		cancel:          cancel,
+		done:            make(chan struct{}),
+	}
+	go s.poll(ctx)
+	return s
+}
+
+// ShouldSample returns the sampling decision of the current sampling
+// strategy.
+func (s *Sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.lock.RLock()
+	sampler := s.sampler
+	s.lock.RUnlock()
+	return sampler.ShouldSample(p)
+}
+
+// Description returns a description of the current sampling strategy.
+func (s *Sampler) Description() string {
+	s.lock.RLock()
+	sampler := s.sampler
+	s.lock.RUnlock()
+	return fmt.Sprintf("JaegerRemoteSampler{%s}", sampler.Description())
+}
+
+// Close stops refreshing the sampling strategy. The last fetched strategy
+// remains in use.
+func (s *Sampler) Close() {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		<-s.done
+	})
+}
+
+func (s *Sampler) poll(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	s.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+// refresh fetches the sampling strategy and applies it. Errors are sent to
+// the global error handler and leave the current sampler in place.
+func (s *Sampler) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.refreshInterval)
+	defer cancel()
+
+	strategy, err := s.fetcher.fetch(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			otel.Handle(fmt.Errorf("jaegerremote: failed to fetch sampling strategy: %w", err))
+		}
+		return
+	}
+	s.update(strategy)
+}
+
+// update replaces the current sampler with one applying strategy. Samplers
+// holding state, such as rate limiters, are updated in place when possible.
+func (s *Sampler) update(strategy *strategyResponse) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch {
+	case strategy.OperationSampling != nil:
+		if current, ok := s.sampler.(*perOperationSampler); ok {
+			current.update(strategy.OperationSampling)
+			return
+		}
+		s.sampler = newPerOperationSampler(strategy.OperationSampling, s.maxOperations)
+	case strategy.ProbabilisticSampling != nil:
+		s.sampler = sdktrace.TraceIDRatioBased(strategy.ProbabilisticSampling.SamplingRate)
+	case strategy.RateLimitingSampling != nil:
+		rate := strategy.RateLimitingSampling.MaxTracesPerSecond
+		if current, ok := s.sampler.(*rateLimitingSampler); ok && current.maxTracesPerSecond == rate {
+			return
+		}
+		s.sampler = newRateLimitingSampler(rate)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// strategyServer serves a replaceable sampling strategy.
+type strategyServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	status   int
+	strategy string
+	services []string
+}
+
+func newStrategyServer(t *testing.T, strategy string) *strategyServer {
+	s := &strategyServer{status: http.StatusOK, strategy: strategy}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.services = append(s.services, r.URL.Query().Get("service"))
+		w.WriteHeader(s.status)
+		_, _ = fmt.Fprint(w, s.strategy)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *strategyServer) set(status int, strategy string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.status = status
+	s.strategy = strategy
+}
+
+func newTestSampler(t *testing.T, srv *strategyServer, opts ...Option) *Sampler {
+	opts = append([]Option{
+		WithSamplingServerURL(srv.URL),
+		WithSamplingRefreshInterval(10 * time.Millisecond),
+		WithInitialSampler(sdktrace.NeverSample()),
+	}, opts...)
+	s := New("test-service", opts...)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func eventuallyDescribed(t *testing.T, s *Sampler, want string) {
+	require.Eventually(t, func() bool {
+		return s.Description() == want
+	}, time.Second, 5*time.Millisecond, "last description: %s", s.Description())
+}
+
+func params(name string) sdktrace.SamplingParameters {
+	return sdktrace.SamplingParameters{
+		ParentContext: trace.ContextWithSpanContext(context.Background(), trace.SpanContext{}),
+		TraceID:       trace.TraceID{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Name:          name,
+	}
+}
+
+func TestSamplerDefaults(t *testing.T) {
+	c := newConfig()
+	assert.Equal(t, DefaultSamplingServerURL, c.serverURL)
+	assert.Equal(t, DefaultSamplingRefreshInterval, c.refreshInterval)
+	assert.Equal(t, DefaultMaxOperations, c.maxOperations)
+	assert.Equal(t, sdktrace.TraceIDRatioBased(DefaultInitialSamplingRate).Description(), c.initialSampler.Description())
+}
+
+func TestSamplerInitialSamplerWhenUnreachable(t *testing.T) {
+	srv := newStrategyServer(t, "")
+	srv.set(http.StatusInternalServerError, "")
+
+	s := newTestSampler(t, srv)
+	require.Eventually(t, func() bool {
+		srv.lock.Lock()
+		defer srv.lock.Unlock()
+		return len(srv.services) > 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "JaegerRemoteSampler{AlwaysOffSampler}", s.Description())
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params("op")).Decision)
+}
+
+func TestSamplerProbabilistic(t *testing.T) {
+	srv := newStrategyServer(t, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.5}}`)
+
+	s := newTestSampler(t, srv)
+	eventuallyDescribed(t, s, "JaegerRemoteSampler{TraceIDRatioBased{0.5}}")
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("op")).Decision)
+
+	srv.lock.Lock()
+	assert.Equal(t, "test-service", srv.services[0])
+	srv.lock.Unlock()
+
+	// The last strategy is kept when the endpoint becomes unavailable.
+	srv.set(http.StatusServiceUnavailable, "")
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, "JaegerRemoteSampler{TraceIDRatioBased{0.5}}", s.Description())
+
+	srv.set(http.StatusOK, `{"probabilisticSampling":{"samplingRate":0.25}}`)
+	eventuallyDescribed(t, s, "JaegerRemoteSampler{TraceIDRatioBased{0.25}}")
+}
+
+func TestSamplerRateLimiting(t *testing.T) {
+	srv := newStrategyServer(t, `{"strategyType":"RATE_LIMITING","rateLimitingSampling":{"maxTracesPerSecond":2}}`)
+
+	s := newTestSampler(t, srv)
+	eventuallyDescribed(t, s, "JaegerRemoteSampler{RateLimitingSampler{2}}")
+
+	// Refreshing the same strategy keeps the rate limiter state.
+	s.lock.RLock()
+	rls := s.sampler.(*rateLimitingSampler)
+	s.lock.RUnlock()
+	time.Sleep(30 * time.Millisecond)
+	s.lock.RLock()
+	assert.Same(t, rls, s.sampler)
+	s.lock.RUnlock()
+}
+
+func TestSamplerPerOperation(t *testing.T) {
+	srv := newStrategyServer(t, `{
+		"strategyType": "PROBABILISTIC",
+		"operationSampling": {
+			"defaultSamplingProbability": 0,
+			"defaultLowerBoundTracesPerSecond": 0,
+			"perOperationStrategies": [
+				{"operation": "sampled", "probabilisticSampling": {"samplingRate": 1}}
+			]
+		}
+	}`)
+
+	s := newTestSampler(t, srv)
+	eventuallyDescribed(t, s, "JaegerRemoteSampler{PerOperationSampler{defaultProbability:0,lowerBound:0,maxOperations:2000}}")
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("sampled")).Decision)
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params("other")).Decision)
+}
+
+func TestSamplerClose(t *testing.T) {
+	srv := newStrategyServer(t, `{"probabilisticSampling":{"samplingRate":1}}`)
+	s := newTestSampler(t, srv)
+	eventuallyDescribed(t, s, "JaegerRemoteSampler{AlwaysOnSampler}")
+	s.Close()
+	// Close is idempotent.
+	s.Close()
+
+	srv.set(http.StatusOK, `{"probabilisticSampling":{"samplingRate":0}}`)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, "JaegerRemoteSampler{AlwaysOnSampler}", s.Description())
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/internal/ratelimit"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// decide returns the SamplingResult of a sampling decision.
+func decide(p sdktrace.SamplingParameters, sampled bool) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if sampled {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// rateLimitingSampler samples at most maxTracesPerSecond traces per second.
+type rateLimitingSampler struct {
+	maxTracesPerSecond float64
+	limiter            *ratelimit.Limiter
+}
+
+var _ sdktrace.Sampler = (*rateLimitingSampler)(nil)
+
+func newRateLimitingSampler(maxTracesPerSecond float64) *rateLimitingSampler {
+	return &rateLimitingSampler{
+		maxTracesPerSecond: maxTracesPerSecond,
+		limiter:            ratelimit.New(maxTracesPerSecond, math.Max(maxTracesPerSecond, 1)),
+	}
+}
+
+func (s *rateLimitingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return decide(p, s.limiter.Allow())
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{%g}", s.maxTracesPerSecond)
+}
+
+// guaranteedThroughputSampler samples with a probabilistic sampler, but
+// ensures at least lowerBound traces per second are sampled.
+type guaranteedThroughputSampler struct {
+	probability float64
+	lowerBound  float64
+
+	probabilistic sdktrace.Sampler
+	limiter       *ratelimit.Limiter
+}
+
+func newGuaranteedThroughputSampler(probability, lowerBound float64) *guaranteedThroughputSampler {
+	return &guaranteedThroughputSampler{
+		probability:   probability,
+		lowerBound:    lowerBound,
+		probabilistic: sdktrace.TraceIDRatioBased(probability),
+		limiter:       ratelimit.New(lowerBound, 1),
+	}
+}
+
+func (s *guaranteedThroughputSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	res := s.probabilistic.ShouldSample(p)
+	// Always consult the limiter so traces sampled probabilistically count
+	// towards the lower bound.
+	allowed := s.limiter.Allow()
+	if res.Decision == sdktrace.RecordAndSample {
+		return res
+	}
+	return decide(p, allowed)
+}
+
+// perOperationSampler samples spans with a guaranteedThroughputSampler per
+// span name.
+type perOperationSampler struct {
+	lock sync.RWMutex
+
+	maxOperations      int
+	defaultProbability float64
+	lowerBound         float64
+	defaultSampler     sdktrace.Sampler
+	operations         map[string]*guaranteedThroughputSampler
+}
+
+var _ sdktrace.Sampler = (*perOperationSampler)(nil)
+
+func newPerOperationSampler(strategies *perOperationStrategies, maxOperations int) *perOperationSampler {
+	s := &perOperationSampler{
+		maxOperations: maxOperations,
+		operations:    make(map[string]*guaranteedThroughputSampler),
+	}
+	s.update(strategies)
+	return s
+}
+
+// update applies strategies to s. Operations that are no longer part of the
+// strategies keep being sampled with their last known strategy.
+func (s *perOperationSampler) update(strategies *perOperationStrategies) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.lowerBound = strategies.DefaultLowerBoundTracesPerSecond
+	if s.defaultSampler == nil || s.defaultProbability != strategies.DefaultSamplingProbability {
+		s.defaultProbability = strategies.DefaultSamplingProbability
+		s.defaultSampler = sdktrace.TraceIDRatioBased(s.defaultProbability)
+	}
+
+	for _, op := range strategies.PerOperationStrategies {
+		probability := s.defaultProbability
+		if op.ProbabilisticSampling != nil {
+			probability = op.ProbabilisticSampling.SamplingRate
+		}
+		current, ok := s.operations[op.Operation]
+		if !ok {
+			if len(s.operations) >= s.maxOperations {
+				continue
+			}
+			s.operations[op.Operation] = newGuaranteedThroughputSampler(probability, s.lowerBound)
+			continue
+		}
+		if current.probability != probability || current.lowerBound != s.lowerBound {
+			// Replace the sampler rather than mutating it, it may be in
+			// use concurrently.
+			updated := &guaranteedThroughputSampler{
+				probability:   probability,
+				lowerBound:    s.lowerBound,
+				probabilistic: sdktrace.TraceIDRatioBased(probability),
+				limiter:       current.limiter,
+			}
+			if current.lowerBound != s.lowerBound {
+				current.limiter.Update(s.lowerBound, 1)
+			}
+			s.operations[op.Operation] = updated
+		}
+	}
+}
+
+func (s *perOperationSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.lock.RLock()
+	op, ok := s.operations[p.Name]
+	s.lock.RUnlock()
+	if ok {
+		return op.ShouldSample(p)
+	}
+
+	s.lock.Lock()
+	op, ok = s.operations[p.Name]
+	if !ok {
+		if len(s.operations) >= s.maxOperations {
+			sampler := s.defaultSampler
+			s.lock.Unlock()
+			return sampler.ShouldSample(p)
+		}
+		op = newGuaranteedThroughputSampler(s.defaultProbability, s.lowerBound)
+		s.operations[p.Name] = op
+	}
+	s.lock.Unlock()
+	return op.ShouldSample(p)
+}
+
+func (s *perOperationSampler) Description() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return fmt.Sprintf(
+		"PerOperationSampler{defaultProbability:%g,lowerBound:%g,maxOperations:%d}",
+		s.defaultProbability, s.lowerBound, s.maxOperations,
+	)
+}
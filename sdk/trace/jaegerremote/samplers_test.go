@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/internal/ratelimit"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestRateLimiter(clock *fakeClock, creditsPerSecond, maxBalance float64) *ratelimit.Limiter {
+	return ratelimit.NewWithClock(creditsPerSecond, maxBalance, clock.Now)
+}
+
+func TestGuaranteedThroughputSampler(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	s := newGuaranteedThroughputSampler(0, 1)
+	s.limiter = newTestRateLimiter(clock, 1, 1)
+
+	// The lower bound samples one trace per second.
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("op")).Decision)
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params("op")).Decision)
+	clock.advance(time.Second)
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("op")).Decision)
+
+	// Probabilistically sampled traces consume the lower bound.
+	s.probabilistic = sdktrace.AlwaysSample()
+	clock.advance(time.Second)
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("op")).Decision)
+	s.probabilistic = sdktrace.NeverSample()
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params("op")).Decision)
+}
+
+func TestPerOperationSamplerMaxOperations(t *testing.T) {
+	s := newPerOperationSampler(&perOperationStrategies{
+		DefaultSamplingProbability: 1,
+		PerOperationStrategies: []operationStrategy{
+			{Operation: "a", ProbabilisticSampling: &probabilisticStrategy{SamplingRate: 0}},
+			{Operation: "b", ProbabilisticSampling: &probabilisticStrategy{SamplingRate: 0}},
+			{Operation: "c", ProbabilisticSampling: &probabilisticStrategy{SamplingRate: 0}},
+		},
+	}, 2)
+
+	assert.Len(t, s.operations, 2)
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params("a")).Decision)
+	// Operations beyond the limit use the default probability.
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("c")).Decision)
+	assert.Len(t, s.operations, 2)
+}
+
+func TestPerOperationSamplerUpdate(t *testing.T) {
+	s := newPerOperationSampler(&perOperationStrategies{
+		DefaultSamplingProbability:       0,
+		DefaultLowerBoundTracesPerSecond: 1,
+	}, 10)
+
+	// Unknown operations are added with the default strategy.
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("a")).Decision)
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params("a")).Decision)
+	limiter := s.operations["a"].limiter
+
+	s.update(&perOperationStrategies{
+		DefaultSamplingProbability:       0,
+		DefaultLowerBoundTracesPerSecond: 1,
+		PerOperationStrategies: []operationStrategy{
+			{Operation: "a", ProbabilisticSampling: &probabilisticStrategy{SamplingRate: 1}},
+		},
+	})
+	assert.Same(t, limiter, s.operations["a"].limiter, "rate limiter state should be kept")
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params("a")).Decision)
+	assert.Equal(t, "PerOperationSampler{defaultProbability:0,lowerBound:1,maxOperations:10}", s.Description())
+}
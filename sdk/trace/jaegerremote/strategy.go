@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// errNoStrategy is returned when a sampling strategy response does not
+// contain any strategy.
+var errNoStrategy = errors.New("no sampling strategy in response")
+
+// strategyResponse is the JSON representation of the sampling strategy
+// served by Jaeger agents and collectors.
+type strategyResponse struct {
+	ProbabilisticSampling *probabilisticStrategy  `json:"probabilisticSampling,omitempty"`
+	RateLimitingSampling  *rateLimitingStrategy   `json:"rateLimitingSampling,omitempty"`
+	OperationSampling     *perOperationStrategies `json:"operationSampling,omitempty"`
+}
+
+type probabilisticStrategy struct {
+	SamplingRate float64 `json:"samplingRate"`
+}
+
+type rateLimitingStrategy struct {
+	MaxTracesPerSecond float64 `json:"maxTracesPerSecond"`
+}
+
+type operationStrategy struct {
+	Operation             string                 `json:"operation"`
+	ProbabilisticSampling *probabilisticStrategy `json:"probabilisticSampling"`
+}
+
+type perOperationStrategies struct {
+	DefaultSamplingProbability       float64             `json:"defaultSamplingProbability"`
+	DefaultLowerBoundTracesPerSecond float64             `json:"defaultLowerBoundTracesPerSecond"`
+	PerOperationStrategies           []operationStrategy `json:"perOperationStrategies"`
+}
+
+// fetcher retrieves the sampling strategy of a service over HTTP.
+type fetcher struct {
+	client      *http.Client
+	url         string
+	serviceName string
+}
+
+func (f fetcher) fetch(ctx context.Context) (*strategyResponse, error) {
+	u, err := url.Parse(f.url)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("service", f.serviceName)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	var s strategyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.OperationSampling == nil && s.ProbabilisticSampling == nil && s.RateLimitingSampling == nil {
+		return nil, errNoStrategy
+	}
+	return &s, nil
+}
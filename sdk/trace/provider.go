@@ -72,11 +72,17 @@ var _ trace.TracerProvider = &TracerProvider{}
 //  - the resource.Default() Resource
 //  - the default SpanLimits.
 //
+// The OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG environment variables
+// are used to override the default Sampler, and the OTEL_SPAN_*_LIMIT
+// environment variables are used to override the default SpanLimits.
+//
 // The passed opts are used to override these default values and configure the
 // returned TracerProvider appropriately.
 func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 	o := &tracerProviderConfig{}
 
+	applyTracerProviderEnvConfigs(o)
+
 	for _, opt := range opts {
 		opt.apply(o)
 	}
@@ -329,6 +335,29 @@ func WithSpanLimits(sl SpanLimits) TracerProviderOption {
 	})
 }
 
+// applyTracerProviderEnvConfigs applies the TracerProviderOptions described by
+// the environment to cfg.
+func applyTracerProviderEnvConfigs(cfg *tracerProviderConfig) {
+	for _, opt := range tracerProviderOptionsFromEnv() {
+		opt.apply(cfg)
+	}
+}
+
+func tracerProviderOptionsFromEnv() []TracerProviderOption {
+	var opts []TracerProviderOption
+
+	sampler, err := samplerFromEnv()
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	if sampler != nil {
+		opts = append(opts, WithSampler(sampler))
+	}
+
+	return opts
+}
+
 // ensureValidTracerProviderConfig ensures that given TracerProviderConfig is valid.
 func ensureValidTracerProviderConfig(cfg *tracerProviderConfig) {
 	if cfg.sampler == nil {
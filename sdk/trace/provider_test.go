@@ -17,10 +17,15 @@ package trace
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/internal/env"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -94,3 +99,201 @@ func TestSchemaURL(t *testing.T) {
 	tracerStruct := tracerIface.(*tracer)
 	assert.EqualValues(t, schemaURL, tracerStruct.instrumentationLibrary.SchemaURL)
 }
+
+func TestTracerProviderSamplerConfigFromEnv(t *testing.T) {
+	type testCase struct {
+		sampler             string
+		samplerArg          string
+		argOptional         bool
+		description         string
+		errorType           error
+		invalidArgErrorType interface{}
+	}
+
+	randFloat := rand.Float64()
+
+	tests := []testCase{
+		{
+			sampler:     "invalid-sampler",
+			argOptional: true,
+			description: ParentBased(AlwaysSample()).Description(),
+			errorType:   errUnsupportedSampler("invalid-sampler"),
+
+			invalidArgErrorType: errUnsupportedSampler("invalid-sampler"),
+		},
+		{
+			sampler:     "always_on",
+			argOptional: true,
+			description: AlwaysSample().Description(),
+		},
+		{
+			sampler:     "always_off",
+			argOptional: true,
+			description: NeverSample().Description(),
+		},
+		{
+			sampler:     "traceidratio",
+			samplerArg:  fmt.Sprintf("%g", randFloat),
+			description: TraceIDRatioBased(randFloat).Description(),
+		},
+		{
+			sampler:     "traceidratio",
+			samplerArg:  fmt.Sprintf("%g", -randFloat),
+			description: TraceIDRatioBased(1.0).Description(),
+			errorType:   errNegativeTraceIDRatio,
+		},
+		{
+			sampler:     "traceidratio",
+			samplerArg:  fmt.Sprintf("%g", 1+randFloat),
+			description: TraceIDRatioBased(1.0).Description(),
+			errorType:   errGreaterThanOneTraceIDRatio,
+		},
+		{
+			sampler:             "traceidratio",
+			argOptional:         true,
+			description:         TraceIDRatioBased(1.0).Description(),
+			invalidArgErrorType: new(samplerArgParseError),
+		},
+		{
+			sampler:     "parentbased_always_on",
+			argOptional: true,
+			description: ParentBased(AlwaysSample()).Description(),
+		},
+		{
+			sampler:     "parentbased_always_off",
+			argOptional: true,
+			description: ParentBased(NeverSample()).Description(),
+		},
+		{
+			sampler:     "parentbased_traceidratio",
+			samplerArg:  fmt.Sprintf("%g", randFloat),
+			description: ParentBased(TraceIDRatioBased(randFloat)).Description(),
+		},
+		{
+			sampler:     "parentbased_traceidratio",
+			samplerArg:  fmt.Sprintf("%g", -randFloat),
+			description: ParentBased(TraceIDRatioBased(1.0)).Description(),
+			errorType:   errNegativeTraceIDRatio,
+		},
+		{
+			sampler:             "parentbased_traceidratio",
+			argOptional:         true,
+			description:         ParentBased(TraceIDRatioBased(1.0)).Description(),
+			invalidArgErrorType: new(samplerArgParseError),
+		},
+	}
+
+	handler.Reset()
+
+	for _, test := range tests {
+		t.Run(test.sampler, func(t *testing.T) {
+			envVars := map[string]string{
+				"OTEL_TRACES_SAMPLER": test.sampler,
+			}
+
+			if test.samplerArg != "" {
+				envVars["OTEL_TRACES_SAMPLER_ARG"] = test.samplerArg
+			}
+			envStore, err := ottest.SetEnvVariables(envVars)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				handler.Reset()
+				require.NoError(t, envStore.Restore())
+			})
+
+			stp := NewTracerProvider(WithSyncer(NewTestExporter()))
+			assert.Equal(t, test.description, stp.sampler.Description())
+			if test.errorType != nil {
+				testStoredError(t, test.errorType)
+			} else {
+				assert.Empty(t, handler.errs)
+			}
+
+			if test.argOptional {
+				t.Run("invalid sampler arg", func(t *testing.T) {
+					envStore, err := ottest.SetEnvVariables(map[string]string{
+						"OTEL_TRACES_SAMPLER":     test.sampler,
+						"OTEL_TRACES_SAMPLER_ARG": "invalid-ignored-string",
+					})
+					require.NoError(t, err)
+					t.Cleanup(func() {
+						handler.Reset()
+						require.NoError(t, envStore.Restore())
+					})
+
+					stp := NewTracerProvider(WithSyncer(NewTestExporter()))
+					t.Cleanup(func() {
+						require.NoError(t, stp.Shutdown(context.Background()))
+					})
+					assert.Equal(t, test.description, stp.sampler.Description())
+
+					if test.invalidArgErrorType != nil {
+						testStoredError(t, test.invalidArgErrorType)
+					} else {
+						assert.Empty(t, handler.errs)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestTracerProviderSamplerOptionOverridesEnv(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		"OTEL_TRACES_SAMPLER": "always_off",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	stp := NewTracerProvider(WithSampler(AlwaysSample()))
+	assert.Equal(t, AlwaysSample().Description(), stp.sampler.Description())
+}
+
+func TestTracerProviderSpanLimitsFromEnv(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		env.SpanAttributeCountKey:      "42",
+		env.SpanEventCountKey:          "43",
+		env.SpanLinkCountKey:           "44",
+		env.SpanEventAttributeCountKey: "45",
+		env.SpanLinkAttributeCountKey:  "invalid",
+		env.AttributeValueLengthKey:    "46",
+	})
+	require.NoError(t, err)
+	defer func() {
+		handler.Reset()
+		require.NoError(t, envStore.Restore())
+	}()
+
+	stp := NewTracerProvider()
+	assert.Equal(t, SpanLimits{
+		AttributeValueLengthLimit:   46,
+		AttributeCountLimit:         42,
+		EventCountLimit:             43,
+		LinkCountLimit:              44,
+		AttributePerEventCountLimit: 45,
+		AttributePerLinkCountLimit:  DefaultAttributePerLinkCountLimit,
+	}, stp.spanLimits)
+
+	// Explicitly set limits take precedence over the environment.
+	stp = NewTracerProvider(WithSpanLimits(SpanLimits{AttributeCountLimit: 10}))
+	assert.Equal(t, 10, stp.spanLimits.AttributeCountLimit)
+	assert.Equal(t, 43, stp.spanLimits.EventCountLimit)
+}
+
+func testStoredError(t *testing.T, target interface{}) {
+	t.Helper()
+
+	if assert.Len(t, handler.errs, 1) && assert.Error(t, handler.errs[0]) {
+		err := handler.errs[0]
+
+		require.Implements(t, (*error)(nil), target)
+		require.NotNil(t, target.(error))
+
+		defer handler.Reset()
+		if errors.Is(err, target.(error)) {
+			return
+		}
+
+		assert.ErrorAs(t, err, target)
+	}
+}
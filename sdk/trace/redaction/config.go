@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction // import "go.opentelemetry.io/otel/sdk/trace/redaction"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// config contains the rules of a Redactor.
+type config struct {
+	allowed map[attribute.Key]struct{}
+	denied  map[attribute.Key]struct{}
+	hashed  map[attribute.Key]struct{}
+	masks   []replacement
+	names   []replacement
+}
+
+// replacement replaces the matches of a pattern in a string.
+type replacement struct {
+	pattern *regexp.Regexp
+	repl    string
+}
+
+func (r replacement) apply(s string) string {
+	return r.pattern.ReplaceAllString(s, r.repl)
+}
+
+func newConfig(opts ...Option) config {
+	var c config
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	return c
+}
+
+// Option applies a redaction rule to a Redactor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+func addKeys(set *map[attribute.Key]struct{}, keys []attribute.Key) {
+	if *set == nil {
+		*set = make(map[attribute.Key]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		(*set)[k] = struct{}{}
+	}
+}
+
+// WithAllowedKeys restricts attributes to those with one of keys. All other
+// attributes are removed. This option can be used multiple times to allow
+// more keys.
+func WithAllowedKeys(keys ...attribute.Key) Option {
+	return optionFunc(func(c *config) {
+		addKeys(&c.allowed, keys)
+	})
+}
+
+// WithDeniedKeys removes the attributes with one of keys. This option can be
+// used multiple times to deny more keys.
+func WithDeniedKeys(keys ...attribute.Key) Option {
+	return optionFunc(func(c *config) {
+		addKeys(&c.denied, keys)
+	})
+}
+
+// WithHashedKeys replaces the values of attributes with one of keys by the
+// hex-encoded SHA-256 hash of their string representation. Hashing keeps
+// values correlatable without disclosing them, but values from a small set,
+// such as numbers, can be recovered by hashing all the candidates. This
+// option can be used multiple times to hash more keys.
+func WithHashedKeys(keys ...attribute.Key) Option {
+	return optionFunc(func(c *config) {
+		addKeys(&c.hashed, keys)
+	})
+}
+
+// WithMask replaces the parts of string attribute values, and of elements
+// of string slice attribute values, matching pattern with repl. Inside repl,
+// $ signs are interpreted as in regexp.Regexp.Expand. Masks are applied in
+// the order they are configured.
+func WithMask(pattern *regexp.Regexp, repl string) Option {
+	return optionFunc(func(c *config) {
+		c.masks = append(c.masks, replacement{pattern: pattern, repl: repl})
+	})
+}
+
+// WithNameRewrite replaces the parts of span names matching pattern with
+// repl. Inside repl, $ signs are interpreted as in regexp.Regexp.Expand.
+// Rewrites are applied in the order they are configured.
+func WithNameRewrite(pattern *regexp.Regexp, repl string) Option {
+	return optionFunc(func(c *config) {
+		c.names = append(c.names, replacement{pattern: pattern, repl: repl})
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redaction provides a SpanProcessor and a SpanExporter that redact
+// spans before they are exported, so sensitive data such as emails, card
+// numbers, or authentication tokens never leaves the process.
+//
+// The rules of a Redactor apply to the attributes of spans, of their events
+// and links, and of their resources, in the following order:
+//
+//   - attributes with a denied key, or without an allowed key if an
+//     allow-list is configured, are removed.
+//   - values of attributes with a hashed key are replaced by their SHA-256
+//     hash.
+//   - parts of string values matching a mask pattern are replaced.
+//
+// Span names are rewritten with the name rules of the Redactor.
+package redaction // import "go.opentelemetry.io/otel/sdk/trace/redaction"
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction // import "go.opentelemetry.io/otel/sdk/trace/redaction"
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanProcessor is a SpanProcessor that passes redacted copies of ended
+// spans to another SpanProcessor.
+type SpanProcessor struct {
+	next     sdktrace.SpanProcessor
+	redactor *Redactor
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor that redacts ended spans with
+// the rules configured with opts before passing them to next.
+//
+// Using it in front of a BatchSpanProcessor ensures unredacted spans are
+// neither queued nor exported.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) *SpanProcessor {
+	return &SpanProcessor{
+		next:     next,
+		redactor: NewRedactor(opts...),
+	}
+}
+
+// OnStart passes s to the wrapped SpanProcessor unmodified.
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes a redacted copy of s to the wrapped SpanProcessor.
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.next.OnEnd(p.redactor.Redact(s))
+}
+
+// Shutdown shuts down the wrapped SpanProcessor.
+func (p *SpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped SpanProcessor.
+func (p *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// Exporter is a SpanExporter that exports redacted copies of spans with
+// another SpanExporter.
+type Exporter struct {
+	next     sdktrace.SpanExporter
+	redactor *Redactor
+}
+
+var _ sdktrace.SpanExporter = (*Exporter)(nil)
+
+// NewExporter returns a SpanExporter that redacts spans with the rules
+// configured with opts before exporting them with next.
+func NewExporter(next sdktrace.SpanExporter, opts ...Option) *Exporter {
+	return &Exporter{
+		next:     next,
+		redactor: NewRedactor(opts...),
+	}
+}
+
+// ExportSpans exports redacted copies of spans with the wrapped
+// SpanExporter.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	redacted := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, s := range spans {
+		redacted[i] = e.redactor.Redact(s)
+	}
+	return e.next.ExportSpans(ctx, redacted)
+}
+
+// Shutdown shuts down the wrapped SpanExporter.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
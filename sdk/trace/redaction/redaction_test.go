@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/redaction"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var emailRe = regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`)
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRedactorDeniedKeys(t *testing.T) {
+	r := redaction.NewRedactor(redaction.WithDeniedKeys("password", "token"))
+	s := tracetest.SpanStub{
+		Attributes: []attribute.KeyValue{
+			attribute.String("user", "alice"),
+			attribute.String("password", "secret"),
+			attribute.String("token", "abc"),
+		},
+	}.Snapshot()
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("user", "alice")}, r.Redact(s).Attributes())
+}
+
+func TestRedactorAllowedKeys(t *testing.T) {
+	r := redaction.NewRedactor(
+		redaction.WithAllowedKeys("http.method", "http.status_code"),
+		redaction.WithDeniedKeys("http.status_code"),
+	)
+	s := tracetest.SpanStub{
+		Attributes: []attribute.KeyValue{
+			attribute.String("http.method", "GET"),
+			attribute.Int("http.status_code", 200),
+			attribute.String("http.url", "https://example.com/?token=abc"),
+		},
+	}.Snapshot()
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("http.method", "GET")}, r.Redact(s).Attributes())
+}
+
+func TestRedactorHashedKeys(t *testing.T) {
+	r := redaction.NewRedactor(redaction.WithHashedKeys("user.id", "user.ids"))
+	s := tracetest.SpanStub{
+		Attributes: []attribute.KeyValue{
+			attribute.Int("user.id", 42),
+			attribute.StringSlice("user.ids", []string{"a", "b"}),
+			attribute.String("other", "value"),
+		},
+	}.Snapshot()
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user.id", hash("42")),
+		attribute.String("user.ids", hash(attribute.StringSliceValue([]string{"a", "b"}).Emit())),
+		attribute.String("other", "value"),
+	}, r.Redact(s).Attributes())
+}
+
+func TestRedactorMasks(t *testing.T) {
+	r := redaction.NewRedactor(
+		redaction.WithMask(emailRe, "<email>"),
+		redaction.WithMask(regexp.MustCompile(`([?&]token=)[^&]*`), "${1}<redacted>"),
+	)
+	emails := []string{"alice@example.com", "none"}
+	s := tracetest.SpanStub{
+		Attributes: []attribute.KeyValue{
+			attribute.String("http.url", "https://example.com/?token=abc&user=bob@example.com"),
+			attribute.StringSlice("emails", emails),
+			attribute.Int("count", 1),
+		},
+	}.Snapshot()
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.url", "https://example.com/?token=<redacted>&user=<email>"),
+		attribute.StringSlice("emails", []string{"<email>", "none"}),
+		attribute.Int("count", 1),
+	}, r.Redact(s).Attributes())
+	assert.Equal(t, []string{"alice@example.com", "none"}, emails, "original values modified")
+}
+
+func TestRedactorNameRewrite(t *testing.T) {
+	r := redaction.NewRedactor(redaction.WithNameRewrite(regexp.MustCompile(`/users/\d+`), "/users/{id}"))
+	s := tracetest.SpanStub{Name: "GET /users/42/orders"}.Snapshot()
+
+	assert.Equal(t, "GET /users/{id}/orders", r.Redact(s).Name())
+}
+
+func TestRedactorEventsLinksAndResource(t *testing.T) {
+	r := redaction.NewRedactor(redaction.WithMask(emailRe, "<email>"))
+	email := attribute.String("email", "alice@example.com")
+	masked := attribute.String("email", "<email>")
+	res := resource.NewWithAttributes("https://example.com/schema", email)
+	s := tracetest.SpanStub{
+		Name:        "span",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{0x01}, SpanID: trace.SpanID{0x01}}),
+		Events:      []sdktrace.Event{{Name: "event", Attributes: []attribute.KeyValue{email}}},
+		Links:       []sdktrace.Link{{Attributes: []attribute.KeyValue{email}, DroppedAttributeCount: 1}},
+		Resource:    res,
+	}.Snapshot()
+
+	got := r.Redact(s)
+	assert.Equal(t, s.SpanContext(), got.SpanContext())
+	assert.Equal(t, []sdktrace.Event{{Name: "event", Attributes: []attribute.KeyValue{masked}}}, got.Events())
+	assert.Equal(t, []sdktrace.Link{{Attributes: []attribute.KeyValue{masked}, DroppedAttributeCount: 1}}, got.Links())
+	assert.Equal(t, []attribute.KeyValue{masked}, got.Resource().Attributes())
+	assert.Equal(t, "https://example.com/schema", got.Resource().SchemaURL())
+	assert.Same(t, got.Resource(), r.Redact(s).Resource(), "redacted resource not cached")
+
+	// The original span is not modified.
+	assert.Equal(t, []attribute.KeyValue{email}, s.Events()[0].Attributes)
+	assert.Equal(t, []attribute.KeyValue{email}, s.Resource().Attributes())
+}
+
+func TestSpanProcessor(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	sp := redaction.NewSpanProcessor(
+		sdktrace.NewSimpleSpanProcessor(exp),
+		redaction.WithDeniedKeys("password"),
+	)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	_, span := tp.Tracer("redaction").Start(context.Background(), "span")
+	span.SetAttributes(attribute.String("password", "secret"), attribute.String("user", "alice"))
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("user", "alice")}, spans[0].Attributes)
+}
+
+func TestExporter(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	re := redaction.NewExporter(exp, redaction.WithMask(emailRe, "<email>"))
+	s := tracetest.SpanStub{
+		Attributes: []attribute.KeyValue{attribute.String("email", "alice@example.com")},
+	}.Snapshot()
+
+	require.NoError(t, re.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{s}))
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("email", "<email>")}, spans[0].Attributes)
+}
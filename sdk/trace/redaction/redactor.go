@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction // import "go.opentelemetry.io/otel/sdk/trace/redaction"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// maxCachedResources is the maximum number of redacted resources cached by
+// a Redactor. Spans usually share a single resource.
+const maxCachedResources = 16
+
+// Redactor redacts spans according to its rules.
+type Redactor struct {
+	cfg config
+
+	mu        sync.Mutex
+	resources map[*resource.Resource]*resource.Resource
+}
+
+// NewRedactor returns a Redactor applying the rules configured with opts.
+func NewRedactor(opts ...Option) *Redactor {
+	return &Redactor{
+		cfg:       newConfig(opts...),
+		resources: make(map[*resource.Resource]*resource.Resource),
+	}
+}
+
+// Redact returns a redacted copy of s. The span s is not modified.
+func (r *Redactor) Redact(s sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+	rs := &redactedSpan{
+		ReadOnlySpan: s,
+		name:         r.name(s.Name()),
+		attributes:   r.attributes(s.Attributes()),
+		resource:     r.resource(s.Resource()),
+	}
+	if events := s.Events(); len(events) > 0 {
+		rs.events = make([]sdktrace.Event, len(events))
+		for i, e := range events {
+			e.Attributes = r.attributes(e.Attributes)
+			rs.events[i] = e
+		}
+	}
+	if links := s.Links(); len(links) > 0 {
+		rs.links = make([]sdktrace.Link, len(links))
+		for i, l := range links {
+			l.Attributes = r.attributes(l.Attributes)
+			rs.links[i] = l
+		}
+	}
+	return rs
+}
+
+func (r *Redactor) name(name string) string {
+	for _, rw := range r.cfg.names {
+		name = rw.apply(name)
+	}
+	return name
+}
+
+// resource returns the redacted copy of res, caching it for the next spans
+// of res.
+func (r *Redactor) resource(res *resource.Resource) *resource.Resource {
+	if res == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if redacted, ok := r.resources[res]; ok {
+		return redacted
+	}
+	redacted := resource.NewWithAttributes(res.SchemaURL(), r.attributes(res.Attributes())...)
+	if len(r.resources) >= maxCachedResources {
+		r.resources = make(map[*resource.Resource]*resource.Resource)
+	}
+	r.resources[res] = redacted
+	return redacted
+}
+
+// attributes returns the redacted copy of attrs.
+func (r *Redactor) attributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if len(attrs) == 0 {
+		return attrs
+	}
+	redacted := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if !r.keep(kv.Key) {
+			continue
+		}
+		if _, ok := r.cfg.hashed[kv.Key]; ok {
+			sum := sha256.Sum256([]byte(kv.Value.Emit()))
+			kv = kv.Key.String(hex.EncodeToString(sum[:]))
+		}
+		redacted = append(redacted, r.mask(kv))
+	}
+	return redacted
+}
+
+// keep returns whether attributes with key are kept.
+func (r *Redactor) keep(key attribute.Key) bool {
+	if _, ok := r.cfg.denied[key]; ok {
+		return false
+	}
+	if r.cfg.allowed == nil {
+		return true
+	}
+	_, ok := r.cfg.allowed[key]
+	return ok
+}
+
+// mask applies the masks to the string values of kv.
+func (r *Redactor) mask(kv attribute.KeyValue) attribute.KeyValue {
+	if len(r.cfg.masks) == 0 {
+		return kv
+	}
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		return kv.Key.String(r.maskString(kv.Value.AsString()))
+	case attribute.STRINGSLICE:
+		// Do not modify the slice shared with the original attribute.
+		orig := kv.Value.AsStringSlice()
+		values := make([]string, len(orig))
+		for i, v := range orig {
+			values[i] = r.maskString(v)
+		}
+		return kv.Key.StringSlice(values)
+	}
+	return kv
+}
+
+func (r *Redactor) maskString(s string) string {
+	for _, m := range r.cfg.masks {
+		s = m.apply(s)
+	}
+	return s
+}
+
+// redactedSpan is a ReadOnlySpan whose name and attributes are redacted.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	name       string
+	attributes []attribute.KeyValue
+	events     []sdktrace.Event
+	links      []sdktrace.Link
+	resource   *resource.Resource
+}
+
+func (s *redactedSpan) Name() string                     { return s.name }
+func (s *redactedSpan) Attributes() []attribute.KeyValue { return s.attributes }
+func (s *redactedSpan) Events() []sdktrace.Event         { return s.events }
+func (s *redactedSpan) Links() []sdktrace.Link           { return s.links }
+func (s *redactedSpan) Resource() *resource.Resource     { return s.resource }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/internal/ratelimit"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -118,6 +119,49 @@ func TraceIDRatioBased(fraction float64) Sampler {
 	}
 }
 
+type rateLimitingSampler struct {
+	limiter     *ratelimit.Limiter
+	description string
+}
+
+func (rs *rateLimitingSampler) ShouldSample(p SamplingParameters) SamplingResult {
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	if rs.limiter.Allow() {
+		return SamplingResult{
+			Decision:   RecordAndSample,
+			Tracestate: psc.TraceState(),
+		}
+	}
+	return SamplingResult{
+		Decision:   Drop,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+func (rs *rateLimitingSampler) Description() string {
+	return rs.description
+}
+
+// RateLimiting samples at most limit traces per second. It uses a token
+// bucket holding up to burst tokens, which is initially full: bursts of up
+// to burst traces are sampled as long as the average rate stays under limit.
+// Limits <= 0 never sample, and bursts < 1 are treated as 1. To respect the
+// parent trace's `SampledFlag` and only limit root traces, the
+// `RateLimiting` sampler should be used as a delegate of a `Parent` sampler.
+func RateLimiting(limit float64, burst int) Sampler {
+	if limit <= 0 {
+		return NeverSample()
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimitingSampler{
+		limiter:     ratelimit.New(limit, float64(burst)),
+		description: fmt.Sprintf("RateLimiting{limit:%g,burst:%d}", limit, burst),
+	}
+}
+
 type alwaysOnSampler struct{}
 
 func (as alwaysOnSampler) ShouldSample(p SamplingParameters) SamplingResult {
@@ -18,11 +18,15 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/sdk/internal/ratelimit"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -235,6 +239,10 @@ func TestTracestateIsPassed(t *testing.T) {
 			"traceIDRatioSampler",
 			TraceIDRatioBased(.5),
 		},
+		{
+			"rateLimitingSampler",
+			RateLimiting(1, 1),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -257,3 +265,84 @@ func TestTracestateIsPassed(t *testing.T) {
 		})
 	}
 }
+
+func newTestRateLimiting(limit float64, burst int, now *time.Time) *rateLimitingSampler {
+	s := RateLimiting(limit, burst).(*rateLimitingSampler)
+	s.limiter = ratelimit.NewWithClock(limit, float64(burst), func() time.Time { return *now })
+	return s
+}
+
+func TestRateLimitingSampler(t *testing.T) {
+	now := time.Unix(0, 0)
+	sampler := newTestRateLimiting(2, 3, &now)
+	params := SamplingParameters{}
+
+	// The initial burst is sampled.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	}
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+
+	// Tokens do not accumulate beyond the burst.
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	}
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+}
+
+func TestRateLimitingSamplerConcurrency(t *testing.T) {
+	now := time.Unix(0, 0)
+	sampler := newTestRateLimiting(1, 100, &now)
+
+	var (
+		sampled int64
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if sampler.ShouldSample(SamplingParameters{}).Decision == RecordAndSample {
+					atomic.AddInt64(&sampled, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(100), sampled)
+}
+
+func TestRateLimitingSamplerParentBased(t *testing.T) {
+	now := time.Unix(0, 0)
+	sampler := ParentBased(newTestRateLimiting(1, 1, &now))
+
+	parentCtx := trace.ContextWithSpanContext(
+		context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01},
+			SpanID:     trace.SpanID{0x01},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	)
+	root := SamplingParameters{ParentContext: context.Background()}
+	child := SamplingParameters{ParentContext: parentCtx}
+
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(root).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(root).Decision)
+	// Children of sampled spans are not limited.
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(child).Decision)
+}
+
+func TestRateLimitingSamplerDescription(t *testing.T) {
+	assert.Equal(t, "RateLimiting{limit:2.5,burst:5}", RateLimiting(2.5, 5).Description())
+	assert.Equal(t, "RateLimiting{limit:10,burst:1}", RateLimiting(10, 0).Description())
+	assert.Equal(t, NeverSample().Description(), RateLimiting(0, 10).Description())
+}
@@ -26,22 +26,23 @@ import (
 // snapshot is an record of a spans state at a particular checkpointed time.
 // It is used as a read-only representation of that state.
 type snapshot struct {
-	name                   string
-	spanContext            trace.SpanContext
-	parent                 trace.SpanContext
-	spanKind               trace.SpanKind
-	startTime              time.Time
-	endTime                time.Time
-	attributes             []attribute.KeyValue
-	events                 []Event
-	links                  []Link
-	status                 Status
-	childSpanCount         int
-	droppedAttributeCount  int
-	droppedEventCount      int
-	droppedLinkCount       int
-	resource               *resource.Resource
-	instrumentationLibrary instrumentation.Library
+	name                    string
+	spanContext             trace.SpanContext
+	parent                  trace.SpanContext
+	spanKind                trace.SpanKind
+	startTime               time.Time
+	endTime                 time.Time
+	attributes              []attribute.KeyValue
+	events                  []Event
+	links                   []Link
+	status                  Status
+	childSpanCount          int
+	droppedAttributeCount   int
+	droppedEventCount       int
+	droppedLinkCount        int
+	truncatedAttributeCount int
+	resource                *resource.Resource
+	instrumentationLibrary  instrumentation.Library
 }
 
 var _ ReadOnlySpan = snapshot{}
@@ -131,6 +132,12 @@ func (s snapshot) DroppedEvents() int {
 	return s.droppedEventCount
 }
 
+// TruncatedAttributes returns the number of span, event, and link attribute
+// values truncated by the span due to limits being reached.
+func (s snapshot) TruncatedAttributes() int {
+	return s.truncatedAttributeCount
+}
+
 // ChildSpanCount returns the count of spans that consider the span a
 // direct parent.
 func (s snapshot) ChildSpanCount() int {
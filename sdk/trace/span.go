@@ -76,6 +76,9 @@ type ReadOnlySpan interface {
 	// DroppedEvents returns the number of events dropped by the span due to
 	// limits being reached.
 	DroppedEvents() int
+	// TruncatedAttributes returns the number of span, event, and link
+	// attribute values truncated by the span due to limits being reached.
+	TruncatedAttributes() int
 	// ChildSpanCount returns the count of spans that consider the span a
 	// direct parent.
 	ChildSpanCount() int
@@ -147,6 +150,10 @@ type recordingSpan struct {
 	// links are stored in FIFO queue capped by configured limit.
 	links *evictedQueue
 
+	// truncatedAttributeCount is the number of attribute values truncated to
+	// the configured length limit.
+	truncatedAttributeCount int
+
 	// executionTracerTaskEnd ends the execution tracer span.
 	executionTracerTaskEnd func()
 
@@ -340,8 +347,10 @@ func (s *recordingSpan) addEvent(name string, o ...trace.EventOption) {
 		discarded = len(attributes) - s.spanLimits.AttributePerEventCountLimit
 		attributes = attributes[:s.spanLimits.AttributePerEventCountLimit]
 	}
+	attributes, truncated := truncateAttrs(s.spanLimits.AttributeValueLengthLimit, attributes)
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.truncatedAttributeCount += truncated
 	s.events.add(Event{
 		Name:                  name,
 		Attributes:            attributes,
@@ -465,6 +474,9 @@ func (s *recordingSpan) addLink(link trace.Link) {
 		droppedAttributeCount = len(link.Attributes) - s.spanLimits.AttributePerLinkCountLimit
 		link.Attributes = link.Attributes[:s.spanLimits.AttributePerLinkCountLimit]
 	}
+	var truncated int
+	link.Attributes, truncated = truncateAttrs(s.spanLimits.AttributeValueLengthLimit, link.Attributes)
+	s.truncatedAttributeCount += truncated
 
 	s.links.add(Link{link.SpanContext, link.Attributes, droppedAttributeCount})
 }
@@ -493,6 +505,14 @@ func (s *recordingSpan) DroppedEvents() int {
 	return s.events.droppedCount
 }
 
+// TruncatedAttributes returns the number of span, event, and link attribute
+// values truncated by the span due to limits being reached.
+func (s *recordingSpan) TruncatedAttributes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.truncatedAttributeCount
+}
+
 // ChildSpanCount returns the count of spans that consider the span a
 // direct parent.
 func (s *recordingSpan) ChildSpanCount() int {
@@ -523,6 +543,7 @@ func (s *recordingSpan) snapshot() ReadOnlySpan {
 	sd.startTime = s.startTime
 	sd.status = s.status
 	sd.childSpanCount = s.childSpanCount
+	sd.truncatedAttributeCount = s.truncatedAttributeCount
 
 	if s.attributes.evictList.Len() > 0 {
 		sd.attributes = s.attributes.toKeyValue()
@@ -562,11 +583,94 @@ func (s *recordingSpan) copyToCappedAttributes(attributes ...attribute.KeyValue)
 		// Ensure attributes conform to the specification:
 		// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.0.1/specification/common/common.md#attributes
 		if a.Valid() {
+			var truncated bool
+			a, truncated = truncateAttr(s.spanLimits.AttributeValueLengthLimit, a)
+			if truncated {
+				s.truncatedAttributeCount++
+			}
 			s.attributes.add(a)
 		}
 	}
 }
 
+// truncateAttrs returns attrs with each value truncated to limit characters
+// and the number of values truncated. The attrs slice is left unchanged, a
+// copy is returned if any value needs to be truncated.
+func truncateAttrs(limit int, attrs []attribute.KeyValue) ([]attribute.KeyValue, int) {
+	if limit <= 0 {
+		return attrs, 0
+	}
+	var (
+		out       []attribute.KeyValue
+		truncated int
+	)
+	for i, a := range attrs {
+		t, ok := truncateAttr(limit, a)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]attribute.KeyValue, len(attrs))
+			copy(out, attrs)
+		}
+		out[i] = t
+		truncated++
+	}
+	if out == nil {
+		return attrs, 0
+	}
+	return out, truncated
+}
+
+// truncateAttr returns attr with its STRING value, or each element of its
+// STRINGSLICE value, truncated to limit characters. It reports whether any
+// truncation happened. Values of other types are never truncated.
+func truncateAttr(limit int, attr attribute.KeyValue) (attribute.KeyValue, bool) {
+	if limit <= 0 {
+		return attr, false
+	}
+	switch attr.Value.Type() {
+	case attribute.STRING:
+		if v, ok := truncate(limit, attr.Value.AsString()); ok {
+			return attr.Key.String(v), true
+		}
+	case attribute.STRINGSLICE:
+		var v []string
+		for i, e := range attr.Value.AsStringSlice() {
+			t, ok := truncate(limit, e)
+			if !ok {
+				continue
+			}
+			if v == nil {
+				// Copy, the slice backs the original value.
+				v = append([]string(nil), attr.Value.AsStringSlice()...)
+			}
+			v[i] = t
+		}
+		if v != nil {
+			return attr.Key.StringSlice(v), true
+		}
+	}
+	return attr, false
+}
+
+// truncate returns s truncated to its first limit characters and whether it
+// was truncated.
+func truncate(limit int, s string) (string, bool) {
+	// A string cannot hold more characters than bytes.
+	if len(s) <= limit {
+		return s, false
+	}
+	var n int
+	for i := range s {
+		if n == limit {
+			return s[:i], true
+		}
+		n++
+	}
+	return s, false
+}
+
 func (s *recordingSpan) addChild() {
 	if !s.IsRecording() {
 		return
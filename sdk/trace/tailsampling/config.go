@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling // import "go.opentelemetry.io/otel/sdk/trace/tailsampling"
+
+import "time"
+
+// Defaults for the SpanProcessor configuration.
+const (
+	// DefaultDecisionWait is the time a trace is buffered, from the end of
+	// its first span, before a sampling decision is made.
+	DefaultDecisionWait = 10 * time.Second
+	// DefaultMaxTraces is the maximum number of traces buffered.
+	DefaultMaxTraces = 10000
+	// DefaultMaxBytes is the maximum estimated size of all buffered spans.
+	DefaultMaxBytes = 64 << 20
+	// DefaultExportTimeout is the maximum duration of an export of sampled
+	// traces.
+	DefaultExportTimeout = 30 * time.Second
+)
+
+// config contains configuration for a SpanProcessor.
+type config struct {
+	decisionWait  time.Duration
+	maxTraces     int
+	maxBytes      int
+	exportTimeout time.Duration
+	policies      []Policy
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		decisionWait:  DefaultDecisionWait,
+		maxTraces:     DefaultMaxTraces,
+		maxBytes:      DefaultMaxBytes,
+		exportTimeout: DefaultExportTimeout,
+	}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	return c
+}
+
+// Option applies a configuration option to a SpanProcessor.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithDecisionWait sets the time a trace is buffered, from the end of its
+// first span, before a sampling decision is made if its root span has not
+// ended yet. Non-positive values are ignored. By default, DefaultDecisionWait
+// is used.
+func WithDecisionWait(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.decisionWait = d
+		}
+	})
+}
+
+// WithMaxTraces sets the maximum number of traces buffered. When it is
+// exceeded, a sampling decision is made early for the oldest trace.
+// Non-positive values are ignored. By default, DefaultMaxTraces is used.
+func WithMaxTraces(n int) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxTraces = n
+		}
+	})
+}
+
+// WithMaxBytes sets the maximum estimated size, in bytes, of all buffered
+// spans. When it is exceeded, sampling decisions are made early for the
+// oldest traces. Non-positive values are ignored. By default,
+// DefaultMaxBytes is used.
+func WithMaxBytes(n int) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxBytes = n
+		}
+	})
+}
+
+// WithExportTimeout sets the maximum duration of an export of sampled
+// traces made in the background. Exports made by ForceFlush and Shutdown are
+// bounded by their context instead. Non-positive values are ignored. By
+// default, DefaultExportTimeout is used.
+func WithExportTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.exportTimeout = d
+		}
+	})
+}
+
+// WithPolicies adds policies deciding which traces are exported. A trace is
+// exported if any of the policies samples it.
+func WithPolicies(policies ...Policy) Option {
+	return optionFunc(func(c *config) {
+		c.policies = append(c.policies, policies...)
+	})
+}
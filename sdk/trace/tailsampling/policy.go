@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling // import "go.opentelemetry.io/otel/sdk/trace/tailsampling"
+
+import (
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Policy decides whether a trace is sampled.
+type Policy interface {
+	// ShouldSample reports whether the trace made of spans should be
+	// exported. All spans belong to the same trace and there is at least
+	// one of them.
+	ShouldSample(spans []sdktrace.ReadOnlySpan) bool
+}
+
+// PolicyFunc is a function that implements Policy.
+type PolicyFunc func(spans []sdktrace.ReadOnlySpan) bool
+
+// ShouldSample calls f(spans).
+func (f PolicyFunc) ShouldSample(spans []sdktrace.ReadOnlySpan) bool {
+	return f(spans)
+}
+
+// ErrorPolicy samples traces containing a span with an Error status.
+func ErrorPolicy() Policy {
+	return PolicyFunc(func(spans []sdktrace.ReadOnlySpan) bool {
+		for _, s := range spans {
+			if s.Status().Code == codes.Error {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// LatencyPolicy samples traces lasting at least threshold, from the start of
+// their first span to the end of their last span.
+func LatencyPolicy(threshold time.Duration) Policy {
+	return PolicyFunc(func(spans []sdktrace.ReadOnlySpan) bool {
+		start, end := spans[0].StartTime(), spans[0].EndTime()
+		for _, s := range spans[1:] {
+			if s.StartTime().Before(start) {
+				start = s.StartTime()
+			}
+			if s.EndTime().After(end) {
+				end = s.EndTime()
+			}
+		}
+		return end.Sub(start) >= threshold
+	})
+}
+
+// AttributePolicy samples traces containing a span with the attribute key. If
+// values are provided, the attribute must also be equal to one of them.
+func AttributePolicy(key attribute.Key, values ...attribute.Value) Policy {
+	return PolicyFunc(func(spans []sdktrace.ReadOnlySpan) bool {
+		for _, s := range spans {
+			for _, kv := range s.Attributes() {
+				if kv.Key != key {
+					continue
+				}
+				if len(values) == 0 {
+					return true
+				}
+				for _, v := range values {
+					if equal(kv.Value, v) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	})
+}
+
+// equal reports whether a and b hold the same value. Slice values are
+// compared element-wise.
+func equal(a, b attribute.Value) bool {
+	return a.Type() == b.Type() && reflect.DeepEqual(a.AsInterface(), b.AsInterface())
+}
+
+// ProbabilisticPolicy samples the given fraction of traces based on their
+// trace ID, like sdktrace.TraceIDRatioBased. It is typically used as a
+// fallback policy to export a baseline of traces.
+func ProbabilisticPolicy(fraction float64) Policy {
+	sampler := sdktrace.TraceIDRatioBased(fraction)
+	return PolicyFunc(func(spans []sdktrace.ReadOnlySpan) bool {
+		p := sdktrace.SamplingParameters{TraceID: spans[0].SpanContext().TraceID()}
+		return sampler.ShouldSample(p).Decision == sdktrace.RecordAndSample
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tailsampling"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spans(stubs ...tracetest.SpanStub) []sdktrace.ReadOnlySpan {
+	return tracetest.SpanStubs(stubs).Snapshots()
+}
+
+func TestErrorPolicy(t *testing.T) {
+	p := tailsampling.ErrorPolicy()
+	assert.False(t, p.ShouldSample(spans(
+		tracetest.SpanStub{Status: sdktrace.Status{Code: codes.Ok}},
+		tracetest.SpanStub{},
+	)))
+	assert.True(t, p.ShouldSample(spans(
+		tracetest.SpanStub{},
+		tracetest.SpanStub{Status: sdktrace.Status{Code: codes.Error}},
+	)))
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	start := time.Unix(100, 0)
+	p := tailsampling.LatencyPolicy(time.Second)
+
+	assert.False(t, p.ShouldSample(spans(
+		tracetest.SpanStub{StartTime: start, EndTime: start.Add(500 * time.Millisecond)},
+	)))
+	// The trace latency spans all its spans.
+	assert.True(t, p.ShouldSample(spans(
+		tracetest.SpanStub{StartTime: start.Add(500 * time.Millisecond), EndTime: start.Add(time.Second)},
+		tracetest.SpanStub{StartTime: start, EndTime: start.Add(500 * time.Millisecond)},
+	)))
+}
+
+func TestAttributePolicy(t *testing.T) {
+	tr := spans(
+		tracetest.SpanStub{Attributes: []attribute.KeyValue{attribute.String("user", "alice")}},
+		tracetest.SpanStub{Attributes: []attribute.KeyValue{attribute.StringSlice("tags", []string{"a", "b"})}},
+	)
+
+	assert.True(t, tailsampling.AttributePolicy("user").ShouldSample(tr))
+	assert.False(t, tailsampling.AttributePolicy("missing").ShouldSample(tr))
+	assert.True(t, tailsampling.AttributePolicy("user", attribute.StringValue("bob"), attribute.StringValue("alice")).ShouldSample(tr))
+	assert.False(t, tailsampling.AttributePolicy("user", attribute.StringValue("bob")).ShouldSample(tr))
+	assert.True(t, tailsampling.AttributePolicy("tags", attribute.StringSliceValue([]string{"a", "b"})).ShouldSample(tr))
+}
+
+func TestProbabilisticPolicy(t *testing.T) {
+	stub := func(id trace.TraceID) []sdktrace.ReadOnlySpan {
+		return spans(tracetest.SpanStub{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: id}),
+		})
+	}
+	low := trace.TraceID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}
+	high := trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	p := tailsampling.ProbabilisticPolicy(0.5)
+	assert.True(t, p.ShouldSample(stub(low)))
+	assert.False(t, p.ShouldSample(stub(high)))
+	assert.False(t, tailsampling.ProbabilisticPolicy(0).ShouldSample(stub(low)))
+	assert.True(t, tailsampling.ProbabilisticPolicy(1).ShouldSample(stub(high)))
+}
@@ -0,0 +1,325 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tailsampling provides a SpanProcessor that decides whether to
+// export a trace once its spans have ended.
+//
+// The SpanProcessor buffers ended spans per trace. A sampling decision is
+// made for a trace when its root span ends, or when the decision wait has
+// elapsed since the end of its first span. The policies are then evaluated
+// against all the buffered spans of the trace, and the whole trace is
+// exported if any policy samples it. Spans of a trace ending after its
+// decision are exported, or dropped, according to that decision.
+//
+// Spans need to be recorded, but not sampled, until the decision is made.
+// Configure the TracerProvider with the Sampler of this package so that all
+// spans are passed to the SpanProcessor without being exported by other
+// processors.
+package tailsampling // import "go.opentelemetry.io/otel/sdk/trace/tailsampling"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// minCheckInterval is the minimum period between two checks for traces
+// whose decision wait has elapsed.
+const minCheckInterval = 10 * time.Millisecond
+
+// pendingTrace holds the buffered spans of a trace awaiting a decision.
+type pendingTrace struct {
+	id       trace.TraceID
+	spans    []sdktrace.ReadOnlySpan
+	bytes    int
+	deadline time.Time
+	elem     *list.Element
+}
+
+// SpanProcessor is a SpanProcessor buffering spans per trace and exporting
+// the traces sampled by its policies.
+type SpanProcessor struct {
+	exporter sdktrace.SpanExporter
+	cfg      config
+
+	mu sync.Mutex
+	// pending maps the ID of undecided traces to their spans.
+	pending map[trace.TraceID]*pendingTrace
+	// order holds the pending traces, oldest first.
+	order *list.List
+	bytes int
+	// decisions holds the most recent sampling decisions, used for spans
+	// ending after the decision of their trace.
+	decisions     map[trace.TraceID]bool
+	decisionOrder []trace.TraceID
+	decisionNext  int
+	// ready holds the spans of sampled traces awaiting export.
+	ready   []sdktrace.ReadOnlySpan
+	stopped bool
+
+	exportMu sync.Mutex
+
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor exporting the traces sampled by
+// its policies to exporter. Without policies, no trace is exported.
+func NewSpanProcessor(exporter sdktrace.SpanExporter, opts ...Option) *SpanProcessor {
+	cfg := newConfig(opts...)
+	sp := &SpanProcessor{
+		exporter:      exporter,
+		cfg:           cfg,
+		pending:       make(map[trace.TraceID]*pendingTrace),
+		order:         list.New(),
+		decisions:     make(map[trace.TraceID]bool),
+		decisionOrder: make([]trace.TraceID, cfg.maxTraces),
+		wake:          make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go sp.run()
+	return sp
+}
+
+// OnStart does nothing.
+func (sp *SpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd buffers s until a sampling decision is made for its trace.
+func (sp *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	id := s.SpanContext().TraceID()
+	isRoot := !s.Parent().IsValid() || s.Parent().IsRemote()
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if sp.stopped {
+		return
+	}
+
+	if sampled, ok := sp.decisions[id]; ok {
+		if sampled {
+			sp.ready = append(sp.ready, s)
+			sp.notify()
+		}
+		return
+	}
+
+	t, ok := sp.pending[id]
+	if !ok {
+		t = &pendingTrace{
+			id:       id,
+			deadline: time.Now().Add(sp.cfg.decisionWait),
+		}
+		t.elem = sp.order.PushBack(t)
+		sp.pending[id] = t
+	}
+	size := spanSize(s)
+	t.spans = append(t.spans, s)
+	t.bytes += size
+	sp.bytes += size
+
+	if isRoot {
+		sp.decide(t)
+	}
+	for sp.order.Len() > sp.cfg.maxTraces || sp.bytes > sp.cfg.maxBytes {
+		sp.decide(sp.order.Front().Value.(*pendingTrace))
+	}
+}
+
+// ForceFlush makes a sampling decision for all buffered traces and exports
+// the sampled ones.
+func (sp *SpanProcessor) ForceFlush(ctx context.Context) error {
+	sp.mu.Lock()
+	for sp.order.Len() > 0 {
+		sp.decide(sp.order.Front().Value.(*pendingTrace))
+	}
+	sp.mu.Unlock()
+	return sp.export(ctx)
+}
+
+// Shutdown flushes all buffered traces and shuts down the exporter. The
+// SpanProcessor ignores spans ending after Shutdown is called.
+func (sp *SpanProcessor) Shutdown(ctx context.Context) error {
+	var err error
+	sp.stopOnce.Do(func() {
+		close(sp.stop)
+		<-sp.done
+
+		sp.mu.Lock()
+		sp.stopped = true
+		sp.mu.Unlock()
+		err = sp.ForceFlush(ctx)
+
+		if shutdownErr := sp.exporter.Shutdown(ctx); err == nil {
+			err = shutdownErr
+		}
+	})
+	return err
+}
+
+// run makes the sampling decisions of traces whose decision wait has elapsed
+// and exports sampled traces until the SpanProcessor is shut down.
+func (sp *SpanProcessor) run() {
+	defer close(sp.done)
+
+	interval := sp.cfg.decisionWait / 10
+	if interval < minCheckInterval {
+		interval = minCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sp.stop:
+			return
+		case <-ticker.C:
+			sp.decideExpired(time.Now())
+		case <-sp.wake:
+		}
+		sp.exportWithTimeout()
+	}
+}
+
+// exportWithTimeout exports the sampled traces within the configured export
+// timeout.
+func (sp *SpanProcessor) exportWithTimeout() {
+	ctx, cancel := context.WithTimeout(context.Background(), sp.cfg.exportTimeout)
+	defer cancel()
+	if err := sp.export(ctx); err != nil {
+		otel.Handle(err)
+	}
+}
+
+func (sp *SpanProcessor) decideExpired(now time.Time) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	// Traces are ordered by creation, and so by deadline.
+	for sp.order.Len() > 0 {
+		t := sp.order.Front().Value.(*pendingTrace)
+		if now.Before(t.deadline) {
+			return
+		}
+		sp.decide(t)
+	}
+}
+
+// decide evaluates the policies for t, removes it from the pending traces
+// and queues its spans for export if it is sampled. The mu lock must be held.
+func (sp *SpanProcessor) decide(t *pendingTrace) {
+	sampled := false
+	for _, p := range sp.cfg.policies {
+		if p.ShouldSample(t.spans) {
+			sampled = true
+			break
+		}
+	}
+
+	sp.order.Remove(t.elem)
+	delete(sp.pending, t.id)
+	sp.bytes -= t.bytes
+
+	// Remember the decision, evicting the oldest one if needed.
+	if evicted := sp.decisionOrder[sp.decisionNext]; evicted.IsValid() {
+		delete(sp.decisions, evicted)
+	}
+	sp.decisionOrder[sp.decisionNext] = t.id
+	sp.decisionNext = (sp.decisionNext + 1) % len(sp.decisionOrder)
+	sp.decisions[t.id] = sampled
+
+	if sampled {
+		sp.ready = append(sp.ready, t.spans...)
+		sp.notify()
+	}
+}
+
+// notify wakes up the export loop without blocking.
+func (sp *SpanProcessor) notify() {
+	select {
+	case sp.wake <- struct{}{}:
+	default:
+	}
+}
+
+// export sends all spans of sampled traces to the exporter.
+func (sp *SpanProcessor) export(ctx context.Context) error {
+	sp.exportMu.Lock()
+	defer sp.exportMu.Unlock()
+
+	sp.mu.Lock()
+	spans := sp.ready
+	sp.ready = nil
+	sp.mu.Unlock()
+
+	if len(spans) == 0 {
+		return nil
+	}
+	return sp.exporter.ExportSpans(ctx, spans)
+}
+
+// spanSize returns an estimate of the memory used by s, in bytes.
+func spanSize(s sdktrace.ReadOnlySpan) int {
+	// Fixed size fields: span context, parent, kind, times, status, counts.
+	const (
+		spanOverhead  = 256
+		eventOverhead = 64
+		linkOverhead  = 64
+	)
+	size := spanOverhead + len(s.Name()) + len(s.Status().Description)
+	size += attributesSize(s.Attributes())
+	for _, e := range s.Events() {
+		size += eventOverhead + len(e.Name) + attributesSize(e.Attributes)
+	}
+	for _, l := range s.Links() {
+		size += linkOverhead + attributesSize(l.Attributes)
+	}
+	return size
+}
+
+// attributesSize returns an estimate of the memory used by attrs, in bytes.
+func attributesSize(attrs []attribute.KeyValue) int {
+	// Size of the value type, numeric and string headers.
+	const kvOverhead = 48
+	size := 0
+	for _, kv := range attrs {
+		size += kvOverhead + len(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.STRING:
+			size += len(kv.Value.AsString())
+		case attribute.STRINGSLICE:
+			for _, v := range kv.Value.AsStringSlice() {
+				size += 16 + len(v)
+			}
+		case attribute.BOOLSLICE:
+			size += len(kv.Value.AsBoolSlice())
+		case attribute.INT64SLICE:
+			size += 8 * len(kv.Value.AsInt64Slice())
+		case attribute.FLOAT64SLICE:
+			size += 8 * len(kv.Value.AsFloat64Slice())
+		}
+	}
+	return size
+}
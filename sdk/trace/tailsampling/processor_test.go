@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tailsampling"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newProvider(t *testing.T, opts ...tailsampling.Option) (trace.Tracer, *tailsampling.SpanProcessor, *tracetest.InMemoryExporter) {
+	exp := tracetest.NewInMemoryExporter()
+	opts = append([]tailsampling.Option{
+		tailsampling.WithPolicies(tailsampling.ErrorPolicy()),
+	}, opts...)
+	sp := tailsampling.NewSpanProcessor(exp, opts...)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(tailsampling.Sampler()),
+		sdktrace.WithSpanProcessor(sp),
+	)
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(context.Background())) })
+	return tp.Tracer("tailsampling"), sp, exp
+}
+
+func spanNames(exp *tracetest.InMemoryExporter) []string {
+	var names []string
+	for _, s := range exp.GetSpans() {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func eventuallyExported(t *testing.T, exp *tracetest.InMemoryExporter, names ...string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return len(exp.GetSpans()) >= len(names)
+	}, time.Second, 5*time.Millisecond)
+	assert.ElementsMatch(t, names, spanNames(exp))
+}
+
+func TestSamplerRecordsWithoutSampling(t *testing.T) {
+	tracer, _, _ := newProvider(t)
+	_, span := tracer.Start(context.Background(), "span")
+	defer span.End()
+
+	assert.True(t, span.IsRecording())
+	assert.False(t, span.SpanContext().IsSampled())
+	assert.Equal(t, "TailSamplingRecordOnly", tailsampling.Sampler().Description())
+}
+
+func TestSpanProcessorDecidesOnRootEnd(t *testing.T) {
+	tracer, _, exp := newProvider(t)
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failure")
+	child.End()
+	assert.Empty(t, exp.GetSpans(), "spans exported before the decision")
+	root.End()
+	eventuallyExported(t, exp, "root", "child")
+
+	// Traces without errors are dropped.
+	exp.Reset()
+	ctx, root = tracer.Start(context.Background(), "ok-root")
+	_, child = tracer.Start(ctx, "ok-child")
+	child.End()
+	root.End()
+
+	// Spans ending after the decision follow it.
+	ctx, root = tracer.Start(context.Background(), "error-root")
+	_, late := tracer.Start(ctx, "late")
+	root.SetStatus(codes.Error, "failure")
+	root.End()
+	late.End()
+	eventuallyExported(t, exp, "error-root", "late")
+}
+
+func TestSpanProcessorDecisionWait(t *testing.T) {
+	tracer, _, exp := newProvider(t, tailsampling.WithDecisionWait(20*time.Millisecond))
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	defer root.End()
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failure")
+	child.End()
+
+	eventuallyExported(t, exp, "child")
+}
+
+func TestSpanProcessorMaxTraces(t *testing.T) {
+	tracer, _, exp := newProvider(t, tailsampling.WithMaxTraces(1))
+
+	for _, name := range []string{"first", "second"} {
+		ctx, root := tracer.Start(context.Background(), "root")
+		defer root.End()
+		_, child := tracer.Start(ctx, name)
+		child.SetStatus(codes.Error, "failure")
+		child.End()
+	}
+
+	// The first trace is decided early to make room for the second.
+	eventuallyExported(t, exp, "first")
+}
+
+func TestSpanProcessorMaxBytes(t *testing.T) {
+	tracer, _, exp := newProvider(t, tailsampling.WithMaxBytes(1))
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	defer root.End()
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failure")
+	child.End()
+
+	eventuallyExported(t, exp, "child")
+}
+
+func TestSpanProcessorForceFlush(t *testing.T) {
+	tracer, sp, exp := newProvider(t)
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	defer root.End()
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failure")
+	child.End()
+
+	require.NoError(t, sp.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"child"}, spanNames(exp))
+}
+
+func TestSpanProcessorShutdown(t *testing.T) {
+	var exported []string
+	sp := tailsampling.NewSpanProcessor(
+		exporterFunc(func(spans []sdktrace.ReadOnlySpan) {
+			for _, s := range spans {
+				exported = append(exported, s.Name())
+			}
+		}),
+		tailsampling.WithPolicies(tailsampling.PolicyFunc(func([]sdktrace.ReadOnlySpan) bool { return true })),
+	)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(tailsampling.Sampler()),
+		sdktrace.WithSpanProcessor(sp),
+	)
+	tracer := tp.Tracer("tailsampling")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	// Buffered traces are flushed on shutdown.
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child"}, exported)
+
+	// Spans ending after shutdown are ignored.
+	root.End()
+	assert.Equal(t, []string{"child"}, exported)
+}
+
+func TestSpanProcessorExportTimeout(t *testing.T) {
+	deadlines := make(chan time.Time, 1)
+	sp := tailsampling.NewSpanProcessor(
+		ctxExporter(func(ctx context.Context) {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok, "export without a deadline")
+			deadlines <- deadline
+		}),
+		tailsampling.WithExportTimeout(time.Minute),
+		tailsampling.WithPolicies(tailsampling.PolicyFunc(func([]sdktrace.ReadOnlySpan) bool { return true })),
+	)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(tailsampling.Sampler()),
+		sdktrace.WithSpanProcessor(sp),
+	)
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	start := time.Now()
+	_, root := tp.Tracer("tailsampling").Start(context.Background(), "root")
+	root.End()
+
+	select {
+	case deadline := <-deadlines:
+		assert.WithinDuration(t, start.Add(time.Minute), deadline, 10*time.Second)
+	case <-time.After(time.Second):
+		t.Fatal("sampled trace not exported")
+	}
+}
+
+type exporterFunc func([]sdktrace.ReadOnlySpan)
+
+func (f exporterFunc) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	f(spans)
+	return nil
+}
+
+func (f exporterFunc) Shutdown(context.Context) error { return nil }
+
+type ctxExporter func(context.Context)
+
+func (f ctxExporter) ExportSpans(ctx context.Context, _ []sdktrace.ReadOnlySpan) error {
+	f(ctx)
+	return nil
+}
+
+func (f ctxExporter) Shutdown(context.Context) error { return nil }
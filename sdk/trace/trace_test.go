@@ -1659,6 +1659,61 @@ func TestAddLinksWithMoreAttributesThanLimit(t *testing.T) {
 	}
 }
 
+func TestAttributeValueLengthLimit(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSpanLimits(SpanLimits{AttributeValueLengthLimit: 3}),
+		WithSyncer(te),
+		WithResource(resource.Empty()),
+	)
+
+	sc1 := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1}), SpanID: trace.SpanID{3}})
+	linkAttrs := []attribute.KeyValue{attribute.String("link", "abcdef")}
+	eventAttrs := []attribute.KeyValue{attribute.String("event", "abcdef")}
+	span := startSpan(tp, "AttributeValueLengthLimit", trace.WithLinks(trace.Link{
+		SpanContext: sc1,
+		Attributes:  linkAttrs,
+	}))
+	span.SetAttributes(
+		attribute.String("string", "abcdef"),
+		attribute.String("short", "ab"),
+		// Truncation is done by character, not byte.
+		attribute.String("unicode", "ààààà"),
+		attribute.StringSlice("slice", []string{"abcdef", "ab"}),
+		attribute.Int64("int", 123456),
+		attribute.BoolSlice("bools", []bool{true, false, true, false}),
+	)
+	span.AddEvent("event", trace.WithAttributes(eventAttrs...))
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("string", "abc"),
+		attribute.String("short", "ab"),
+		attribute.String("unicode", "ààà"),
+		attribute.StringSlice("slice", []string{"abc", "ab"}),
+		attribute.Int64("int", 123456),
+		attribute.BoolSlice("bools", []bool{true, false, true, false}),
+	}, got.Attributes())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("link", "abc")}, got.Links()[0].Attributes)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("event", "abc")}, got.Events()[0].Attributes)
+	assert.Equal(t, 5, got.TruncatedAttributes())
+
+	// Values passed by the caller must not be modified.
+	assert.Equal(t, "abcdef", linkAttrs[0].Value.AsString())
+	assert.Equal(t, "abcdef", eventAttrs[0].Value.AsString())
+}
+
+func TestAttributeValueLengthLimitStringSliceNotModified(t *testing.T) {
+	values := []string{"abcdef"}
+	kv := attribute.StringSlice("slice", values)
+	got, truncated := truncateAttr(2, kv)
+	assert.True(t, truncated)
+	assert.Equal(t, []string{"ab"}, got.Value.AsStringSlice())
+	assert.Equal(t, []string{"abcdef"}, kv.Value.AsStringSlice())
+}
+
 type stateSampler struct {
 	prefix string
 	f      func(trace.TraceState) trace.TraceState
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracemetric // import "go.opentelemetry.io/otel/sdk/trace/tracemetric"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// ReasonKey is the attribute key of the reason of an export failure.
+const ReasonKey = attribute.Key("reason")
+
+// Export failure reasons.
+const (
+	// ReasonTimeout is the reason of exports failing because their deadline
+	// was exceeded.
+	ReasonTimeout = "timeout"
+	// ReasonCanceled is the reason of exports failing because their
+	// context was canceled.
+	ReasonCanceled = "canceled"
+	// ReasonError is the reason of all other export failures.
+	ReasonError = "error"
+)
+
+// recorder records the outcome and duration of span exports.
+type recorder struct {
+	exported metric.Int64Counter
+	failures metric.Int64Counter
+	duration metric.Float64Histogram
+	attrs    []attribute.KeyValue
+}
+
+// newRecorder returns a recorder whose instruments are created with meter
+// and named with prefix. The attrs are added to all measurements.
+//
+// The following instruments are created:
+//   - prefix.spans.exported: the number of spans exported successfully.
+//   - prefix.export.failures: the number of failed exports, by reason.
+//   - prefix.export.duration: the duration of exports, in milliseconds.
+func newRecorder(meter metric.Meter, prefix string, attrs ...attribute.KeyValue) *recorder {
+	r := &recorder{attrs: attrs}
+
+	var err error
+	r.exported, err = meter.NewInt64Counter(
+		prefix+".spans.exported",
+		metric.WithDescription("Number of spans exported successfully"),
+	)
+	handle(err)
+	r.failures, err = meter.NewInt64Counter(
+		prefix+".export.failures",
+		metric.WithDescription("Number of failed exports"),
+	)
+	handle(err)
+	r.duration, err = meter.NewFloat64Histogram(
+		prefix+".export.duration",
+		metric.WithDescription("Duration of exports"),
+		metric.WithUnit(unit.Milliseconds),
+	)
+	handle(err)
+	return r
+}
+
+// record records the export of n spans that took d. The export failed if
+// err is not nil.
+func (r *recorder) record(ctx context.Context, n int, d time.Duration, err error) {
+	elapsed := float64(d) / float64(time.Millisecond)
+	if err != nil {
+		attrs := make([]attribute.KeyValue, 0, len(r.attrs)+1)
+		attrs = append(attrs, r.attrs...)
+		attrs = append(attrs, ReasonKey.String(Reason(err)))
+		r.failures.Add(ctx, 1, attrs...)
+		r.duration.Record(ctx, elapsed, attrs...)
+		return
+	}
+	r.exported.Add(ctx, int64(n), r.attrs...)
+	r.duration.Record(ctx, elapsed, r.attrs...)
+}
+
+// Reason returns the reason of the export failure err.
+func Reason(err error) string {
+	var timeout interface{ Timeout() bool }
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	case errors.Is(err, context.Canceled):
+		return ReasonCanceled
+	case errors.As(err, &timeout) && timeout.Timeout():
+		return ReasonTimeout
+	}
+	return ReasonError
+}
+
+// handle sends err to the global error handler if it is not nil. The
+// instruments returned along with an error are still usable.
+func handle(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracemetric reports metrics about the span processors and
+// exporters of the trace SDK, so a telemetry pipeline can monitor itself.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package tracemetric // import "go.opentelemetry.io/otel/sdk/trace/tracemetric"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const instrumentationName = "go.opentelemetry.io/otel/sdk/trace/tracemetric"
+
+func meter(mp metric.MeterProvider) metric.Meter {
+	return mp.Meter(instrumentationName, metric.WithInstrumentationVersion(otel.Version()))
+}
+
+// WithMeterProvider returns a BatchSpanProcessorOption that reports the
+// queue size and capacity (otel.bsp.queue.size, otel.bsp.queue.capacity),
+// the spans dropped and exported (otel.bsp.spans.dropped,
+// otel.bsp.spans.exported), the failed exports by reason
+// (otel.bsp.export.failures), and the duration of exports
+// (otel.bsp.export.duration) of a batch span processor with mp.
+//
+// The hooks set on the processor before this option are still called.
+func WithMeterProvider(mp metric.MeterProvider) sdktrace.BatchSpanProcessorOption {
+	m := meter(mp)
+	exports := newRecorder(m, "otel.bsp")
+	dropped, err := m.NewInt64Counter(
+		"otel.bsp.spans.dropped",
+		metric.WithDescription("Number of spans dropped because the queue was full"),
+	)
+	handle(err)
+
+	return func(o *sdktrace.BatchSpanProcessorOptions) {
+		prev := o.Hooks
+		o.Hooks = sdktrace.BatchSpanProcessorHooks{
+			RegisterQueue: func(length func() int, capacity int) {
+				observeQueue(m, length, capacity)
+				if prev.RegisterQueue != nil {
+					prev.RegisterQueue(length, capacity)
+				}
+			},
+			OnDrop: func() {
+				dropped.Add(context.Background(), 1)
+				if prev.OnDrop != nil {
+					prev.OnDrop()
+				}
+			},
+			OnExport: func(ctx context.Context, spans int, d time.Duration, err error) {
+				exports.record(ctx, spans, d, err)
+				if prev.OnExport != nil {
+					prev.OnExport(ctx, spans, d, err)
+				}
+			},
+		}
+	}
+}
+
+// observeQueue creates the observers of the length and capacity of the
+// queue of a batch span processor.
+func observeQueue(m metric.Meter, length func() int, capacity int) {
+	_, err := m.NewInt64GaugeObserver(
+		"otel.bsp.queue.size",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			result.Observe(int64(length()))
+		},
+		metric.WithDescription("Number of spans in the queue"),
+	)
+	handle(err)
+	_, err = m.NewInt64GaugeObserver(
+		"otel.bsp.queue.capacity",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			result.Observe(int64(capacity))
+		},
+		metric.WithDescription("Maximum number of spans in the queue"),
+	)
+	handle(err)
+}
+
+// exporter is a SpanExporter reporting on the exports of the SpanExporter
+// it wraps.
+type exporter struct {
+	sdktrace.SpanExporter
+
+	metrics *recorder
+}
+
+var _ sdktrace.SpanExporter = (*exporter)(nil)
+
+// NewExporter returns a SpanExporter that exports spans with exp and
+// reports the spans it exported (otel.exporter.spans.exported), its failed
+// exports by reason (otel.exporter.export.failures), and the duration of its
+// exports (otel.exporter.export.duration) with mp. The measurements have an
+// exporter attribute set to name, such as "jaeger", "zipkin" or "otlptrace".
+func NewExporter(exp sdktrace.SpanExporter, name string, mp metric.MeterProvider) sdktrace.SpanExporter {
+	return &exporter{
+		SpanExporter: exp,
+		metrics:      newRecorder(meter(mp), "otel.exporter", attribute.String("exporter", name)),
+	}
+}
+
+// ExportSpans exports spans with the wrapped SpanExporter and records the
+// outcome and duration of the export.
+func (e *exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	start := time.Now()
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.metrics.record(ctx, len(spans), time.Since(start), err)
+	return err
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracemetric_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/metrictest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracemetric"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestReason(t *testing.T) {
+	assert.Equal(t, tracemetric.ReasonTimeout, tracemetric.Reason(context.DeadlineExceeded))
+	assert.Equal(t, tracemetric.ReasonTimeout, tracemetric.Reason(fmt.Errorf("upload: %w", timeoutError{})))
+	assert.Equal(t, tracemetric.ReasonCanceled, tracemetric.Reason(fmt.Errorf("upload: %w", context.Canceled)))
+	assert.Equal(t, tracemetric.ReasonError, tracemetric.Reason(errors.New("failure")))
+}
+
+func measured(mp *metrictest.MeterProvider) map[string][]metrictest.Measured {
+	got := map[string][]metrictest.Measured{}
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		got[m.Name] = append(got[m.Name], m)
+	}
+	return got
+}
+
+// blockingExporter blocks exports until release is closed. The first export
+// fails with err.
+type blockingExporter struct {
+	started chan struct{}
+	release chan struct{}
+	err     error
+	calls   int
+}
+
+func (e *blockingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	select {
+	case e.started <- struct{}{}:
+	default:
+	}
+	<-e.release
+	e.calls++
+	if e.calls == 1 {
+		return e.err
+	}
+	return nil
+}
+
+func (e *blockingExporter) Shutdown(context.Context) error { return nil }
+
+func TestWithMeterProvider(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+	exp := &blockingExporter{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+		err:     context.DeadlineExceeded,
+	}
+	var dropped int
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxQueueSize(2),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithBatchSpanProcessorHooks(sdktrace.BatchSpanProcessorHooks{
+			OnDrop: func() { dropped++ },
+		}),
+		tracemetric.WithMeterProvider(mp),
+	)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(bsp))
+	tr := tp.Tracer("WithMeterProvider")
+
+	// The first span is dequeued and blocks in the exporter.
+	_, span := tr.Start(context.Background(), "first")
+	span.End()
+	<-exp.started
+
+	// Two spans fill the queue and two are dropped.
+	for i := 0; i < 4; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+	mp.RunAsyncInstruments()
+
+	close(exp.release)
+	require.NoError(t, bsp.ForceFlush(context.Background()))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	// Hooks set before the option are kept.
+	assert.Equal(t, 2, dropped)
+
+	sums := map[string]int64{}
+	got := measured(mp)
+	for name, ms := range got {
+		for _, m := range ms {
+			if name != "otel.bsp.export.duration" {
+				sums[name] += m.Number.AsInt64()
+			}
+		}
+	}
+	assert.Equal(t, int64(2), sums["otel.bsp.queue.size"])
+	assert.Equal(t, int64(2), sums["otel.bsp.queue.capacity"])
+	assert.Equal(t, int64(2), sums["otel.bsp.spans.dropped"])
+	assert.Equal(t, int64(2), sums["otel.bsp.spans.exported"])
+	assert.Equal(t, int64(1), sums["otel.bsp.export.failures"])
+	require.Len(t, got["otel.bsp.export.failures"], 1)
+	assert.Equal(t, attribute.StringValue(tracemetric.ReasonTimeout), got["otel.bsp.export.failures"][0].Labels[tracemetric.ReasonKey])
+	assert.Len(t, got["otel.bsp.export.duration"], 3)
+}
+
+type errExporter struct {
+	*tracetest.InMemoryExporter
+	err error
+}
+
+func (e errExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func TestNewExporter(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+	mem := tracetest.NewInMemoryExporter()
+	spans := tracetest.SpanStubs{{Name: "s1"}, {Name: "s2"}}.Snapshots()
+
+	exp := tracemetric.NewExporter(mem, "test", mp)
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+	assert.Len(t, mem.GetSpans(), 2)
+
+	exp = tracemetric.NewExporter(errExporter{mem, fmt.Errorf("upload: %w", context.Canceled)}, "test", mp)
+	require.Error(t, exp.ExportSpans(context.Background(), spans))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	got := measured(mp)
+	for _, ms := range got {
+		for _, m := range ms {
+			assert.Equal(t, attribute.StringValue("test"), m.Labels["exporter"])
+		}
+	}
+	require.Len(t, got["otel.exporter.spans.exported"], 1)
+	assert.Equal(t, int64(2), got["otel.exporter.spans.exported"][0].Number.AsInt64())
+	require.Len(t, got["otel.exporter.export.failures"], 1)
+	assert.Equal(t, attribute.StringValue(tracemetric.ReasonCanceled), got["otel.exporter.export.failures"][0].Labels[tracemetric.ReasonKey])
+	assert.Len(t, got["otel.exporter.export.duration"], 2)
+}